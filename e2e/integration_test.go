@@ -116,8 +116,8 @@ func testStopAction(server *mockGcpServer) func(t *testing.T, m *e2e.Minikube, e
 			nil,
 		)
 		require.NoError(t, err)
-		// The action is instantaneous (no status poll, no stop hook) so exec.Wait() would
-		// block forever. Cancelling releases the harness goroutine immediately.
+		// The mock reports the operation DONE on the first status poll. Cancelling still releases the
+		// harness goroutine in case the assertion below finishes before the action does.
 		defer func() { _ = exec.Cancel() }()
 
 		require.Eventually(t, func() bool {
@@ -49,6 +49,14 @@ var routes = []route{
 			m.recordStop(match[1], match[2], match[3])
 			writeJSON(w, computeOperation("stop", match[2]))
 		}},
+	{http.MethodGet, regexp.MustCompile(`^/compute/v1/projects/([^/]+)/zones/([^/]+)/operations/([^/]+)$`),
+		func(_ *mockGcpServer, w http.ResponseWriter, match []string) {
+			writeJSON(w, computeOperation("stop", match[2]))
+		}},
+	{http.MethodGet, regexp.MustCompile(`^/compute/v1/projects/([^/]+)/zones/([^/]+)/instances/([^/]+)$`),
+		func(_ *mockGcpServer, w http.ResponseWriter, match []string) {
+			writeJSON(w, instance(match[3], "TERMINATED"))
+		}},
 	{http.MethodGet, regexp.MustCompile(`^/compute/v1/projects/([^/]+)/aggregated/routers$`),
 		func(_ *mockGcpServer, w http.ResponseWriter, match []string) {
 			writeJSON(w, routersAggregatedList(match[1]))
@@ -255,6 +263,15 @@ func sqlInstancesList() string {
 }`
 }
 
+func instance(name, status string) string {
+	return fmt.Sprintf(`{
+  "kind": "compute#instance",
+  "id": "1",
+  "name": "%s",
+  "status": "%s"
+}`, name, status)
+}
+
 func computeOperation(opType, zone string) string {
 	return fmt.Sprintf(`{
   "kind": "compute#operation",
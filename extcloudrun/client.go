@@ -0,0 +1,57 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/steadybit/extension-gcp/utils"
+)
+
+// servicesApi is the part of the Cloud Run Admin API the service attacks use. UpdateService hands back the name of
+// its long-running operation so Status and Stop can follow an update started in an earlier call.
+type servicesApi interface {
+	GetService(ctx context.Context, name string) (*runpb.Service, error)
+	UpdateService(ctx context.Context, service *runpb.Service) (string, error)
+	// PollUpdate reports whether the operation is done. An error with done=true is the operation's own failure;
+	// with done=false the poll itself failed.
+	PollUpdate(ctx context.Context, operation string) (bool, error)
+}
+
+type cloudRunServicesClient struct {
+	client *run.ServicesClient
+}
+
+func newServicesApi(ctx context.Context, projectID string) (servicesApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := run.NewServicesClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cloudRunServicesClient{client: c}, func() { _ = c.Close() }, nil
+}
+
+func (c *cloudRunServicesClient) GetService(ctx context.Context, name string) (*runpb.Service, error) {
+	return c.client.GetService(ctx, &runpb.GetServiceRequest{Name: name})
+}
+
+func (c *cloudRunServicesClient) UpdateService(ctx context.Context, service *runpb.Service) (string, error) {
+	op, err := c.client.UpdateService(ctx, &runpb.UpdateServiceRequest{Service: service})
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *cloudRunServicesClient) PollUpdate(ctx context.Context, operation string) (bool, error) {
+	op := c.client.UpdateServiceOperation(operation)
+	_, err := op.Poll(ctx)
+	return op.Done(), err
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/steadybit/extension-gcp/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeServices serves services from a map and applies updates immediately. Operations finish on their first poll
+// unless pendingPolls says otherwise; opErrors fails them once done.
+type fakeServices struct {
+	services     map[string]*runpb.Service
+	pendingPolls map[string]int
+	opErrors     map[string]error
+	updates      []*runpb.Service
+	nextOp       int
+}
+
+func newFakeServices() *fakeServices {
+	return &fakeServices{
+		services:     map[string]*runpb.Service{},
+		pendingPolls: map[string]int{},
+		opErrors:     map[string]error{},
+	}
+}
+
+func (f *fakeServices) provider() func(context.Context, string) (servicesApi, func(), error) {
+	return func(context.Context, string) (servicesApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeServices) GetService(_ context.Context, name string) (*runpb.Service, error) {
+	if svc, ok := f.services[name]; ok {
+		return proto.Clone(svc).(*runpb.Service), nil
+	}
+	return nil, fmt.Errorf("service %s not found", name)
+}
+
+func (f *fakeServices) UpdateService(_ context.Context, service *runpb.Service) (string, error) {
+	f.updates = append(f.updates, service)
+	f.services[service.Name] = proto.Clone(service).(*runpb.Service)
+	f.nextOp++
+	return fmt.Sprintf("operations/op-%d", f.nextOp), nil
+}
+
+func (f *fakeServices) PollUpdate(_ context.Context, operation string) (bool, error) {
+	if f.pendingPolls[operation] > 0 {
+		f.pendingPolls[operation]--
+		return false, nil
+	}
+	return true, f.opErrors[operation]
+}
+
+func withFastOperationPolling(t *testing.T) {
+	previous := utils.OperationPollInterval
+	utils.OperationPollInterval = time.Millisecond
+	t.Cleanup(func() { utils.OperationPollInterval = previous })
+}
@@ -5,11 +5,24 @@
 package extcloudrun
 
 const (
-	TargetIDService = "com.steadybit.extension_gcp.cloudrun.service"
-	targetIcon      = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMTQ0LjQsMjcyYy02LjQsMC0xMi40LTMuOC0xNC45LTEwLjFMNTUuNCw3NS45Yy0zLjMtOC4yLjctMTcuNSw4LjktMjAuOCw4LjItMy4zLDE3LjUuNywyMC44LDguOWw3NC4yLDE4NmMzLjMsOC4yLS43LDE3LjUtOC45LDIwLjgtMS45LjgtMy45LDEuMS01LjksMS4xaC0uMVoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDI3MmMtNi40LDAtMTIuNC0zLjgtMTQuOS0xMC4xbC03NC4xLTE4NmMtMi42LTYuNi0uNi0xNC4xLDUtMTguNXMxMy40LTQuNSwxOS4yLS40bDI2MC4xLDE4NmM3LjIsNS4xLDguOSwxNS4xLDMuNywyMi4zcy0xNS4xLDguOS0yMi4zLDMuN0wyMTYuOSwxMTQuN2w1NCwxMzUuM2MzLjMsOC4yLS43LDE3LjUtOC45LDIwLjgtMS45LjgtNCwxLjEtNS45LDEuMWgtLjFaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEyNy4yLDI1NmwtNzIsMTgwYy0zLjMsOC4yLjcsMTcuNSw4LjksMjAuOCwzLjEsMS4yLDQsMS4xLDUuOSwxLjEsNi4zLDAsMTIuNC0zLjgsMTQuOS0xMC4xbDc0LjQtMTg2Yy44LTIsMS4xLTQsMS4xLTUuOWgtMzMuMloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDE0LjUsMjU2bC0xOTcuNywxNDEuMiw1NC4xLTEzNS4zYy44LTIsMS4xLTQsMS4xLTUuOWgtMzMuMmwtNzIsMTgwYy0yLjYsNi42LS42LDE0LjEsNSwxOC41LDIuOSwyLjMsNi40LDMuNCw5LjksMy40czYuNS0xLDkuMy0zbDI2MC40LTE4NmM0LjQtMy4xLDYuNy04LDYuNy0xM2gtNDMuNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KPC9zdmc+"
+	TargetIDService                = "com.steadybit.extension_gcp.cloudrun.service"
+	TargetIDRevision               = "com.steadybit.extension_gcp.cloudrun.revision"
+	TargetIDJob                    = "com.steadybit.extension_gcp.cloudrun.job"
+	TargetIDExecution              = "com.steadybit.extension_gcp.cloudrun.execution"
+	ServiceTrafficShiftActionId    = "com.steadybit.extension_gcp.cloudrun.service.traffic-shift"
+	ServiceScalingClampActionId    = "com.steadybit.extension_gcp.cloudrun.service.scaling-clamp"
+	ServiceIngressLockdownActionId = "com.steadybit.extension_gcp.cloudrun.service.ingress-lockdown"
+	targetIcon                     = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMTQ0LjQsMjcyYy02LjQsMC0xMi40LTMuOC0xNC45LTEwLjFMNTUuNCw3NS45Yy0zLjMtOC4yLjctMTcuNSw4LjktMjAuOCw4LjItMy4zLDE3LjUuNywyMC44LDguOWw3NC4yLDE4NmMzLjMsOC4yLS43LDE3LjUtOC45LDIwLjgtMS45LjgtMy45LDEuMS01LjksMS4xaC0uMVoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDI3MmMtNi40LDAtMTIuNC0zLjgtMTQuOS0xMC4xbC03NC4xLTE4NmMtMi42LTYuNi0uNi0xNC4xLDUtMTguNXMxMy40LTQuNSwxOS4yLS40bDI2MC4xLDE4NmM3LjIsNS4xLDguOSwxNS4xLDMuNywyMi4zcy0xNS4xLDguOS0yMi4zLDMuN0wyMTYuOSwxMTQuN2w1NCwxMzUuM2MzLjMsOC4yLS43LDE3LjUtOC45LDIwLjgtMS45LjgtNCwxLjEtNS45LDEuMWgtLjFaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEyNy4yLDI1NmwtNzIsMTgwYy0zLjMsOC4yLjcsMTcuNSw4LjksMjAuOCwzLjEsMS4yLDQsMS4xLDUuOSwxLjEsNi4zLDAsMTIuNC0zLjgsMTQuOS0xMC4xbDc0LjQtMTg2Yy44LTIsMS4xLTQsMS4xLTUuOWgtMzMuMloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDE0LjUsMjU2bC0xOTcuNywxNDEuMiw1NC4xLTEzNS4zYy44LTIsMS4xLTQsMS4xLTUuOWgtMzMuMmwtNzIsMTgwYy0yLjYsNi42LS42LDE0LjEsNSwxOC41LDIuOSwyLjMsNi40LDMuNCw5LjksMy40czYuNS0xLDkuMy0zbDI2MC40LTE4NmM0LjQtMy4xLDYuNy04LDYuNy0xM2gtNDMuNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KPC9zdmc+"
 
 	// Attribute names extracted per Sonar go:S1192.
-	attrLocation  = "gcp.cloudrun.service.location"
-	attrIngress   = "gcp.cloudrun.service.ingress"
-	attrProjectID = "gcp.project.id"
+	attrServiceName = "gcp.cloudrun.service.name"
+	attrLocation    = "gcp.cloudrun.service.location"
+	attrIngress     = "gcp.cloudrun.service.ingress"
+	attrProjectID   = "gcp.project.id"
+
+	attrRevisionName           = "gcp.cloudrun.revision.name"
+	attrRevisionTrafficPercent = "gcp.cloudrun.revision.traffic-percent"
+	attrJobName                = "gcp.cloudrun.job.name"
+	attrJobLocation            = "gcp.cloudrun.job.location"
+	attrExecutionName          = "gcp.cloudrun.execution.name"
 )
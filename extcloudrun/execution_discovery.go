@@ -0,0 +1,184 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+type executionDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*executionDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*executionDiscovery)(nil)
+)
+
+func NewExecutionDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&executionDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *executionDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDExecution,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *executionDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDExecution,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Cloud Run job execution", Other: "Cloud Run job executions"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrJobName},
+				{Attribute: "gcp.cloudrun.execution.running-count"},
+				{Attribute: attrJobLocation},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *executionDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrExecutionName, Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution name", Other: "Cloud Run execution names"}},
+		{Attribute: "gcp.cloudrun.execution.task-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution task count", Other: "Cloud Run execution task counts"}},
+		{Attribute: "gcp.cloudrun.execution.parallelism", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution parallelism", Other: "Cloud Run execution parallelisms"}},
+		{Attribute: "gcp.cloudrun.execution.running-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution running tasks", Other: "Cloud Run execution running tasks"}},
+		{Attribute: "gcp.cloudrun.execution.succeeded-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution succeeded tasks", Other: "Cloud Run execution succeeded tasks"}},
+		{Attribute: "gcp.cloudrun.execution.failed-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution failed tasks", Other: "Cloud Run execution failed tasks"}},
+		{Attribute: "gcp.cloudrun.execution.retried-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution retried tasks", Other: "Cloud Run execution retried tasks"}},
+		{Attribute: "gcp.cloudrun.execution.start-time", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution start time", Other: "Cloud Run execution start times"}},
+		{Attribute: "gcp.cloudrun.execution.container.image", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution container image", Other: "Cloud Run execution container images"}},
+		{Attribute: "gcp.cloudrun.execution.container.image-digest", Label: discovery_kit_api.PluralLabel{One: "Cloud Run execution container image digest", Other: "Cloud Run execution container image digests"}},
+	}
+}
+
+func (d *executionDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		jobs, err := run.NewJobsClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Run jobs client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = jobs.Close() }()
+		executions, err := run.NewExecutionsClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Run executions client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = executions.Close() }()
+		return getAllRunningExecutions(ctx, jobs, executions, access.ProjectID)
+	}, ctx, "cloudrun-execution")
+}
+
+// getAllRunningExecutions only emits executions that have not completed yet. Finished executions pile up by the
+// thousands and cannot be attacked anymore, so executions are only listed for jobs whose latest execution is still
+// running; an older execution outliving a newer one is not discovered.
+func getAllRunningExecutions(ctx context.Context, jobs *run.JobsClient, executions *run.ExecutionsClient, projectID string) ([]discovery_kit_api.Target, error) {
+	targets := make([]discovery_kit_api.Target, 0)
+	it := jobs.ListJobs(ctx, &runpb.ListJobsRequest{Parent: fmt.Sprintf("projects/%s/locations/-", projectID)})
+	for {
+		j, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).Msg("Failed to list Cloud Run jobs")
+			return nil, err
+		}
+		if !latestExecutionRunning(j) {
+			continue
+		}
+		execIt := executions.ListExecutions(ctx, &runpb.ListExecutionsRequest{Parent: j.Name})
+		for {
+			e, err := execIt.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				log.Warn().Err(err).Str("job", j.Name).Msg("Failed to list Cloud Run executions")
+				return nil, err
+			}
+			if e.CompletionTime != nil {
+				continue
+			}
+			targets = append(targets, toExecutionTarget(e, projectID))
+		}
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesCloudRunJob), nil
+}
+
+func latestExecutionRunning(j *runpb.Job) bool {
+	latest := j.GetLatestCreatedExecution()
+	if latest == nil || latest.GetCompletionTime() != nil {
+		return false
+	}
+	switch latest.GetCompletionStatus() {
+	case runpb.ExecutionReference_EXECUTION_SUCCEEDED, runpb.ExecutionReference_EXECUTION_FAILED, runpb.ExecutionReference_EXECUTION_CANCELLED:
+		return false
+	}
+	return true
+}
+
+func toExecutionTarget(e *runpb.Execution, projectID string) discovery_kit_api.Target {
+	// e.Name is "projects/<p>/locations/<region>/jobs/<job>/executions/<execution>"
+	location, job := parseResourceName(e.Name, "jobs")
+	_, name := parseResourceName(e.Name, "executions")
+
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrJobName] = []string{job}
+	if location != "" {
+		attributes[attrJobLocation] = []string{location}
+	}
+	attributes[attrExecutionName] = []string{name}
+	attributes["gcp.cloudrun.execution.task-count"] = []string{strconv.Itoa(int(e.TaskCount))}
+	if e.Parallelism > 0 {
+		attributes["gcp.cloudrun.execution.parallelism"] = []string{strconv.Itoa(int(e.Parallelism))}
+	}
+	attributes["gcp.cloudrun.execution.running-count"] = []string{strconv.Itoa(int(e.RunningCount))}
+	attributes["gcp.cloudrun.execution.succeeded-count"] = []string{strconv.Itoa(int(e.SucceededCount))}
+	attributes["gcp.cloudrun.execution.failed-count"] = []string{strconv.Itoa(int(e.FailedCount))}
+	attributes["gcp.cloudrun.execution.retried-count"] = []string{strconv.Itoa(int(e.RetriedCount))}
+	if e.StartTime != nil {
+		attributes["gcp.cloudrun.execution.start-time"] = []string{e.StartTime.AsTime().UTC().Format(time.RFC3339)}
+	}
+	if task := e.Template; task != nil {
+		addContainerAttributes(attributes, "gcp.cloudrun.execution.container", task.Containers)
+	}
+	for k, v := range e.Labels {
+		attributes[fmt.Sprintf("gcp.cloudrun.execution.label.%s", strings.ToLower(k))] = []string{v}
+	}
+
+	return discovery_kit_api.Target{
+		Id:         e.Name,
+		TargetType: TargetIDExecution,
+		Label:      name,
+		Attributes: attributes,
+	}
+}
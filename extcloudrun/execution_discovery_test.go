@@ -0,0 +1,34 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLatestExecutionRunning(t *testing.T) {
+	completedAt := timestamppb.New(time.Date(2026, 3, 1, 2, 10, 0, 0, time.UTC))
+	tests := []struct {
+		name   string
+		latest *runpb.ExecutionReference
+		want   bool
+	}{
+		{name: "never executed", latest: nil, want: false},
+		{name: "running", latest: &runpb.ExecutionReference{CompletionStatus: runpb.ExecutionReference_EXECUTION_RUNNING}, want: true},
+		{name: "pending", latest: &runpb.ExecutionReference{CompletionStatus: runpb.ExecutionReference_EXECUTION_PENDING}, want: true},
+		{name: "completed", latest: &runpb.ExecutionReference{CompletionTime: completedAt}, want: false},
+		{name: "failed", latest: &runpb.ExecutionReference{CompletionStatus: runpb.ExecutionReference_EXECUTION_FAILED}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, latestExecutionRunning(&runpb.Job{LatestCreatedExecution: tt.latest}))
+		})
+	}
+}
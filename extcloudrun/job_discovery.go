@@ -0,0 +1,195 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+type jobDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber          = (*jobDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber       = (*jobDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*jobDiscovery)(nil)
+)
+
+func NewJobDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&jobDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *jobDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDJob,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *jobDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDJob,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Cloud Run job", Other: "Cloud Run jobs"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrJobLocation},
+				{Attribute: "gcp.cloudrun.job.latest-execution.completion-status"},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *jobDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrJobName, Label: discovery_kit_api.PluralLabel{One: "Cloud Run job name", Other: "Cloud Run job names"}},
+		{Attribute: attrJobLocation, Label: discovery_kit_api.PluralLabel{One: "Cloud Run job location", Other: "Cloud Run job locations"}},
+		{Attribute: "gcp.cloudrun.job.execution-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job execution count", Other: "Cloud Run job execution counts"}},
+		{Attribute: "gcp.cloudrun.job.latest-execution.name", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job latest execution", Other: "Cloud Run job latest executions"}},
+		{Attribute: "gcp.cloudrun.job.latest-execution.completion-status", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job latest execution status", Other: "Cloud Run job latest execution statuses"}},
+		{Attribute: "gcp.cloudrun.job.latest-execution.create-time", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job latest execution creation time", Other: "Cloud Run job latest execution creation times"}},
+		{Attribute: "gcp.cloudrun.job.template.task-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job task count", Other: "Cloud Run job task counts"}},
+		{Attribute: "gcp.cloudrun.job.template.parallelism", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job parallelism", Other: "Cloud Run job parallelisms"}},
+		{Attribute: "gcp.cloudrun.job.template.max-retries", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job max retries", Other: "Cloud Run job max retries"}},
+		{Attribute: "gcp.cloudrun.job.template.timeout", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job task timeout", Other: "Cloud Run job task timeouts"}},
+		{Attribute: "gcp.cloudrun.job.template.service-account", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job service account", Other: "Cloud Run job service accounts"}},
+		{Attribute: "gcp.cloudrun.job.container.image", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job container image", Other: "Cloud Run job container images"}},
+		{Attribute: "gcp.cloudrun.job.container.cpu-limit", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job container CPU limit", Other: "Cloud Run job container CPU limits"}},
+		{Attribute: "gcp.cloudrun.job.container.memory-limit", Label: discovery_kit_api.PluralLabel{One: "Cloud Run job container memory limit", Other: "Cloud Run job container memory limits"}},
+	}
+}
+
+// DescribeEnrichmentRules copies the job attributes onto its running executions.
+func (d *jobDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		{
+			Id:      "com.steadybit.extension_gcp.cloudrun.job-to-execution",
+			Version: extbuild.GetSemverVersionStringOrUnknown(),
+			Src: discovery_kit_api.SourceOrDestination{
+				Type: TargetIDJob,
+				Selector: map[string]string{
+					attrProjectID:   "${dest.gcp.project.id}",
+					attrJobLocation: "${dest.gcp.cloudrun.job.location}",
+					attrJobName:     "${dest.gcp.cloudrun.job.name}",
+				},
+			},
+			Dest: discovery_kit_api.SourceOrDestination{
+				Type: TargetIDExecution,
+				Selector: map[string]string{
+					attrProjectID:   "${src.gcp.project.id}",
+					attrJobLocation: "${src.gcp.cloudrun.job.location}",
+					attrJobName:     "${src.gcp.cloudrun.job.name}",
+				},
+			},
+			Attributes: []discovery_kit_api.Attribute{
+				{Matcher: discovery_kit_api.StartsWith, Name: "gcp.cloudrun.job."},
+			},
+		},
+	}
+}
+
+func (d *jobDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		client, err := run.NewJobsClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Run jobs client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = client.Close() }()
+		return getAllJobs(ctx, client, access.ProjectID)
+	}, ctx, "cloudrun-job")
+}
+
+func getAllJobs(ctx context.Context, client *run.JobsClient, projectID string) ([]discovery_kit_api.Target, error) {
+	targets := make([]discovery_kit_api.Target, 0)
+	it := client.ListJobs(ctx, &runpb.ListJobsRequest{Parent: fmt.Sprintf("projects/%s/locations/-", projectID)})
+	for {
+		j, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).Msg("Failed to list Cloud Run jobs")
+			return nil, err
+		}
+		targets = append(targets, toJobTarget(j, projectID))
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesCloudRunJob), nil
+}
+
+func toJobTarget(j *runpb.Job, projectID string) discovery_kit_api.Target {
+	location, name := parseResourceName(j.Name, "jobs")
+
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrJobName] = []string{name}
+	if location != "" {
+		attributes[attrJobLocation] = []string{location}
+	}
+	attributes["gcp.cloudrun.job.execution-count"] = []string{strconv.Itoa(int(j.ExecutionCount))}
+
+	if latest := j.LatestCreatedExecution; latest != nil {
+		_, execution := parseResourceName(latest.Name, "executions")
+		attributes["gcp.cloudrun.job.latest-execution.name"] = []string{execution}
+		if latest.CompletionStatus != runpb.ExecutionReference_COMPLETION_STATUS_UNSPECIFIED {
+			attributes["gcp.cloudrun.job.latest-execution.completion-status"] = []string{latest.CompletionStatus.String()}
+		}
+		if latest.CreateTime != nil {
+			attributes["gcp.cloudrun.job.latest-execution.create-time"] = []string{latest.CreateTime.AsTime().UTC().Format(time.RFC3339)}
+		}
+	}
+
+	if tpl := j.Template; tpl != nil {
+		attributes["gcp.cloudrun.job.template.task-count"] = []string{strconv.Itoa(int(tpl.TaskCount))}
+		if tpl.Parallelism > 0 {
+			attributes["gcp.cloudrun.job.template.parallelism"] = []string{strconv.Itoa(int(tpl.Parallelism))}
+		}
+		if task := tpl.Template; task != nil {
+			if retries, ok := task.Retries.(*runpb.TaskTemplate_MaxRetries); ok {
+				attributes["gcp.cloudrun.job.template.max-retries"] = []string{strconv.Itoa(int(retries.MaxRetries))}
+			}
+			if task.Timeout != nil {
+				attributes["gcp.cloudrun.job.template.timeout"] = []string{task.Timeout.AsDuration().String()}
+			}
+			if task.ServiceAccount != "" {
+				attributes["gcp.cloudrun.job.template.service-account"] = []string{task.ServiceAccount}
+			}
+			addContainerAttributes(attributes, "gcp.cloudrun.job.container", task.Containers)
+		}
+	}
+
+	for k, v := range j.Labels {
+		attributes[fmt.Sprintf("gcp.cloudrun.job.label.%s", strings.ToLower(k))] = []string{v}
+	}
+
+	return discovery_kit_api.Target{
+		Id:         j.Name,
+		TargetType: TargetIDJob,
+		Label:      name,
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestToJobTarget_Populated(t *testing.T) {
+	job := &runpb.Job{
+		Name:           "projects/proj-a/locations/europe-west1/jobs/nightly-export",
+		ExecutionCount: 12,
+		LatestCreatedExecution: &runpb.ExecutionReference{
+			Name:             "projects/proj-a/locations/europe-west1/jobs/nightly-export/executions/nightly-export-abc12",
+			CreateTime:       timestamppb.New(time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)),
+			CompletionStatus: runpb.ExecutionReference_EXECUTION_SUCCEEDED,
+		},
+		Template: &runpb.ExecutionTemplate{
+			TaskCount:   4,
+			Parallelism: 2,
+			Template: &runpb.TaskTemplate{
+				Retries:        &runpb.TaskTemplate_MaxRetries{MaxRetries: 3},
+				Timeout:        durationpb.New(10 * time.Minute),
+				ServiceAccount: "batch@proj-a.iam.gserviceaccount.com",
+				Containers: []*runpb.Container{{
+					Image:     "europe-docker.pkg.dev/proj-a/batch/export:1.4",
+					Resources: &runpb.ResourceRequirements{Limits: map[string]string{"cpu": "1", "memory": "512Mi"}},
+				}},
+			},
+		},
+		Labels: map[string]string{"team": "data"},
+	}
+
+	target := toJobTarget(job, "proj-a")
+
+	assert.Equal(t, TargetIDJob, target.TargetType)
+	assert.Equal(t, job.Name, target.Id)
+	assert.Equal(t, "nightly-export", target.Label)
+	assert.Equal(t, []string{"nightly-export"}, target.Attributes[attrJobName])
+	assert.Equal(t, []string{"europe-west1"}, target.Attributes[attrJobLocation])
+	assert.Equal(t, []string{"12"}, target.Attributes["gcp.cloudrun.job.execution-count"])
+	assert.Equal(t, []string{"nightly-export-abc12"}, target.Attributes["gcp.cloudrun.job.latest-execution.name"])
+	assert.Equal(t, []string{"EXECUTION_SUCCEEDED"}, target.Attributes["gcp.cloudrun.job.latest-execution.completion-status"])
+	assert.Equal(t, []string{"2026-03-01T02:00:00Z"}, target.Attributes["gcp.cloudrun.job.latest-execution.create-time"])
+	assert.Equal(t, []string{"4"}, target.Attributes["gcp.cloudrun.job.template.task-count"])
+	assert.Equal(t, []string{"2"}, target.Attributes["gcp.cloudrun.job.template.parallelism"])
+	assert.Equal(t, []string{"3"}, target.Attributes["gcp.cloudrun.job.template.max-retries"])
+	assert.Equal(t, []string{"10m0s"}, target.Attributes["gcp.cloudrun.job.template.timeout"])
+	assert.Equal(t, []string{"batch@proj-a.iam.gserviceaccount.com"}, target.Attributes["gcp.cloudrun.job.template.service-account"])
+	assert.Equal(t, []string{"europe-docker.pkg.dev/proj-a/batch/export:1.4"}, target.Attributes["gcp.cloudrun.job.container.image"])
+	assert.Equal(t, []string{"1"}, target.Attributes["gcp.cloudrun.job.container.cpu-limit"])
+	assert.Equal(t, []string{"512Mi"}, target.Attributes["gcp.cloudrun.job.container.memory-limit"])
+	assert.Equal(t, []string{"data"}, target.Attributes["gcp.cloudrun.job.label.team"])
+}
+
+func TestToJobTarget_NeverExecuted(t *testing.T) {
+	job := &runpb.Job{Name: "projects/proj-a/locations/europe-west1/jobs/fresh"}
+
+	target := toJobTarget(job, "proj-a")
+
+	assert.Equal(t, []string{"0"}, target.Attributes["gcp.cloudrun.job.execution-count"])
+	assert.NotContains(t, target.Attributes, "gcp.cloudrun.job.latest-execution.name")
+	assert.NotContains(t, target.Attributes, "gcp.cloudrun.job.template.task-count")
+}
+
+func TestToExecutionTarget(t *testing.T) {
+	execution := &runpb.Execution{
+		Name:         "projects/proj-a/locations/europe-west1/jobs/nightly-export/executions/nightly-export-abc12",
+		TaskCount:    4,
+		Parallelism:  2,
+		RunningCount: 2,
+		FailedCount:  1,
+		RetriedCount: 1,
+		StartTime:    timestamppb.New(time.Date(2026, 3, 1, 2, 0, 5, 0, time.UTC)),
+		Template: &runpb.TaskTemplate{
+			Containers: []*runpb.Container{{Image: "europe-docker.pkg.dev/proj-a/batch/export@sha256:def456"}},
+		},
+	}
+
+	target := toExecutionTarget(execution, "proj-a")
+
+	assert.Equal(t, TargetIDExecution, target.TargetType)
+	assert.Equal(t, "nightly-export-abc12", target.Label)
+	assert.Equal(t, []string{"proj-a"}, target.Attributes[attrProjectID])
+	assert.Equal(t, []string{"nightly-export"}, target.Attributes[attrJobName])
+	assert.Equal(t, []string{"europe-west1"}, target.Attributes[attrJobLocation])
+	assert.Equal(t, []string{"nightly-export-abc12"}, target.Attributes[attrExecutionName])
+	assert.Equal(t, []string{"4"}, target.Attributes["gcp.cloudrun.execution.task-count"])
+	assert.Equal(t, []string{"2"}, target.Attributes["gcp.cloudrun.execution.running-count"])
+	assert.Equal(t, []string{"0"}, target.Attributes["gcp.cloudrun.execution.succeeded-count"])
+	assert.Equal(t, []string{"1"}, target.Attributes["gcp.cloudrun.execution.failed-count"])
+	assert.Equal(t, []string{"2026-03-01T02:00:05Z"}, target.Attributes["gcp.cloudrun.execution.start-time"])
+	assert.Equal(t, []string{"sha256:def456"}, target.Attributes["gcp.cloudrun.execution.container.image-digest"])
+}
+
+func TestJobToExecutionEnrichmentRule(t *testing.T) {
+	rules := (&jobDiscovery{}).DescribeEnrichmentRules()
+	assert.Len(t, rules, 1)
+	assert.Equal(t, TargetIDJob, rules[0].Src.Type)
+	assert.Equal(t, TargetIDExecution, rules[0].Dest.Type)
+	assert.Equal(t, "${dest.gcp.cloudrun.job.name}", rules[0].Src.Selector[attrJobName])
+}
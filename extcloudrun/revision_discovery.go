@@ -0,0 +1,208 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	run "cloud.google.com/go/run/apiv2"
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+type revisionDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*revisionDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*revisionDiscovery)(nil)
+)
+
+func NewRevisionDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&revisionDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *revisionDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDRevision,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *revisionDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDRevision,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Cloud Run revision", Other: "Cloud Run revisions"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrServiceName},
+				{Attribute: attrRevisionTrafficPercent},
+				{Attribute: attrLocation},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *revisionDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrRevisionName, Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision name", Other: "Cloud Run revision names"}},
+		{Attribute: attrRevisionTrafficPercent, Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision traffic percent", Other: "Cloud Run revision traffic percents"}},
+		{Attribute: "gcp.cloudrun.revision.traffic-tag", Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision traffic tag", Other: "Cloud Run revision traffic tags"}},
+		{Attribute: "gcp.cloudrun.revision.latest-ready", Label: discovery_kit_api.PluralLabel{One: "Cloud Run latest ready revision", Other: "Cloud Run latest ready revisions"}},
+		{Attribute: "gcp.cloudrun.revision.create-time", Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision creation time", Other: "Cloud Run revision creation times"}},
+		{Attribute: "gcp.cloudrun.revision.container.image", Label: discovery_kit_api.PluralLabel{One: "Cloud Run container image", Other: "Cloud Run container images"}},
+		{Attribute: "gcp.cloudrun.revision.container.image-digest", Label: discovery_kit_api.PluralLabel{One: "Cloud Run container image digest", Other: "Cloud Run container image digests"}},
+		{Attribute: "gcp.cloudrun.revision.container.cpu-limit", Label: discovery_kit_api.PluralLabel{One: "Cloud Run container CPU limit", Other: "Cloud Run container CPU limits"}},
+		{Attribute: "gcp.cloudrun.revision.container.memory-limit", Label: discovery_kit_api.PluralLabel{One: "Cloud Run container memory limit", Other: "Cloud Run container memory limits"}},
+		{Attribute: "gcp.cloudrun.revision.scaling.min-instance-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision min instances", Other: "Cloud Run revision min instances"}},
+		{Attribute: "gcp.cloudrun.revision.scaling.max-instance-count", Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision max instances", Other: "Cloud Run revision max instances"}},
+		{Attribute: "gcp.cloudrun.revision.max-instance-request-concurrency", Label: discovery_kit_api.PluralLabel{One: "Cloud Run revision max request concurrency", Other: "Cloud Run revision max request concurrencies"}},
+	}
+}
+
+func (d *revisionDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		services, err := run.NewServicesClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Run services client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = services.Close() }()
+		revisions, err := run.NewRevisionsClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Run revisions client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = revisions.Close() }()
+		return getAllRevisions(ctx, services, revisions, access.ProjectID)
+	}, ctx, "cloudrun-revision")
+}
+
+// getAllRevisions lists the revisions service by service, because the traffic a revision receives is only known
+// to its service.
+func getAllRevisions(ctx context.Context, services *run.ServicesClient, revisions *run.RevisionsClient, projectID string) ([]discovery_kit_api.Target, error) {
+	targets := make([]discovery_kit_api.Target, 0)
+	it := services.ListServices(ctx, &runpb.ListServicesRequest{Parent: fmt.Sprintf("projects/%s/locations/-", projectID)})
+	for {
+		s, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).Msg("Failed to list Cloud Run services")
+			return nil, err
+		}
+		revIt := revisions.ListRevisions(ctx, &runpb.ListRevisionsRequest{Parent: s.Name})
+		for {
+			r, err := revIt.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				log.Warn().Err(err).Str("service", s.Name).Msg("Failed to list Cloud Run revisions")
+				return nil, err
+			}
+			targets = append(targets, toRevisionTarget(r, s, projectID))
+		}
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesCloudRunRevision), nil
+}
+
+func toRevisionTarget(r *runpb.Revision, s *runpb.Service, projectID string) discovery_kit_api.Target {
+	location, serviceID := parseServiceName(s.Name)
+	_, revisionID := parseResourceName(r.Name, "revisions")
+	_, latestReady := parseResourceName(s.LatestReadyRevision, "revisions")
+
+	// Project, location and service name are the keys the service-to-revision enrichment joins on.
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrServiceName] = []string{serviceID}
+	if location != "" {
+		attributes[attrLocation] = []string{location}
+	}
+	attributes[attrRevisionName] = []string{revisionID}
+	attributes["gcp.cloudrun.revision.latest-ready"] = []string{strconv.FormatBool(revisionID == latestReady)}
+
+	var percent int32
+	var tags []string
+	for _, t := range s.TrafficStatuses {
+		target := t.Revision
+		if t.Type == runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST {
+			target = latestReady
+		}
+		if target != revisionID {
+			continue
+		}
+		percent += t.Percent
+		if t.Tag != "" {
+			tags = append(tags, t.Tag)
+		}
+	}
+	attributes[attrRevisionTrafficPercent] = []string{strconv.Itoa(int(percent))}
+	if len(tags) > 0 {
+		attributes["gcp.cloudrun.revision.traffic-tag"] = tags
+	}
+	if r.CreateTime != nil {
+		attributes["gcp.cloudrun.revision.create-time"] = []string{r.CreateTime.AsTime().UTC().Format(time.RFC3339)}
+	}
+	addContainerAttributes(attributes, "gcp.cloudrun.revision.container", r.Containers)
+	if r.Scaling != nil {
+		attributes["gcp.cloudrun.revision.scaling.min-instance-count"] = []string{strconv.Itoa(int(r.Scaling.MinInstanceCount))}
+		if r.Scaling.MaxInstanceCount > 0 {
+			attributes["gcp.cloudrun.revision.scaling.max-instance-count"] = []string{strconv.Itoa(int(r.Scaling.MaxInstanceCount))}
+		}
+	}
+	if r.MaxInstanceRequestConcurrency > 0 {
+		attributes["gcp.cloudrun.revision.max-instance-request-concurrency"] = []string{strconv.Itoa(int(r.MaxInstanceRequestConcurrency))}
+	}
+	for k, v := range r.Labels {
+		attributes[fmt.Sprintf("gcp.cloudrun.revision.label.%s", strings.ToLower(k))] = []string{v}
+	}
+
+	return discovery_kit_api.Target{
+		Id:         r.Name,
+		TargetType: TargetIDRevision,
+		Label:      revisionID,
+		Attributes: attributes,
+	}
+}
+
+// addContainerAttributes emits image, digest and resource limits of every container under prefix. Revisions and
+// executions pin their images by digest; job templates usually reference a tag and yield no digest.
+func addContainerAttributes(attributes map[string][]string, prefix string, containers []*runpb.Container) {
+	for _, c := range containers {
+		if c.Image == "" {
+			continue
+		}
+		attributes[prefix+".image"] = append(attributes[prefix+".image"], c.Image)
+		if _, digest, ok := strings.Cut(c.Image, "@"); ok {
+			attributes[prefix+".image-digest"] = append(attributes[prefix+".image-digest"], digest)
+		}
+		if cpu := c.GetResources().GetLimits()["cpu"]; cpu != "" {
+			attributes[prefix+".cpu-limit"] = append(attributes[prefix+".cpu-limit"], cpu)
+		}
+		if memory := c.GetResources().GetLimits()["memory"]; memory != "" {
+			attributes[prefix+".memory-limit"] = append(attributes[prefix+".memory-limit"], memory)
+		}
+	}
+}
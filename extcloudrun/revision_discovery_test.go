@@ -0,0 +1,92 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestToRevisionTarget_Populated(t *testing.T) {
+	svc := &runpb.Service{
+		Name:                "projects/proj-a/locations/europe-west1/services/svc-a",
+		LatestReadyRevision: "projects/proj-a/locations/europe-west1/services/svc-a/revisions/svc-a-00002",
+		TrafficStatuses: []*runpb.TrafficTargetStatus{
+			{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST, Percent: 90},
+			{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION, Revision: "svc-a-00002", Tag: "canary"},
+			{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION, Revision: "svc-a-00001", Percent: 10},
+		},
+	}
+	rev := &runpb.Revision{
+		Name:       "projects/proj-a/locations/europe-west1/services/svc-a/revisions/svc-a-00002",
+		CreateTime: timestamppb.New(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)),
+		Containers: []*runpb.Container{{
+			Image:     "europe-docker.pkg.dev/proj-a/app/svc@sha256:abc123",
+			Resources: &runpb.ResourceRequirements{Limits: map[string]string{"cpu": "2", "memory": "1Gi"}},
+		}},
+		Scaling:                       &runpb.RevisionScaling{MinInstanceCount: 1, MaxInstanceCount: 10},
+		MaxInstanceRequestConcurrency: 80,
+		Labels:                        map[string]string{"Team": "core"},
+	}
+
+	target := toRevisionTarget(rev, svc, "proj-a")
+
+	assert.Equal(t, TargetIDRevision, target.TargetType)
+	assert.Equal(t, rev.Name, target.Id)
+	assert.Equal(t, "svc-a-00002", target.Label)
+	assert.Equal(t, []string{"proj-a"}, target.Attributes[attrProjectID])
+	assert.Equal(t, []string{"svc-a"}, target.Attributes[attrServiceName])
+	assert.Equal(t, []string{"europe-west1"}, target.Attributes[attrLocation])
+	assert.Equal(t, []string{"svc-a-00002"}, target.Attributes[attrRevisionName])
+	assert.Equal(t, []string{"90"}, target.Attributes[attrRevisionTrafficPercent])
+	assert.Equal(t, []string{"canary"}, target.Attributes["gcp.cloudrun.revision.traffic-tag"])
+	assert.Equal(t, []string{"true"}, target.Attributes["gcp.cloudrun.revision.latest-ready"])
+	assert.Equal(t, []string{"2026-03-01T12:00:00Z"}, target.Attributes["gcp.cloudrun.revision.create-time"])
+	assert.Equal(t, []string{"europe-docker.pkg.dev/proj-a/app/svc@sha256:abc123"}, target.Attributes["gcp.cloudrun.revision.container.image"])
+	assert.Equal(t, []string{"sha256:abc123"}, target.Attributes["gcp.cloudrun.revision.container.image-digest"])
+	assert.Equal(t, []string{"2"}, target.Attributes["gcp.cloudrun.revision.container.cpu-limit"])
+	assert.Equal(t, []string{"1Gi"}, target.Attributes["gcp.cloudrun.revision.container.memory-limit"])
+	assert.Equal(t, []string{"1"}, target.Attributes["gcp.cloudrun.revision.scaling.min-instance-count"])
+	assert.Equal(t, []string{"10"}, target.Attributes["gcp.cloudrun.revision.scaling.max-instance-count"])
+	assert.Equal(t, []string{"80"}, target.Attributes["gcp.cloudrun.revision.max-instance-request-concurrency"])
+	assert.Equal(t, []string{"core"}, target.Attributes["gcp.cloudrun.revision.label.team"])
+}
+
+func TestToRevisionTarget_WithoutTraffic(t *testing.T) {
+	svc := &runpb.Service{
+		Name:                "projects/proj-a/locations/europe-west1/services/svc-a",
+		LatestReadyRevision: "projects/proj-a/locations/europe-west1/services/svc-a/revisions/svc-a-00002",
+		TrafficStatuses: []*runpb.TrafficTargetStatus{
+			{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST, Percent: 100},
+		},
+	}
+	rev := &runpb.Revision{
+		Name:       "projects/proj-a/locations/europe-west1/services/svc-a/revisions/svc-a-00001",
+		Containers: []*runpb.Container{{Image: "nginx:latest"}},
+	}
+
+	target := toRevisionTarget(rev, svc, "proj-a")
+
+	assert.Equal(t, []string{"0"}, target.Attributes[attrRevisionTrafficPercent])
+	assert.Equal(t, []string{"false"}, target.Attributes["gcp.cloudrun.revision.latest-ready"])
+	assert.NotContains(t, target.Attributes, "gcp.cloudrun.revision.traffic-tag")
+	assert.NotContains(t, target.Attributes, "gcp.cloudrun.revision.create-time")
+	assert.Equal(t, []string{"nginx:latest"}, target.Attributes["gcp.cloudrun.revision.container.image"])
+	assert.NotContains(t, target.Attributes, "gcp.cloudrun.revision.container.image-digest")
+	assert.NotContains(t, target.Attributes, "gcp.cloudrun.revision.container.cpu-limit")
+}
+
+func TestParseResourceName(t *testing.T) {
+	loc, name := parseResourceName("projects/p/locations/us-central1/services/svc/revisions/svc-00001", "revisions")
+	assert.Equal(t, "us-central1", loc)
+	assert.Equal(t, "svc-00001", name)
+
+	_, name = parseResourceName("", "revisions")
+	assert.Equal(t, "", name)
+}
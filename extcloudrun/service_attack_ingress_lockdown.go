@@ -0,0 +1,219 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// IngressLockdownState records the ingress setting found in Prepare. The attack is reversible: Stop writes the
+// original setting back. Ingress is a service-level setting, so no revision is rolled out either way.
+type IngressLockdownState struct {
+	ProjectID       string
+	ServiceName     string
+	ServiceID       string
+	Ingress         string
+	OriginalIngress string
+	UpdateOperation string
+	UpdateReported  bool
+}
+
+type ingressLockdownAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (servicesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[IngressLockdownState] = (*ingressLockdownAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[IngressLockdownState] = (*ingressLockdownAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[IngressLockdownState] = (*ingressLockdownAttack)(nil)
+
+// ingressRestrictiveness ranks the settings from open to closed; a lockdown must move a service further down.
+var ingressRestrictiveness = map[runpb.IngressTraffic]int{
+	runpb.IngressTraffic_INGRESS_TRAFFIC_ALL:                    0,
+	runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_LOAD_BALANCER: 1,
+	runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY:          2,
+	runpb.IngressTraffic_INGRESS_TRAFFIC_NONE:                   3,
+}
+
+func NewServiceIngressLockdownAction() action_kit_sdk.ActionWithStop[IngressLockdownState] {
+	return &ingressLockdownAttack{clientProvider: newServicesApi}
+}
+
+func (a *ingressLockdownAttack) NewEmptyState() IngressLockdownState {
+	return IngressLockdownState{}
+}
+
+func (a *ingressLockdownAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ServiceIngressLockdownActionId,
+		Label:       "Lock down Cloud Run ingress",
+		Description: "Restricts the ingress of a Cloud Run service to internal traffic for the given duration, so external clients and uptime checks are cut off while callers inside the VPC keep working. The original ingress is restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         TargetIDService,
+			SelectionTemplates: extutil.Ptr(serviceSelectionTemplates),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Cloud Run"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the ingress stays locked down. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "ingress",
+				Label:        "Ingress",
+				Description:  extutil.Ptr("Ingress setting during the attack. Must be more restrictive than the service's current one."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: extutil.Ptr(runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY.String()),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Internal", Value: runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY.String()},
+					action_kit_api.ExplicitParameterOption{Label: "Internal and Cloud Load Balancing", Value: runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_LOAD_BALANCER.String()},
+				}),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *ingressLockdownAttack) Prepare(ctx context.Context, state *IngressLockdownState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.ServiceID, state.ServiceName, err = resolveService(request)
+	if err != nil {
+		return nil, err
+	}
+	state.Ingress = extutil.ToString(request.Config["ingress"])
+	ingress := runpb.IngressTraffic(runpb.IngressTraffic_value[state.Ingress])
+	if ingress != runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY && ingress != runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_LOAD_BALANCER {
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported ingress %q; use %s or %s", state.Ingress, runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY, runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_LOAD_BALANCER), nil)
+	}
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud Run service %s", state.ServiceID), err)
+	}
+	current := svc.GetIngress()
+	if ingressRestrictiveness[current] >= ingressRestrictiveness[ingress] {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud Run service %s already has ingress %s; locking it down to %s would not cut off any traffic", state.ServiceID, current, ingress), nil)
+	}
+	state.OriginalIngress = current.String()
+	return nil, nil
+}
+
+func (a *ingressLockdownAttack) Start(ctx context.Context, state *IngressLockdownState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud Run service %s", state.ServiceID), err)
+	}
+	svc.Ingress = runpb.IngressTraffic(runpb.IngressTraffic_value[state.Ingress])
+	state.UpdateOperation, err = client.UpdateService(ctx, svc)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to lock down ingress of Cloud Run service %s", state.ServiceID), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Setting ingress of Cloud Run service %s to %s (was %s)", state.ServiceID, state.Ingress, state.OriginalIngress),
+		}}),
+	}, nil
+}
+
+// Status follows the update operation and reports once the lockdown is in effect. Only a failed update ends the
+// action early.
+func (a *ingressLockdownAttack) Status(ctx context.Context, state *IngressLockdownState) (*action_kit_api.StatusResult, error) {
+	if state.UpdateReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollUpdate(ctx, state.UpdateOperation)
+	if result, err := utils.OperationStatus(done, err, "Cloud Run", fmt.Sprintf("Ingress lockdown of Cloud Run service %s failed", state.ServiceID)); result != nil || err != nil {
+		return result, err
+	}
+	state.UpdateReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Cloud Run service %s only accepts %s traffic", state.ServiceID, state.Ingress),
+		}}),
+	}, nil
+}
+
+func (a *ingressLockdownAttack) Stop(ctx context.Context, state *IngressLockdownState) (*action_kit_api.StopResult, error) {
+	if state.UpdateOperation == "" {
+		return nil, nil
+	}
+	if err := a.restoreIngress(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore ingress of Cloud Run service %s", state.ServiceID)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore ingress of Cloud Run service %s to %s", state.ServiceID, state.OriginalIngress), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Ingress of Cloud Run service %s restored to %s", state.ServiceID, state.OriginalIngress),
+		}}),
+	}, nil
+}
+
+func (a *ingressLockdownAttack) restoreIngress(ctx context.Context, state *IngressLockdownState) error {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	if err := utils.WaitForOperation(ctx, client.PollUpdate, state.UpdateOperation); err != nil {
+		log.Warn().Err(err).Msgf("Ingress lockdown of Cloud Run service %s did not complete cleanly", state.ServiceID)
+	}
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return err
+	}
+	original := runpb.IngressTraffic(runpb.IngressTraffic_value[state.OriginalIngress])
+	if svc.GetIngress() == original {
+		return nil
+	}
+	svc.Ingress = original
+	op, err := client.UpdateService(ctx, svc)
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, client.PollUpdate, op)
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publicService() *runpb.Service {
+	return &runpb.Service{
+		Name:    serviceName,
+		Ingress: runpb.IngressTraffic_INGRESS_TRAFFIC_ALL,
+	}
+}
+
+func TestIngressLockdown_LocksAndRestores(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeServices()
+	api.services[serviceName] = publicService()
+	a := &ingressLockdownAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"ingress": "INGRESS_TRAFFIC_INTERNAL_ONLY"}))
+	require.NoError(t, err)
+	assert.Equal(t, "INGRESS_TRAFFIC_ALL", state.OriginalIngress)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY, api.services[serviceName].Ingress)
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	require.NotNil(t, status.Messages)
+	assert.True(t, state.UpdateReported)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.updates, 2)
+	assert.Equal(t, runpb.IngressTraffic_INGRESS_TRAFFIC_ALL, api.services[serviceName].Ingress)
+}
+
+func TestIngressLockdown_StopSkipsRestoredService(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeServices()
+	api.services[serviceName] = publicService()
+	a := &ingressLockdownAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"ingress": "INGRESS_TRAFFIC_INTERNAL_LOAD_BALANCER"}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	api.services[serviceName].Ingress = runpb.IngressTraffic_INGRESS_TRAFFIC_ALL
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Len(t, api.updates, 1)
+}
+
+func TestIngressLockdown_StatusReportsFailedUpdate(t *testing.T) {
+	api := newFakeServices()
+	api.services[serviceName] = publicService()
+	a := &ingressLockdownAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"ingress": "INGRESS_TRAFFIC_INTERNAL_ONLY"}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	api.opErrors[state.UpdateOperation] = errors.New("permission denied")
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+}
+
+func TestIngressLockdown_Prepare_Refusals(t *testing.T) {
+	api := newFakeServices()
+	internal := publicService()
+	internal.Ingress = runpb.IngressTraffic_INGRESS_TRAFFIC_INTERNAL_ONLY
+	api.services[serviceName] = internal
+	a := &ingressLockdownAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"ingress": "INGRESS_TRAFFIC_ALL"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported ingress")
+
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"ingress": "INGRESS_TRAFFIC_INTERNAL_LOAD_BALANCER"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would not cut off any traffic")
+
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"ingress": "INGRESS_TRAFFIC_INTERNAL_ONLY"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has ingress")
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// ScalingClampState records the instance limits found in Prepare. The attack is reversible: Stop writes the original
+// limits back, which rolls out one more revision with the original template.
+type ScalingClampState struct {
+	ProjectID                  string
+	ServiceName                string
+	ServiceID                  string
+	MaxInstanceCount           int32
+	ScaleToZero                bool
+	OriginalMinInstanceCount   int32
+	OriginalMaxInstanceCount   int32
+	OriginalServiceMinInstance int32
+	UpdateOperation            string
+	UpdateReported             bool
+}
+
+type scalingClampAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (servicesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[ScalingClampState] = (*scalingClampAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[ScalingClampState] = (*scalingClampAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[ScalingClampState] = (*scalingClampAttack)(nil)
+
+func NewServiceScalingClampAction() action_kit_sdk.ActionWithStop[ScalingClampState] {
+	return &scalingClampAttack{clientProvider: newServicesApi}
+}
+
+func (a *scalingClampAttack) NewEmptyState() ScalingClampState {
+	return ScalingClampState{}
+}
+
+func (a *scalingClampAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ServiceScalingClampActionId,
+		Label:       "Clamp Cloud Run scaling",
+		Description: "Limits the maximum number of instances of a Cloud Run service and/or lets it scale to zero for the given duration, to observe capacity limits and cold starts. The change rolls out as a new revision. The original limits are restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         TargetIDService,
+			SelectionTemplates: extutil.Ptr(serviceSelectionTemplates),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Cloud Run"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the scaling limits stay clamped. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "maxInstanceCount",
+				Label:        "Max instances",
+				Description:  extutil.Ptr("Maximum number of instances during the attack. 0 leaves the maximum unchanged."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("1"),
+				MinValue:     extutil.Ptr(0),
+				Order:        extutil.Ptr(2),
+			},
+			{
+				Name:         "scaleToZero",
+				Label:        "Scale to zero",
+				Description:  extutil.Ptr("Set the minimum number of instances (of the revision and of the service) to 0, so idle instances are shut down and requests hit cold starts."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("false"),
+				Order:        extutil.Ptr(3),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *scalingClampAttack) Prepare(ctx context.Context, state *ScalingClampState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.ServiceID, state.ServiceName, err = resolveService(request)
+	if err != nil {
+		return nil, err
+	}
+	state.MaxInstanceCount = int32(extutil.ToInt64(request.Config["maxInstanceCount"]))
+	state.ScaleToZero = extutil.ToBool(request.Config["scaleToZero"])
+	if state.MaxInstanceCount < 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Max instances must not be negative, got %d", state.MaxInstanceCount), nil)
+	}
+	if state.MaxInstanceCount == 0 && !state.ScaleToZero {
+		return nil, extension_kit.ToError("Set max instances, scale to zero, or both", nil)
+	}
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud Run service %s", state.ServiceID), err)
+	}
+	if svc.GetScaling().GetScalingMode() == runpb.ServiceScaling_MANUAL {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud Run service %s uses manual scaling; instance limits do not apply", state.ServiceID), nil)
+	}
+	// The clamp ships as a new revision, which only serves requests if the latest revision receives traffic.
+	if !routesToLatest(svc.GetTraffic()) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud Run service %s sends no traffic to its latest revision, so the clamped revision would not serve requests", state.ServiceID), nil)
+	}
+	state.OriginalMinInstanceCount = svc.GetTemplate().GetScaling().GetMinInstanceCount()
+	state.OriginalMaxInstanceCount = svc.GetTemplate().GetScaling().GetMaxInstanceCount()
+	state.OriginalServiceMinInstance = svc.GetScaling().GetMinInstanceCount()
+	return nil, nil
+}
+
+func (a *scalingClampAttack) Start(ctx context.Context, state *ScalingClampState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud Run service %s", state.ServiceID), err)
+	}
+	minInstances, maxInstances, serviceMin := state.OriginalMinInstanceCount, state.OriginalMaxInstanceCount, state.OriginalServiceMinInstance
+	if state.MaxInstanceCount > 0 {
+		maxInstances = state.MaxInstanceCount
+		minInstances = min(minInstances, maxInstances)
+	}
+	if state.ScaleToZero {
+		minInstances, serviceMin = 0, 0
+	}
+	setScaling(svc, minInstances, maxInstances, serviceMin)
+	state.UpdateOperation, err = client.UpdateService(ctx, svc)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to clamp scaling of Cloud Run service %s", state.ServiceID), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Clamping Cloud Run service %s to %s (was %s)", state.ServiceID, describeScaling(minInstances, maxInstances), describeScaling(state.OriginalMinInstanceCount, state.OriginalMaxInstanceCount)),
+		}}),
+	}, nil
+}
+
+// Status reports once the clamped revision is serving. The clamp then stays in place until Stop restores the original
+// scaling bounds.
+func (a *scalingClampAttack) Status(ctx context.Context, state *ScalingClampState) (*action_kit_api.StatusResult, error) {
+	if state.UpdateReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollUpdate(ctx, state.UpdateOperation)
+	if result, err := utils.OperationStatus(done, err, "Cloud Run", fmt.Sprintf("Scaling clamp of Cloud Run service %s failed", state.ServiceID)); result != nil || err != nil {
+		return result, err
+	}
+	state.UpdateReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Clamped revision of Cloud Run service %s is serving", state.ServiceID),
+		}}),
+	}, nil
+}
+
+func (a *scalingClampAttack) Stop(ctx context.Context, state *ScalingClampState) (*action_kit_api.StopResult, error) {
+	if state.UpdateOperation == "" {
+		return nil, nil
+	}
+	original := describeScaling(state.OriginalMinInstanceCount, state.OriginalMaxInstanceCount)
+	if err := a.restoreScaling(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore scaling of Cloud Run service %s", state.ServiceID)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore scaling of Cloud Run service %s to %s", state.ServiceID, original), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Scaling of Cloud Run service %s restored to %s", state.ServiceID, original),
+		}}),
+	}, nil
+}
+
+func (a *scalingClampAttack) restoreScaling(ctx context.Context, state *ScalingClampState) error {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	if err := utils.WaitForOperation(ctx, client.PollUpdate, state.UpdateOperation); err != nil {
+		log.Warn().Err(err).Msgf("Scaling clamp of Cloud Run service %s did not complete cleanly", state.ServiceID)
+	}
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return err
+	}
+	if svc.GetTemplate().GetScaling().GetMinInstanceCount() == state.OriginalMinInstanceCount &&
+		svc.GetTemplate().GetScaling().GetMaxInstanceCount() == state.OriginalMaxInstanceCount &&
+		svc.GetScaling().GetMinInstanceCount() == state.OriginalServiceMinInstance {
+		return nil
+	}
+	setScaling(svc, state.OriginalMinInstanceCount, state.OriginalMaxInstanceCount, state.OriginalServiceMinInstance)
+	op, err := client.UpdateService(ctx, svc)
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, client.PollUpdate, op)
+}
+
+// setScaling writes the limits into the service. An explicit revision name is cleared, as Cloud Run refuses to
+// create a second revision under the same name.
+func setScaling(svc *runpb.Service, minInstances, maxInstances, serviceMin int32) {
+	if svc.Template == nil {
+		svc.Template = &runpb.RevisionTemplate{}
+	}
+	if svc.Template.Scaling == nil {
+		svc.Template.Scaling = &runpb.RevisionScaling{}
+	}
+	svc.Template.Revision = ""
+	svc.Template.Scaling.MinInstanceCount = minInstances
+	svc.Template.Scaling.MaxInstanceCount = maxInstances
+	if svc.Scaling != nil {
+		svc.Scaling.MinInstanceCount = serviceMin
+	}
+}
+
+func routesToLatest(traffic []*runpb.TrafficTarget) bool {
+	for _, t := range traffic {
+		if t.GetType() == runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST && t.GetPercent() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func describeScaling(minInstances, maxInstances int32) string {
+	if maxInstances == 0 {
+		return fmt.Sprintf("min %d, max default", minInstances)
+	}
+	return fmt.Sprintf("min %d, max %d", minInstances, maxInstances)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scalableService() *runpb.Service {
+	return &runpb.Service{
+		Name:    serviceName,
+		Scaling: &runpb.ServiceScaling{MinInstanceCount: 1},
+		Template: &runpb.RevisionTemplate{
+			Revision: "svc-a-pinned",
+			Scaling:  &runpb.RevisionScaling{MinInstanceCount: 2, MaxInstanceCount: 20},
+		},
+		Traffic: []*runpb.TrafficTarget{{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST, Percent: 100}},
+	}
+}
+
+func TestScalingClamp_ClampsAndRestores(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeServices()
+	api.services[serviceName] = scalableService()
+	a := &scalingClampAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"maxInstanceCount": 1, "scaleToZero": true}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), state.OriginalMinInstanceCount)
+	assert.Equal(t, int32(20), state.OriginalMaxInstanceCount)
+	assert.Equal(t, int32(1), state.OriginalServiceMinInstance)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	clamped := api.services[serviceName]
+	assert.Equal(t, int32(0), clamped.Template.Scaling.MinInstanceCount)
+	assert.Equal(t, int32(1), clamped.Template.Scaling.MaxInstanceCount)
+	assert.Equal(t, int32(0), clamped.Scaling.MinInstanceCount)
+	assert.Empty(t, clamped.Template.Revision)
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	require.NotNil(t, status.Messages)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.updates, 2)
+	restored := api.services[serviceName]
+	assert.Equal(t, int32(2), restored.Template.Scaling.MinInstanceCount)
+	assert.Equal(t, int32(20), restored.Template.Scaling.MaxInstanceCount)
+	assert.Equal(t, int32(1), restored.Scaling.MinInstanceCount)
+}
+
+func TestScalingClamp_MaxOnlyKeepsMinWithinMax(t *testing.T) {
+	api := newFakeServices()
+	api.services[serviceName] = scalableService()
+	a := &scalingClampAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"maxInstanceCount": 1}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	clamped := api.services[serviceName]
+	assert.Equal(t, int32(1), clamped.Template.Scaling.MinInstanceCount)
+	assert.Equal(t, int32(1), clamped.Template.Scaling.MaxInstanceCount)
+	assert.Equal(t, int32(1), clamped.Scaling.MinInstanceCount)
+}
+
+func TestScalingClamp_Prepare_Refusals(t *testing.T) {
+	api := newFakeServices()
+	pinned := scalableService()
+	pinned.Traffic = []*runpb.TrafficTarget{{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION, Revision: "svc-a-001", Percent: 100}}
+	api.services[serviceName] = pinned
+	a := &scalingClampAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"maxInstanceCount": 0, "scaleToZero": false}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Set max instances, scale to zero, or both")
+
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"maxInstanceCount": 3}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no traffic to its latest revision")
+
+	api.services[serviceName] = scalableService()
+	api.services[serviceName].Scaling.ScalingMode = runpb.ServiceScaling_MANUAL
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"maxInstanceCount": 3}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "manual scaling")
+}
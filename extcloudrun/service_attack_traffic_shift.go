@@ -0,0 +1,389 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// TrafficAllocation is one entry of a service's traffic split. It is kept apart from runpb.TrafficTarget so the
+// action state stays plain JSON.
+type TrafficAllocation struct {
+	Type     string
+	Revision string
+	Percent  int32
+	Tag      string
+}
+
+// TrafficShiftState records the traffic split found in Prepare. The attack is reversible: Stop writes the original
+// split back, unless the service already has it again.
+type TrafficShiftState struct {
+	ProjectID       string
+	ServiceName     string
+	ServiceID       string
+	Revision        string
+	Percent         int32
+	OriginalTraffic []TrafficAllocation
+	ShiftedTraffic  []TrafficAllocation
+	ShiftOperation  string
+	ShiftReported   bool
+}
+
+type trafficShiftAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (servicesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[TrafficShiftState] = (*trafficShiftAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[TrafficShiftState] = (*trafficShiftAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[TrafficShiftState] = (*trafficShiftAttack)(nil)
+
+func NewServiceTrafficShiftAction() action_kit_sdk.ActionWithStop[TrafficShiftState] {
+	return &trafficShiftAttack{clientProvider: newServicesApi}
+}
+
+func (a *trafficShiftAttack) NewEmptyState() TrafficShiftState {
+	return TrafficShiftState{}
+}
+
+func (a *trafficShiftAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ServiceTrafficShiftActionId,
+		Label:       "Shift Cloud Run traffic",
+		Description: "Routes a share of a Cloud Run service's traffic to another revision — an older one for a rollback, or a broken or tagged one — for the given duration. The remaining traffic keeps the original split, scaled down. The original split is restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         TargetIDService,
+			SelectionTemplates: extutil.Ptr(serviceSelectionTemplates),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Cloud Run"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the traffic stays shifted. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "revision",
+				Label:       "Revision",
+				Description: extutil.Ptr("Name of the revision that receives the traffic, e.g. my-service-00042-abc. Leave empty when using a tag."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       extutil.Ptr(2),
+			},
+			{
+				Name:        "tag",
+				Label:       "Tag",
+				Description: extutil.Ptr("Traffic tag whose revision receives the traffic. Used when no revision is set."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       extutil.Ptr(3),
+			},
+			{
+				Name:         "percent",
+				Label:        "Traffic share",
+				Description:  extutil.Ptr("Percentage of the traffic sent to the revision."),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: extutil.Ptr("100"),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(100),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+var serviceSelectionTemplates = []action_kit_api.TargetSelectionTemplate{
+	{
+		Label:       "by service name",
+		Description: extutil.Ptr("Find Cloud Run service by name"),
+		Query:       attrServiceName + "=\"\"",
+	},
+}
+
+// resolveService reads the service from the target attributes and builds its resource name.
+func resolveService(request action_kit_api.PrepareActionRequestBody) (projectID, serviceID, serviceName string, err error) {
+	projectID = mustHave(request.Target.Attributes, attrProjectID)
+	serviceID = mustHave(request.Target.Attributes, attrServiceName)
+	location := mustHave(request.Target.Attributes, attrLocation)
+	if projectID == "" || serviceID == "" || location == "" {
+		return "", "", "", extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.cloudrun.service.name, gcp.cloudrun.service.location", nil)
+	}
+	return projectID, serviceID, fmt.Sprintf("projects/%s/locations/%s/services/%s", projectID, location, serviceID), nil
+}
+
+func mustHave(attrs map[string][]string, key string) string {
+	v, ok := attrs[key]
+	if !ok || len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func (a *trafficShiftAttack) Prepare(ctx context.Context, state *TrafficShiftState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.ServiceID, state.ServiceName, err = resolveService(request)
+	if err != nil {
+		return nil, err
+	}
+	state.Percent = int32(extutil.ToInt64(request.Config["percent"]))
+	if state.Percent < 1 || state.Percent > 100 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Traffic share must be between 1 and 100, got %d", state.Percent), nil)
+	}
+	revision := strings.TrimSpace(extutil.ToString(request.Config["revision"]))
+	tag := strings.TrimSpace(extutil.ToString(request.Config["tag"]))
+	if revision == "" && tag == "" {
+		return nil, extension_kit.ToError("Either a revision or a tag is required", nil)
+	}
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	// Read the split live rather than from discovery: restoring a stale split would leave the service misrouted.
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud Run service %s", state.ServiceID), err)
+	}
+	state.OriginalTraffic = toTrafficAllocations(svc.GetTraffic())
+	if len(state.OriginalTraffic) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud Run service %s has no traffic configuration", state.ServiceID), nil)
+	}
+	if revision == "" {
+		revision = revisionOfTag(state.OriginalTraffic, tag)
+		if revision == "" {
+			return nil, extension_kit.ToError(fmt.Sprintf("Cloud Run service %s has no revision tagged %s", state.ServiceID, tag), nil)
+		}
+	}
+	state.Revision = revision
+	if _, latestReady := parseResourceName(svc.GetLatestReadyRevision(), "revisions"); state.Percent < 100 && latestReady == revision && carriesAllTraffic(state.OriginalTraffic, revision) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Revision %s is the latest ready revision of Cloud Run service %s and receives all of its traffic, so there is no other revision for the remaining %d%%", revision, state.ServiceID, 100-state.Percent), nil)
+	}
+	state.ShiftedTraffic = shiftTraffic(state.OriginalTraffic, revision, state.Percent)
+	if sameTraffic(state.OriginalTraffic, state.ShiftedTraffic) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Revision %s already receives %d%% of the traffic of Cloud Run service %s", revision, state.Percent, state.ServiceID), nil)
+	}
+	return nil, nil
+}
+
+func (a *trafficShiftAttack) Start(ctx context.Context, state *TrafficShiftState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud Run service %s", state.ServiceID), err)
+	}
+	svc.Traffic = toTrafficTargets(state.ShiftedTraffic)
+	state.ShiftOperation, err = client.UpdateService(ctx, svc)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to shift traffic of Cloud Run service %s", state.ServiceID), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Shifting %d%% of the traffic of Cloud Run service %s to revision %s (was %s)", state.Percent, state.ServiceID, state.Revision, describeTraffic(state.OriginalTraffic)),
+		}}),
+	}, nil
+}
+
+// Status reports once the shifted traffic split is serving. The shift then stays in place until Stop.
+func (a *trafficShiftAttack) Status(ctx context.Context, state *TrafficShiftState) (*action_kit_api.StatusResult, error) {
+	if state.ShiftReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud Run client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollUpdate(ctx, state.ShiftOperation)
+	if result, err := utils.OperationStatus(done, err, "Cloud Run", fmt.Sprintf("Traffic shift of Cloud Run service %s failed", state.ServiceID)); result != nil || err != nil {
+		return result, err
+	}
+	state.ShiftReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Cloud Run service %s now routes %s", state.ServiceID, describeTraffic(state.ShiftedTraffic)),
+		}}),
+	}, nil
+}
+
+func (a *trafficShiftAttack) Stop(ctx context.Context, state *TrafficShiftState) (*action_kit_api.StopResult, error) {
+	if state.ShiftOperation == "" {
+		return nil, nil
+	}
+	if err := a.restoreTraffic(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore traffic of Cloud Run service %s", state.ServiceID)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore traffic of Cloud Run service %s to %s", state.ServiceID, describeTraffic(state.OriginalTraffic)), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Traffic of Cloud Run service %s restored to %s", state.ServiceID, describeTraffic(state.OriginalTraffic)),
+		}}),
+	}, nil
+}
+
+// restoreTraffic waits for the shift to settle first, because Cloud Run rejects an update while the previous one is
+// still rolling out.
+func (a *trafficShiftAttack) restoreTraffic(ctx context.Context, state *TrafficShiftState) error {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	if err := utils.WaitForOperation(ctx, client.PollUpdate, state.ShiftOperation); err != nil {
+		log.Warn().Err(err).Msgf("Traffic shift of Cloud Run service %s did not complete cleanly", state.ServiceID)
+	}
+	svc, err := client.GetService(ctx, state.ServiceName)
+	if err != nil {
+		return err
+	}
+	if sameTraffic(toTrafficAllocations(svc.GetTraffic()), state.OriginalTraffic) {
+		return nil
+	}
+	svc.Traffic = toTrafficTargets(state.OriginalTraffic)
+	op, err := client.UpdateService(ctx, svc)
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, client.PollUpdate, op)
+}
+
+// shiftTraffic gives the revision exactly percent and scales the other entries to share the rest in their original
+// proportions. Rounding leftovers go to the entry that had the largest share. If the revision carried all traffic
+// before, the rest goes to the latest ready revision instead. Tagged entries are kept even at 0% so their tag URLs
+// keep working.
+func shiftTraffic(original []TrafficAllocation, revision string, percent int32) []TrafficAllocation {
+	isTarget := func(t TrafficAllocation) bool {
+		return t.Type == runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION.String() && t.Revision == revision
+	}
+	var othersTotal int32
+	for _, t := range original {
+		if !isTarget(t) {
+			othersTotal += t.Percent
+		}
+	}
+
+	remaining := 100 - percent
+	result := make([]TrafficAllocation, 0, len(original)+2)
+	leftover := remaining
+	largest := -1
+	for _, t := range original {
+		if isTarget(t) {
+			t.Percent = 0
+		} else if othersTotal > 0 {
+			if t.Percent > 0 && (largest < 0 || t.Percent > result[largest].Percent) {
+				largest = len(result)
+			}
+			t.Percent = t.Percent * remaining / othersTotal
+			leftover -= t.Percent
+		}
+		result = append(result, t)
+	}
+	if largest >= 0 {
+		result[largest].Percent += leftover
+	} else if remaining > 0 {
+		result = append(result, TrafficAllocation{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST.String(), Percent: remaining})
+	}
+
+	if targetIdx := slices.IndexFunc(result, isTarget); targetIdx >= 0 {
+		result[targetIdx].Percent = percent
+	} else {
+		result = append(result, TrafficAllocation{
+			Type:     runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION.String(),
+			Revision: revision,
+			Percent:  percent,
+		})
+	}
+	return slices.DeleteFunc(result, func(t TrafficAllocation) bool { return t.Percent == 0 && t.Tag == "" })
+}
+
+// carriesAllTraffic reports whether no entry but the revision's own receives traffic.
+func carriesAllTraffic(traffic []TrafficAllocation, revision string) bool {
+	for _, t := range traffic {
+		if t.Percent > 0 && (t.Type != runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION.String() || t.Revision != revision) {
+			return false
+		}
+	}
+	return true
+}
+
+func revisionOfTag(traffic []TrafficAllocation, tag string) string {
+	for _, t := range traffic {
+		if t.Tag == tag && t.Revision != "" {
+			return t.Revision
+		}
+	}
+	return ""
+}
+
+func sameTraffic(a, b []TrafficAllocation) bool {
+	return slices.Equal(a, b)
+}
+
+func describeTraffic(traffic []TrafficAllocation) string {
+	parts := make([]string, 0, len(traffic))
+	for _, t := range traffic {
+		target := t.Revision
+		if t.Type == runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST.String() {
+			target = "LATEST"
+		}
+		if t.Tag != "" {
+			target = fmt.Sprintf("%s (tag %s)", target, t.Tag)
+		}
+		parts = append(parts, fmt.Sprintf("%d%% to %s", t.Percent, target))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func toTrafficAllocations(traffic []*runpb.TrafficTarget) []TrafficAllocation {
+	result := make([]TrafficAllocation, 0, len(traffic))
+	for _, t := range traffic {
+		result = append(result, TrafficAllocation{Type: t.GetType().String(), Revision: t.GetRevision(), Percent: t.GetPercent(), Tag: t.GetTag()})
+	}
+	return result
+}
+
+func toTrafficTargets(traffic []TrafficAllocation) []*runpb.TrafficTarget {
+	result := make([]*runpb.TrafficTarget, 0, len(traffic))
+	for _, t := range traffic {
+		result = append(result, &runpb.TrafficTarget{
+			Type:     runpb.TrafficTargetAllocationType(runpb.TrafficTargetAllocationType_value[t.Type]),
+			Revision: t.Revision,
+			Percent:  t.Percent,
+			Tag:      t.Tag,
+		})
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/run/apiv2/runpb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serviceName = "projects/proj-a/locations/europe-west1/services/svc-a"
+
+var validServiceAttrs = map[string][]string{
+	attrProjectID:   {"proj-a"},
+	attrServiceName: {"svc-a"},
+	attrLocation:    {"europe-west1"},
+}
+
+func serviceReq(cfg map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: validServiceAttrs}),
+		Config: cfg,
+	})
+}
+
+const (
+	revisionType = "TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION"
+	latestType   = "TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST"
+)
+
+func serviceWithTraffic(traffic ...*runpb.TrafficTarget) *runpb.Service {
+	return &runpb.Service{Name: serviceName, Traffic: traffic}
+}
+
+func TestShiftTraffic_ScalesRemainingSplit(t *testing.T) {
+	original := []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-003", Percent: 70},
+		{Type: revisionType, Revision: "svc-a-002", Percent: 30},
+		{Type: revisionType, Revision: "svc-a-001", Tag: "old"},
+	}
+
+	shifted := shiftTraffic(original, "svc-a-001", 25)
+
+	assert.Equal(t, []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-003", Percent: 53},
+		{Type: revisionType, Revision: "svc-a-002", Percent: 22},
+		{Type: revisionType, Revision: "svc-a-001", Percent: 25, Tag: "old"},
+	}, shifted)
+}
+
+func TestShiftTraffic_ReplacesShareOfTargetCarryingTraffic(t *testing.T) {
+	original := []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-001", Percent: 50},
+		{Type: revisionType, Revision: "svc-a-002", Percent: 50},
+	}
+
+	shifted := shiftTraffic(original, "svc-a-002", 30)
+
+	assert.Equal(t, []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-001", Percent: 70},
+		{Type: revisionType, Revision: "svc-a-002", Percent: 30},
+	}, shifted)
+}
+
+func TestShiftTraffic_SendsRestToLatestWhenTargetCarriedAllTraffic(t *testing.T) {
+	original := []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-001", Percent: 100},
+		{Type: revisionType, Revision: "svc-a-002", Tag: "canary"},
+	}
+
+	shifted := shiftTraffic(original, "svc-a-001", 30)
+
+	assert.Equal(t, []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-001", Percent: 30},
+		{Type: revisionType, Revision: "svc-a-002", Tag: "canary"},
+		{Type: latestType, Percent: 70},
+	}, shifted)
+}
+
+func TestShiftTraffic_FullShiftDropsUntaggedEntries(t *testing.T) {
+	original := []TrafficAllocation{
+		{Type: latestType, Percent: 100},
+		{Type: revisionType, Revision: "svc-a-002", Tag: "canary"},
+	}
+
+	shifted := shiftTraffic(original, "svc-a-001", 100)
+
+	assert.Equal(t, []TrafficAllocation{
+		{Type: revisionType, Revision: "svc-a-002", Tag: "canary"},
+		{Type: revisionType, Revision: "svc-a-001", Percent: 100},
+	}, shifted)
+}
+
+func TestTrafficShift_ShiftsAndRestores(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeServices()
+	api.services[serviceName] = serviceWithTraffic(
+		&runpb.TrafficTarget{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST, Percent: 100},
+		&runpb.TrafficTarget{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION, Revision: "svc-a-001", Tag: "broken"},
+	)
+	a := &trafficShiftAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"tag": "broken", "percent": 40}))
+	require.NoError(t, err)
+	assert.Equal(t, "svc-a-001", state.Revision)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.updates, 1)
+	assert.Equal(t, int32(60), api.updates[0].Traffic[0].Percent)
+	assert.Equal(t, int32(40), api.updates[0].Traffic[1].Percent)
+
+	api.pendingPolls[state.ShiftOperation] = 1
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.Nil(t, status.Messages)
+
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	require.NotNil(t, status.Messages)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.updates, 2)
+	assert.Equal(t, state.OriginalTraffic, toTrafficAllocations(api.services[serviceName].Traffic))
+}
+
+func TestTrafficShift_StopSkipsRestoredService(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeServices()
+	api.services[serviceName] = serviceWithTraffic(&runpb.TrafficTarget{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_LATEST, Percent: 100})
+	a := &trafficShiftAttack{clientProvider: api.provider()}
+	state := TrafficShiftState{
+		ProjectID:       "proj-a",
+		ServiceName:     serviceName,
+		ServiceID:       "svc-a",
+		OriginalTraffic: []TrafficAllocation{{Type: latestType, Percent: 100}},
+		ShiftOperation:  "operations/op-0",
+	}
+
+	_, err := a.Stop(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.Empty(t, api.updates)
+}
+
+func TestTrafficShift_StatusReportsFailedUpdate(t *testing.T) {
+	api := newFakeServices()
+	api.opErrors["operations/op-1"] = errors.New("revision svc-a-404 not found")
+	a := &trafficShiftAttack{clientProvider: api.provider()}
+	state := TrafficShiftState{ProjectID: "proj-a", ServiceID: "svc-a", ShiftOperation: "operations/op-1"}
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, "revision svc-a-404 not found", *status.Error.Detail)
+}
+
+func TestTrafficShift_Prepare_RejectsUnknownTagAndNoop(t *testing.T) {
+	api := newFakeServices()
+	api.services[serviceName] = serviceWithTraffic(&runpb.TrafficTarget{Type: runpb.TrafficTargetAllocationType_TRAFFIC_TARGET_ALLOCATION_TYPE_REVISION, Revision: "svc-a-001", Percent: 100})
+	a := &trafficShiftAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"tag": "missing", "percent": 50}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no revision tagged missing")
+
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"revision": "svc-a-001", "percent": 100}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already receives 100%")
+
+	api.services[serviceName].LatestReadyRevision = "projects/proj-a/locations/europe-west1/services/svc-a/revisions/svc-a-001"
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"revision": "svc-a-001", "percent": 30}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no other revision for the remaining 70%")
+
+	_, err = a.Prepare(context.Background(), &state, serviceReq(map[string]interface{}{"percent": 100}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Either a revision or a tag")
+}
@@ -27,8 +27,9 @@ import (
 type serviceDiscovery struct{}
 
 var (
-	_ discovery_kit_sdk.TargetDescriber    = (*serviceDiscovery)(nil)
-	_ discovery_kit_sdk.AttributeDescriber = (*serviceDiscovery)(nil)
+	_ discovery_kit_sdk.TargetDescriber          = (*serviceDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber       = (*serviceDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*serviceDiscovery)(nil)
 )
 
 func NewServiceDiscovery() discovery_kit_sdk.TargetDiscovery {
@@ -66,7 +67,7 @@ func (d *serviceDiscovery) DescribeTarget() discovery_kit_api.TargetDescription
 
 func (d *serviceDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
 	return []discovery_kit_api.AttributeDescription{
-		{Attribute: "gcp.cloudrun.service.name", Label: discovery_kit_api.PluralLabel{One: "Cloud Run service name", Other: "Cloud Run service names"}},
+		{Attribute: attrServiceName, Label: discovery_kit_api.PluralLabel{One: "Cloud Run service name", Other: "Cloud Run service names"}},
 		{Attribute: attrLocation, Label: discovery_kit_api.PluralLabel{One: "Cloud Run service location", Other: "Cloud Run service locations"}},
 		{Attribute: attrIngress, Label: discovery_kit_api.PluralLabel{One: "Cloud Run service ingress", Other: "Cloud Run service ingress"}},
 		{Attribute: "gcp.cloudrun.service.launch-stage", Label: discovery_kit_api.PluralLabel{One: "Cloud Run service launch stage", Other: "Cloud Run service launch stages"}},
@@ -83,6 +84,36 @@ func (d *serviceDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDes
 	}
 }
 
+// DescribeEnrichmentRules copies the service attributes onto its revisions, so a revision can be selected by the
+// ingress, scaling or labels of the service serving it.
+func (d *serviceDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		{
+			Id:      "com.steadybit.extension_gcp.cloudrun.service-to-revision",
+			Version: extbuild.GetSemverVersionStringOrUnknown(),
+			Src: discovery_kit_api.SourceOrDestination{
+				Type: TargetIDService,
+				Selector: map[string]string{
+					attrProjectID:   "${dest.gcp.project.id}",
+					attrLocation:    "${dest.gcp.cloudrun.service.location}",
+					attrServiceName: "${dest.gcp.cloudrun.service.name}",
+				},
+			},
+			Dest: discovery_kit_api.SourceOrDestination{
+				Type: TargetIDRevision,
+				Selector: map[string]string{
+					attrProjectID:   "${src.gcp.project.id}",
+					attrLocation:    "${src.gcp.cloudrun.service.location}",
+					attrServiceName: "${src.gcp.cloudrun.service.name}",
+				},
+			},
+			Attributes: []discovery_kit_api.Attribute{
+				{Matcher: discovery_kit_api.StartsWith, Name: "gcp.cloudrun.service."},
+			},
+		},
+	}
+}
+
 func (d *serviceDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
 	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
 		client, err := run.NewServicesClient(ctx, access.ClientOptions...)
@@ -118,7 +149,7 @@ func toServiceTarget(s *runpb.Service, projectID string) discovery_kit_api.Targe
 
 	attributes := make(map[string][]string)
 	attributes[attrProjectID] = []string{projectID}
-	attributes["gcp.cloudrun.service.name"] = []string{name}
+	attributes[attrServiceName] = []string{name}
 	if location != "" {
 		attributes[attrLocation] = []string{location}
 	}
@@ -178,12 +209,18 @@ func toServiceTarget(s *runpb.Service, projectID string) discovery_kit_api.Targe
 
 func parseServiceName(full string) (location, name string) {
 	// projects/<p>/locations/<region>/services/<name>
+	return parseResourceName(full, "services")
+}
+
+// parseResourceName returns the location and the ID following collection in a Cloud Run resource name, e.g. the
+// revision in "projects/<p>/locations/<region>/services/<service>/revisions/<revision>".
+func parseResourceName(full, collection string) (location, name string) {
 	parts := strings.Split(full, "/")
 	for i := 0; i+1 < len(parts); i++ {
 		switch parts[i] {
 		case "locations":
 			location = parts[i+1]
-		case "services":
+		case collection:
 			name = parts[i+1]
 		}
 	}
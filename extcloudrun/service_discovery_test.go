@@ -105,3 +105,12 @@ func TestNewServiceDiscovery(t *testing.T) {
 	d := NewServiceDiscovery()
 	assert.NotNil(t, d)
 }
+
+func TestServiceToRevisionEnrichmentRule(t *testing.T) {
+	rules := (&serviceDiscovery{}).DescribeEnrichmentRules()
+	assert.Len(t, rules, 1)
+	assert.Equal(t, TargetIDService, rules[0].Src.Type)
+	assert.Equal(t, TargetIDRevision, rules[0].Dest.Type)
+	assert.Equal(t, "${dest.gcp.cloudrun.service.name}", rules[0].Src.Selector[attrServiceName])
+	assert.Equal(t, "${src.gcp.project.id}", rules[0].Dest.Selector[attrProjectID])
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/sqladmin/v1"
+)
+
+// sqlInstancesApi is the slice of the sqladmin service the operation-tracking actions use. The generated service
+// exposes call builders rather than methods, so it is wrapped to be replaceable in tests.
+type sqlInstancesApi interface {
+	Get(ctx context.Context, projectID, instance string) (*sqladmin.DatabaseInstance, error)
+	Restart(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error)
+	Patch(ctx context.Context, projectID, instance string, body *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	StopReplica(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error)
+	StartReplica(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error)
+	Failover(ctx context.Context, projectID, instance string, settingsVersion int64) (*sqladmin.Operation, error)
+	GetOperation(ctx context.Context, projectID, operation string) (*sqladmin.Operation, error)
+}
+
+type sqlAdminClient struct {
+	svc *sqladmin.Service
+}
+
+func newSqlInstancesApi(ctx context.Context, projectID string) (sqlInstancesApi, error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := sqladmin.NewService(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlAdminClient{svc: svc}, nil
+}
+
+func (c *sqlAdminClient) Get(ctx context.Context, projectID, instance string) (*sqladmin.DatabaseInstance, error) {
+	return c.svc.Instances.Get(projectID, instance).Context(ctx).Do()
+}
+
+func (c *sqlAdminClient) Restart(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error) {
+	return c.svc.Instances.Restart(projectID, instance).Context(ctx).Do()
+}
+
+func (c *sqlAdminClient) Patch(ctx context.Context, projectID, instance string, body *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return c.svc.Instances.Patch(projectID, instance, body).Context(ctx).Do()
+}
+
+func (c *sqlAdminClient) StopReplica(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error) {
+	return c.svc.Instances.StopReplica(projectID, instance).Context(ctx).Do()
+}
+
+func (c *sqlAdminClient) StartReplica(ctx context.Context, projectID, instance string) (*sqladmin.Operation, error) {
+	return c.svc.Instances.StartReplica(projectID, instance).Context(ctx).Do()
+}
+
+func (c *sqlAdminClient) Failover(ctx context.Context, projectID, instance string, settingsVersion int64) (*sqladmin.Operation, error) {
+	return c.svc.Instances.Failover(projectID, instance, &sqladmin.InstancesFailoverRequest{
+		FailoverContext: &sqladmin.FailoverContext{
+			Kind:            "sql#failoverContext",
+			SettingsVersion: settingsVersion,
+		},
+	}).Context(ctx).Do()
+}
+
+func (c *sqlAdminClient) GetOperation(ctx context.Context, projectID, operation string) (*sqladmin.Operation, error) {
+	return c.svc.Operations.Get(projectID, operation).Context(ctx).Do()
+}
+
+// operationPoller follows sqladmin operations through Operations.Get, for utils.WaitForOperation. sqladmin operations
+// carry their failure in the resource, so a DONE operation with errors is reported as done with that error.
+func operationPoller(api sqlInstancesApi, projectID string) utils.OperationPoller {
+	return func(ctx context.Context, operation string) (bool, error) {
+		op, err := api.GetOperation(ctx, projectID, operation)
+		if err != nil {
+			return false, err
+		}
+		if op.Status != "DONE" {
+			return false, nil
+		}
+		if detail := operationErrorDetail(op); detail != "" {
+			return true, errors.New(detail)
+		}
+		return true, nil
+	}
+}
+
+// failedOperationResult ends a status-tracked action with the operation's error, or returns nil if it succeeded.
+func failedOperationResult(op *sqladmin.Operation, title string) *action_kit_api.StatusResult {
+	detail := operationErrorDetail(op)
+	if detail == "" {
+		return nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Error: &action_kit_api.ActionKitError{
+			Title:  title,
+			Detail: extutil.Ptr(detail),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		},
+	}
+}
+
+func operationErrorDetail(op *sqladmin.Operation) string {
+	if op.Error == nil {
+		return ""
+	}
+	details := make([]string, 0, len(op.Error.Errors))
+	for _, e := range op.Error.Errors {
+		details = append(details, fmt.Sprintf("%s: %s", e.Code, e.Message))
+	}
+	return strings.Join(details, "; ")
+}
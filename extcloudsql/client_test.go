@@ -0,0 +1,120 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/sqladmin/v1"
+)
+
+// fakeSqlInstances serves instances and operations from maps. Every read of an operation advances it through the
+// statuses queued in opStatuses; an operation without queued statuses is DONE.
+type fakeSqlInstances struct {
+	instances  map[string]*sqladmin.DatabaseInstance
+	opStatuses map[string][]string
+	opErrors   map[string]*sqladmin.OperationErrors
+	patches    []*sqladmin.DatabaseInstance
+	restarts   []string
+	replicaOps []string
+	failovers  []int64
+	nextOp     int
+}
+
+func newFakeSqlInstances() *fakeSqlInstances {
+	return &fakeSqlInstances{
+		instances:  map[string]*sqladmin.DatabaseInstance{},
+		opStatuses: map[string][]string{},
+		opErrors:   map[string]*sqladmin.OperationErrors{},
+	}
+}
+
+func (f *fakeSqlInstances) provider() func(context.Context, string) (sqlInstancesApi, error) {
+	return func(context.Context, string) (sqlInstancesApi, error) { return f, nil }
+}
+
+func (f *fakeSqlInstances) Get(_ context.Context, _, instance string) (*sqladmin.DatabaseInstance, error) {
+	if i, ok := f.instances[instance]; ok {
+		return i, nil
+	}
+	return nil, fmt.Errorf("instance %s not found", instance)
+}
+
+func (f *fakeSqlInstances) Restart(_ context.Context, _, instance string) (*sqladmin.Operation, error) {
+	f.restarts = append(f.restarts, instance)
+	return f.newOperation(), nil
+}
+
+func (f *fakeSqlInstances) Patch(_ context.Context, _, instance string, body *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	f.patches = append(f.patches, body)
+	if i, ok := f.instances[instance]; ok && body.Settings != nil {
+		i.Settings.ActivationPolicy = body.Settings.ActivationPolicy
+	}
+	return f.newOperation(), nil
+}
+
+func (f *fakeSqlInstances) StopReplica(_ context.Context, _, instance string) (*sqladmin.Operation, error) {
+	return f.setReplication(instance, false, "stop")
+}
+
+func (f *fakeSqlInstances) StartReplica(_ context.Context, _, instance string) (*sqladmin.Operation, error) {
+	return f.setReplication(instance, true, "start")
+}
+
+func (f *fakeSqlInstances) setReplication(instance string, enabled bool, call string) (*sqladmin.Operation, error) {
+	f.replicaOps = append(f.replicaOps, call)
+	if i, ok := f.instances[instance]; ok {
+		i.Settings.DatabaseReplicationEnabled = enabled
+	}
+	return f.newOperation(), nil
+}
+
+func (f *fakeSqlInstances) Failover(_ context.Context, _, _ string, settingsVersion int64) (*sqladmin.Operation, error) {
+	f.failovers = append(f.failovers, settingsVersion)
+	return f.newOperation(), nil
+}
+
+func (f *fakeSqlInstances) GetOperation(_ context.Context, _, operation string) (*sqladmin.Operation, error) {
+	op := &sqladmin.Operation{Name: operation, Status: "DONE", Error: f.opErrors[operation]}
+	if queued := f.opStatuses[operation]; len(queued) > 0 {
+		op.Status = queued[0]
+		f.opStatuses[operation] = queued[1:]
+	}
+	return op, nil
+}
+
+func (f *fakeSqlInstances) newOperation() *sqladmin.Operation {
+	f.nextOp++
+	return &sqladmin.Operation{Name: fmt.Sprintf("op-%d", f.nextOp), Status: "PENDING"}
+}
+
+func withFastOperationPolling(t *testing.T) {
+	previous := utils.OperationPollInterval
+	utils.OperationPollInterval = time.Millisecond
+	t.Cleanup(func() { utils.OperationPollInterval = previous })
+}
+
+func TestOperationPoller(t *testing.T) {
+	api := newFakeSqlInstances()
+	api.opStatuses["op-1"] = []string{"RUNNING"}
+	api.opErrors["op-2"] = &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "INTERNAL_ERROR", Message: "boom"}}}
+	poll := operationPoller(api, "proj-a")
+
+	done, err := poll(context.Background(), "op-1")
+	assert.False(t, done)
+	assert.NoError(t, err)
+	done, err = poll(context.Background(), "op-1")
+	assert.True(t, done)
+	assert.NoError(t, err)
+
+	done, err = poll(context.Background(), "op-2")
+	assert.True(t, done)
+	assert.ErrorContains(t, err, "INTERNAL_ERROR: boom")
+}
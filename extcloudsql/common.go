@@ -7,6 +7,9 @@ package extcloudsql
 const (
 	TargetIDInstance         = "com.steadybit.extension_gcp.cloudsql.instance"
 	InstanceFailoverActionId = "com.steadybit.extension_gcp.cloudsql.instance.failover"
+	InstanceRestartActionId  = "com.steadybit.extension_gcp.cloudsql.instance.restart"
+	InstanceStopActionId     = "com.steadybit.extension_gcp.cloudsql.instance.stop"
+	InstanceStopReplicaId    = "com.steadybit.extension_gcp.cloudsql.instance.stop-replica"
 	targetIcon               = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNNDQ3LjUsMTIzLjdsLTE4Ni02OC43Yy0zLjYtMS4zLTcuNS0xLjMtMTEuMSwwbC00MC43LDE1LTE0NS4yLDUzLjVjLTYuMywyLjMtMTAuNSw4LjMtMTAuNSwxNXM0LjIsMTIuNywxMC41LDE1bDE0NS4xLDUzLjRzMCwwLDAsMGw0MC44LDE1YzEuOC42LDMuNywxLDUuNSwxczMuNy0uMyw1LjUtMWwxODUuOS02OC4yYzYuMy0yLjMsMTAuNS04LjMsMTAuNS0xNSwwLTYuNy00LjItMTIuNy0xMC41LTE1aDBaTTI1NiwxODkuOWwtMTM5LjYtNTEuNCwxMzkuNi01MS40LDEzOS43LDUxLjYtMTM5LjYsNTEuMloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQ3LjUsMzg4LjhsLTE4NS45LDY4LjJjLTEuOC42LTMuNiwxLTUuNSwxcy0zLjctLjMtNS41LTFsLTQwLjgtMTVoMGwtMTQ1LjEtNTMuNGMtOC4zLTMtMTIuNS0xMi4yLTkuNS0yMC41LDMtOC4zLDEyLjItMTIuNSwyMC41LTkuNWwxODAuNCw2Ni40LDE4MC40LTY2LjJjOC4zLTMsMTcuNSwxLjIsMjAuNSw5LjUsMyw4LjMtMS4yLDE3LjUtOS41LDIwLjVoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQ3LjUsMzEwLjRsLTE4NS45LDY4LjJjLTEuOC42LTMuNiwxLTUuNSwxcy0zLjctLjMtNS41LTFsLTQwLjgtMTVoMGwtMTQ1LjEtNTMuNGMtOC4zLTMtMTIuNS0xMi4yLTkuNS0yMC41LDMtOC4zLDEyLjItMTIuNSwyMC41LTkuNWwxODAuNCw2Ni40LDE4MC40LTY2LjJjOC4zLTMsMTcuNSwxLjIsMjAuNSw5LjUsMyw4LjMtMS4yLDE3LjUtOS41LDIwLjVoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQ3LjUsMjMyLjFsLTE4NS45LDY4LjJjLTEuOC43LTMuNiwxLTUuNSwxcy0zLjctLjMtNS41LTFsLTQwLjgtMTVoMGwtMTQ1LjEtNTMuNGMtOC4zLTMuMS0xMi41LTEyLjItOS41LTIwLjUsMy04LjMsMTIuMi0xMi41LDIwLjUtOS41bDE4MC40LDY2LjQsMTgwLjQtNjYuMmM4LjMtMywxNy41LDEuMiwyMC41LDkuNSwzLDguMy0xLjIsMTcuNS05LjUsMjAuNWgwWiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik00NTgsMTM4LjdjMCw2LjctNC4yLDEyLjctMTAuNSwxNWwtMTg2LDY4LjJjLTEuOC43LTMuNiwxLTUuNSwxcy0zLjctLjMtNS41LTFsLTQwLjgtMTVoMHM0Ni40LTE3LDQ2LjQtMTdsMTM5LjYtNTEuMi0xMzkuNy01MS42LTQ2LjItMTcuMSw0MC43LTE1YzMuNi0xLjMsNy41LTEuMywxMS4xLDBsMTg2LDY4LjdjNi4zLDIuMywxMC41LDguMywxMC41LDE1aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTQ0Ny41LDM4OC44bC0xODUuOSw2OC4yYy0xLjguNi0zLjYsMS01LjUsMXMtMy43LS4zLTUuNS0xbC00MC44LTE1LDQ2LjQtMTcsMTgwLjQtNjYuMmM4LjMtMywxNy41LDEuMiwyMC41LDkuNSwzLDguMy0xLjIsMTcuNS05LjUsMjAuNWgwWiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik00NDcuNSwzMTAuNGwtMTg1LjksNjguMmMtMS44LjYtMy42LDEtNS41LDFzLTMuNy0uMy01LjUtMWwtNDAuOC0xNWgwbDQ2LjQtMTcsMTgwLjQtNjYuMmM4LjMtMywxNy41LDEuMiwyMC41LDkuNSwzLDguMy0xLjIsMTcuNS05LjUsMjAuNVoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQ3LjUsMjMyLjFsLTE4NS45LDY4LjJjLTEuOC43LTMuNiwxLTUuNSwxcy0zLjctLjMtNS41LTFsLTQwLjgtMTVoMGw0Ni40LTE3LDE4MC40LTY2LjJjOC4zLTMsMTcuNSwxLjIsMjAuNSw5LjUsMyw4LjMtMS4yLDE3LjUtOS41LDIwLjVoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMzAyLjQsMjA2LjlsLTE4Ni02OC41LTUxLjgtMTVjLTYuMywyLjMtMTAuNSw4LjMtMTAuNSwxNXM0LjIsMTIuNywxMC41LDE1bDE0NS4xLDUzLjRzMCwwLDAsMGw0MC44LDE1YzEuOC42LDMuNywxLDUuNSwxczMuNy0uMyw1LjUtMWw0MC45LTE1aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+Cjwvc3ZnPg=="
 
 	// Attribute names duplicated across DescribeTarget / DescribeAttributes /
@@ -15,4 +18,7 @@ const (
 	attrTier             = "gcp.cloudsql.tier"
 	attrAvailabilityType = "gcp.cloudsql.availability-type"
 	attrRegion           = "gcp.cloudsql.region"
+	attrInstanceType     = "gcp.cloudsql.instance-type"
+	attrMasterInstance   = "gcp.cloudsql.master-instance-name"
+	attrReplicaNames     = "gcp.cloudsql.replica-names"
 )
@@ -7,40 +7,36 @@ package extcloudsql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
-	"github.com/steadybit/extension-gcp/utils"
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
-	"google.golang.org/api/sqladmin/v1"
 )
 
-// CloudSqlFailoverState holds enough to trigger a failover. The attack is instantaneous and is not
-// reversible: Cloud SQL promotes the standby to primary and rebuilds a new HA standby behind it. Cloud
-// SQL only supports failover on HA (REGIONAL availability_type) instances.
+// CloudSqlFailoverState holds enough to trigger a failover and to verify it afterwards. The attack is not
+// reversible: Cloud SQL promotes the standby to primary and rebuilds a new HA standby behind it. Cloud SQL only
+// supports failover on HA (REGIONAL availability_type) instances.
 type CloudSqlFailoverState struct {
-	ProjectID    string
-	InstanceName string
+	ProjectID     string
+	InstanceName  string
+	ZoneBefore    string
+	OperationName string
+	OperationDone bool
+	StartedAt     time.Time
 }
 
 type cloudSqlFailoverAttack struct {
-	clientProvider func(ctx context.Context, projectID string) (*sqladmin.Service, error)
+	clientProvider func(ctx context.Context, projectID string) (sqlInstancesApi, error)
 }
 
 var _ action_kit_sdk.Action[CloudSqlFailoverState] = (*cloudSqlFailoverAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[CloudSqlFailoverState] = (*cloudSqlFailoverAttack)(nil)
 
-func NewInstanceFailoverAction() action_kit_sdk.Action[CloudSqlFailoverState] {
-	return &cloudSqlFailoverAttack{
-		clientProvider: func(ctx context.Context, projectID string) (*sqladmin.Service, error) {
-			access, err := utils.GetGcpAccess(projectID)
-			if err != nil {
-				return nil, err
-			}
-			return sqladmin.NewService(ctx, access.ClientOptions...)
-		},
-	}
+func NewInstanceFailoverAction() action_kit_sdk.ActionWithStatus[CloudSqlFailoverState] {
+	return &cloudSqlFailoverAttack{clientProvider: newSqlInstancesApi}
 }
 
 func (a *cloudSqlFailoverAttack) NewEmptyState() CloudSqlFailoverState {
@@ -51,7 +47,7 @@ func (a *cloudSqlFailoverAttack) Describe() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:    InstanceFailoverActionId,
 		Label: "Trigger Cloud SQL failover",
-		Description: "Fails over a REGIONAL Cloud SQL HA instance to its standby. Not reversible.",
+		Description: "Fails over a REGIONAL Cloud SQL HA instance to its standby, waits until it is RUNNABLE again and verifies the primary moved to another zone. Not reversible.",
 		Version: extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:    extutil.Ptr(targetIcon),
 		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
@@ -66,9 +62,12 @@ func (a *cloudSqlFailoverAttack) Describe() action_kit_api.ActionDescription {
 		}),
 		Technology:  extutil.Ptr("GCP"),
 		Category:    extutil.Ptr("Cloud SQL"),
-		TimeControl: action_kit_api.TimeControlInstantaneous,
+		TimeControl: action_kit_api.TimeControlInternal,
 		Kind:        action_kit_api.Attack,
 		Parameters:  []action_kit_api.ActionParameter{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
 	}
 }
 
@@ -85,7 +84,7 @@ func (a *cloudSqlFailoverAttack) Prepare(_ context.Context, state *CloudSqlFailo
 }
 
 func (a *cloudSqlFailoverAttack) Start(ctx context.Context, state *CloudSqlFailoverState) (*action_kit_api.StartResult, error) {
-	svc, err := a.clientProvider(ctx, state.ProjectID)
+	api, err := a.clientProvider(ctx, state.ProjectID)
 	if err != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
 	}
@@ -94,26 +93,85 @@ func (a *cloudSqlFailoverAttack) Start(ctx context.Context, state *CloudSqlFailo
 	// settingsVersion — GCP uses it as an optimistic-concurrency fingerprint
 	// and fails the request if the instance was mutated between our Get and
 	// our Failover call.
-	inst, err := svc.Instances.Get(state.ProjectID, state.InstanceName).Context(ctx).Do()
+	inst, err := api.Get(ctx, state.ProjectID, state.InstanceName)
 	if err != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to fetch Cloud SQL instance %s to read settingsVersion", state.InstanceName), err)
 	}
 	if inst.Settings == nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Cloud SQL instance %s has no Settings — cannot compute failover context", state.InstanceName), nil)
 	}
-	_, err = svc.Instances.Failover(state.ProjectID, state.InstanceName, &sqladmin.InstancesFailoverRequest{
-		FailoverContext: &sqladmin.FailoverContext{
-			Kind:            "sql#failoverContext",
-			SettingsVersion: inst.Settings.SettingsVersion,
-		},
-	}).Context(ctx).Do()
+	state.ZoneBefore = inst.GceZone
+	state.StartedAt = time.Now()
+	op, err := api.Failover(ctx, state.ProjectID, state.InstanceName, inst.Settings.SettingsVersion)
 	if err != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to trigger Cloud SQL failover for %s", state.InstanceName), err)
 	}
+	state.OperationName = op.Name
 	return &action_kit_api.StartResult{
 		Messages: extutil.Ptr([]action_kit_api.Message{{
 			Level:   extutil.Ptr(action_kit_api.Info),
-			Message: fmt.Sprintf("Failover triggered for Cloud SQL instance %s", state.InstanceName),
+			Message: fmt.Sprintf("Failover triggered for Cloud SQL instance %s (primary in %s)", state.InstanceName, state.ZoneBefore),
+		}}),
+	}, nil
+}
+
+// Status first follows the failover operation, then waits for the instance to be RUNNABLE again. The time from
+// Start until then is reported as the failover duration.
+func (a *cloudSqlFailoverAttack) Status(ctx context.Context, state *CloudSqlFailoverState) (*action_kit_api.StatusResult, error) {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	if !state.OperationDone {
+		op, err := api.GetOperation(ctx, state.ProjectID, state.OperationName)
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to get operation %s of Cloud SQL instance %s", state.OperationName, state.InstanceName), err)
+		}
+		if op.Status != "DONE" {
+			return &action_kit_api.StatusResult{Completed: false}, nil
+		}
+		if failed := failedOperationResult(op, fmt.Sprintf("Failover of Cloud SQL instance %s failed", state.InstanceName)); failed != nil {
+			return failed, nil
+		}
+		state.OperationDone = true
+	}
+
+	inst, err := api.Get(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud SQL instance %s", state.InstanceName), err)
+	}
+	if inst.State != "RUNNABLE" {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	now := time.Now()
+	elapsed := now.Sub(state.StartedAt)
+	metrics := extutil.Ptr([]action_kit_api.Metric{{
+		Name: extutil.Ptr("gcp_cloudsql_failover_duration_seconds"),
+		Metric: map[string]string{
+			"gcp.project.id":             state.ProjectID,
+			"gcp.cloudsql.instance.name": state.InstanceName,
+			"zone.before":                state.ZoneBefore,
+			"zone.after":                 inst.GceZone,
+		},
+		Timestamp: now,
+		Value:     elapsed.Seconds(),
+	}})
+	if inst.GceZone == state.ZoneBefore {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Metrics:   metrics,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Cloud SQL instance %s is RUNNABLE again, but its primary is still in %s", state.InstanceName, inst.GceZone),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Metrics:   metrics,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Cloud SQL instance %s failed over from %s to %s in %s", state.InstanceName, state.ZoneBefore, inst.GceZone, elapsed.Round(time.Second)),
 		}}),
 	}, nil
 }
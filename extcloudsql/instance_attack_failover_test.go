@@ -7,11 +7,13 @@ package extcloudsql
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/sqladmin/v1"
 )
 
 func TestCloudSqlFailover_Prepare_Success(t *testing.T) {
@@ -95,6 +97,80 @@ func TestCloudSqlFailover_NewInstanceFailoverAction(t *testing.T) {
 	assert.NotNil(t, a)
 }
 
+func regionalInstance(zone, state string) *sqladmin.DatabaseInstance {
+	return &sqladmin.DatabaseInstance{
+		Name:     "primary",
+		State:    state,
+		GceZone:  zone,
+		Settings: &sqladmin.Settings{AvailabilityType: "REGIONAL", SettingsVersion: 7},
+	}
+}
+
+func TestCloudSqlFailover_WaitsUntilRunnableAndReportsDuration(t *testing.T) {
+	api := newFakeSqlInstances()
+	api.instances["primary"] = regionalInstance("europe-west1-b", "RUNNABLE")
+	a := &cloudSqlFailoverAttack{clientProvider: api.provider()}
+	state := CloudSqlFailoverState{ProjectID: "proj-a", InstanceName: "primary"}
+
+	_, err := a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7}, api.failovers)
+	assert.Equal(t, "europe-west1-b", state.ZoneBefore)
+	state.StartedAt = state.StartedAt.Add(-42 * time.Second)
+
+	api.opStatuses[state.OperationName] = []string{"RUNNING"}
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	api.instances["primary"] = regionalInstance("europe-west1-c", "MAINTENANCE")
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.True(t, state.OperationDone)
+
+	api.instances["primary"] = regionalInstance("europe-west1-c", "RUNNABLE")
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.Nil(t, status.Error)
+	assert.Contains(t, (*status.Messages)[0].Message, "from europe-west1-b to europe-west1-c in 42s")
+	require.Len(t, *status.Metrics, 1)
+	metric := (*status.Metrics)[0]
+	assert.Equal(t, "gcp_cloudsql_failover_duration_seconds", *metric.Name)
+	assert.InDelta(t, 42, metric.Value, 1)
+	assert.Equal(t, "europe-west1-c", metric.Metric["zone.after"])
+}
+
+func TestCloudSqlFailover_FailsWhenPrimaryDidNotMove(t *testing.T) {
+	api := newFakeSqlInstances()
+	api.instances["primary"] = regionalInstance("europe-west1-b", "RUNNABLE")
+	a := &cloudSqlFailoverAttack{clientProvider: api.provider()}
+	state := CloudSqlFailoverState{ProjectID: "proj-a", InstanceName: "primary", ZoneBefore: "europe-west1-b", OperationName: "op-1", StartedAt: time.Now()}
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Contains(t, status.Error.Title, "still in europe-west1-b")
+	assert.NotNil(t, status.Metrics)
+}
+
+func TestCloudSqlFailover_ReportsOperationError(t *testing.T) {
+	api := newFakeSqlInstances()
+	api.opErrors["op-1"] = &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "OPERATION_FAILED", Message: "standby unhealthy"}}}
+	a := &cloudSqlFailoverAttack{clientProvider: api.provider()}
+	state := CloudSqlFailoverState{ProjectID: "proj-a", InstanceName: "primary", OperationName: "op-1"}
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, "OPERATION_FAILED: standby unhealthy", *status.Error.Detail)
+}
+
 func TestMustHave(t *testing.T) {
 	attrs := map[string][]string{
 		"present": {"value"},
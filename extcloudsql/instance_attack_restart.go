@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// CloudSqlRestartState tracks the restart operation so Status can follow it until DONE. A restart is not
+// reversible, but also leaves nothing to restore: the instance comes back with the same configuration.
+type CloudSqlRestartState struct {
+	ProjectID     string
+	InstanceName  string
+	OperationName string
+}
+
+type cloudSqlRestartAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (sqlInstancesApi, error)
+}
+
+var _ action_kit_sdk.Action[CloudSqlRestartState] = (*cloudSqlRestartAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[CloudSqlRestartState] = (*cloudSqlRestartAttack)(nil)
+
+func NewInstanceRestartAction() action_kit_sdk.ActionWithStatus[CloudSqlRestartState] {
+	return &cloudSqlRestartAttack{clientProvider: newSqlInstancesApi}
+}
+
+func (a *cloudSqlRestartAttack) NewEmptyState() CloudSqlRestartState {
+	return CloudSqlRestartState{}
+}
+
+func (a *cloudSqlRestartAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          InstanceRestartActionId,
+		Label:       "Restart Cloud SQL instance",
+		Description: "Restarts a Cloud SQL instance and waits for the restart operation to complete. Open connections are dropped.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDInstance,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by Cloud SQL instance name",
+					Description: extutil.Ptr("Find Cloud SQL instance by name"),
+					Query:       "gcp.cloudsql.instance.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Cloud SQL"),
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Attack,
+		Parameters:  []action_kit_api.ActionParameter{},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+	}
+}
+
+func (a *cloudSqlRestartAttack) Prepare(_ context.Context, state *CloudSqlRestartState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, "gcp.project.id")
+	state.InstanceName = mustHave(request.Target.Attributes, "gcp.cloudsql.instance.name")
+	if state.ProjectID == "" || state.InstanceName == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.cloudsql.instance.name", nil)
+	}
+	return nil, nil
+}
+
+func (a *cloudSqlRestartAttack) Start(ctx context.Context, state *CloudSqlRestartState) (*action_kit_api.StartResult, error) {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	op, err := api.Restart(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restart Cloud SQL instance %s", state.InstanceName), err)
+	}
+	state.OperationName = op.Name
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Restart of Cloud SQL instance %s requested (operation %s)", state.InstanceName, state.OperationName),
+		}}),
+	}, nil
+}
+
+func (a *cloudSqlRestartAttack) Status(ctx context.Context, state *CloudSqlRestartState) (*action_kit_api.StatusResult, error) {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	op, err := api.GetOperation(ctx, state.ProjectID, state.OperationName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get operation %s of Cloud SQL instance %s", state.OperationName, state.InstanceName), err)
+	}
+	if op.Status != "DONE" {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	if failed := failedOperationResult(op, fmt.Sprintf("Restart of Cloud SQL instance %s failed", state.InstanceName)); failed != nil {
+		return failed, nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Cloud SQL instance %s restarted", state.InstanceName),
+		}}),
+	}, nil
+}
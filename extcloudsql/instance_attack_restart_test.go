@@ -0,0 +1,72 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/sqladmin/v1"
+)
+
+func TestCloudSqlRestart_TracksOperationUntilDone(t *testing.T) {
+	api := newFakeSqlInstances()
+	a := &cloudSqlRestartAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+	_, err := a.Prepare(context.Background(), &state, extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"gcp.project.id":             {"proj-a"},
+				"gcp.cloudsql.instance.name": {"primary"},
+			},
+		}),
+	}))
+	require.NoError(t, err)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"primary"}, api.restarts)
+	assert.Equal(t, "op-1", state.OperationName)
+	api.opStatuses["op-1"] = []string{"RUNNING"}
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.Nil(t, status.Error)
+}
+
+func TestCloudSqlRestart_ReportsOperationError(t *testing.T) {
+	api := newFakeSqlInstances()
+	api.opErrors["op-1"] = &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "INTERNAL_ERROR", Message: "boom"}}}
+	a := &cloudSqlRestartAttack{clientProvider: api.provider()}
+	state := CloudSqlRestartState{ProjectID: "proj-a", InstanceName: "primary", OperationName: "op-1"}
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, "INTERNAL_ERROR: boom", *status.Error.Detail)
+}
+
+func TestCloudSqlRestart_Prepare_MissingInstanceName(t *testing.T) {
+	a := &cloudSqlRestartAttack{}
+	state := a.NewEmptyState()
+	_, err := a.Prepare(context.Background(), &state, extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{"gcp.project.id": {"proj-a"}},
+		}),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gcp.cloudsql.instance.name")
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/sqladmin/v1"
+)
+
+const activationPolicyNever = "NEVER"
+
+// CloudSqlStopState records the activation policy found in Prepare. The attack is reversible: Stop patches the
+// original policy back, which starts the instance again when it was ALWAYS.
+type CloudSqlStopState struct {
+	ProjectID                string
+	InstanceName             string
+	OriginalActivationPolicy string
+	StopOperation            string
+	StoppedReported          bool
+}
+
+type cloudSqlStopAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (sqlInstancesApi, error)
+}
+
+var _ action_kit_sdk.Action[CloudSqlStopState] = (*cloudSqlStopAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[CloudSqlStopState] = (*cloudSqlStopAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[CloudSqlStopState] = (*cloudSqlStopAttack)(nil)
+
+func NewInstanceStopAction() action_kit_sdk.ActionWithStop[CloudSqlStopState] {
+	return &cloudSqlStopAttack{clientProvider: newSqlInstancesApi}
+}
+
+func (a *cloudSqlStopAttack) NewEmptyState() CloudSqlStopState {
+	return CloudSqlStopState{}
+}
+
+func (a *cloudSqlStopAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          InstanceStopActionId,
+		Label:       "Stop Cloud SQL instance",
+		Description: "Stops a Cloud SQL instance by setting its activation policy to NEVER for the given duration. The original activation policy is restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDInstance,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by Cloud SQL instance name",
+					Description: extutil.Ptr("Find Cloud SQL instance by name"),
+					Query:       "gcp.cloudsql.instance.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Cloud SQL"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the instance stays stopped. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *cloudSqlStopAttack) Prepare(ctx context.Context, state *CloudSqlStopState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, "gcp.project.id")
+	state.InstanceName = mustHave(request.Target.Attributes, "gcp.cloudsql.instance.name")
+	if state.ProjectID == "" || state.InstanceName == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.cloudsql.instance.name", nil)
+	}
+
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	// Read the policy live rather than from discovery: restoring a stale value would leave the instance in the wrong state.
+	instance, err := api.Get(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud SQL instance %s", state.InstanceName), err)
+	}
+	if instance.State != "RUNNABLE" {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud SQL instance %s is %s; only RUNNABLE instances can be stopped", state.InstanceName, instance.State), nil)
+	}
+	if instance.Settings == nil || instance.Settings.ActivationPolicy == "" {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud SQL instance %s has no activation policy", state.InstanceName), nil)
+	}
+	if instance.Settings.ActivationPolicy == activationPolicyNever {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud SQL instance %s already has activation policy NEVER", state.InstanceName), nil)
+	}
+	state.OriginalActivationPolicy = instance.Settings.ActivationPolicy
+	return nil, nil
+}
+
+func (a *cloudSqlStopAttack) Start(ctx context.Context, state *CloudSqlStopState) (*action_kit_api.StartResult, error) {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	op, err := api.Patch(ctx, state.ProjectID, state.InstanceName, activationPolicyPatch(activationPolicyNever))
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to stop Cloud SQL instance %s", state.InstanceName), err)
+	}
+	state.StopOperation = op.Name
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Stop of Cloud SQL instance %s requested (activation policy %s -> NEVER)", state.InstanceName, state.OriginalActivationPolicy),
+		}}),
+	}, nil
+}
+
+// Status follows the stop operation. The action keeps running for the configured duration; only a failed stop
+// ends it early.
+func (a *cloudSqlStopAttack) Status(ctx context.Context, state *CloudSqlStopState) (*action_kit_api.StatusResult, error) {
+	if state.StoppedReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	op, err := api.GetOperation(ctx, state.ProjectID, state.StopOperation)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get operation %s of Cloud SQL instance %s", state.StopOperation, state.InstanceName), err)
+	}
+	if op.Status != "DONE" {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	if failed := failedOperationResult(op, fmt.Sprintf("Stop of Cloud SQL instance %s failed", state.InstanceName)); failed != nil {
+		return failed, nil
+	}
+	state.StoppedReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Cloud SQL instance %s stopped", state.InstanceName),
+		}}),
+	}, nil
+}
+
+func (a *cloudSqlStopAttack) Stop(ctx context.Context, state *CloudSqlStopState) (*action_kit_api.StopResult, error) {
+	if state.StopOperation == "" {
+		return nil, nil
+	}
+	if err := a.restoreActivationPolicy(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore activation policy %s of Cloud SQL instance %s", state.OriginalActivationPolicy, state.InstanceName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore activation policy %s of Cloud SQL instance %s", state.OriginalActivationPolicy, state.InstanceName), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Activation policy of Cloud SQL instance %s restored to %s", state.InstanceName, state.OriginalActivationPolicy),
+		}}),
+	}, nil
+}
+
+// restoreActivationPolicy waits for the stop to settle first, because Cloud SQL rejects a patch while another
+// operation on the instance is running. A policy that is already back to the original is left alone.
+func (a *cloudSqlStopAttack) restoreActivationPolicy(ctx context.Context, state *CloudSqlStopState) error {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	if err := utils.WaitForOperation(ctx, operationPoller(api, state.ProjectID), state.StopOperation); err != nil {
+		log.Warn().Err(err).Msgf("Stop of Cloud SQL instance %s did not complete cleanly", state.InstanceName)
+	}
+	instance, err := api.Get(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return err
+	}
+	if instance.Settings != nil && instance.Settings.ActivationPolicy == state.OriginalActivationPolicy {
+		return nil
+	}
+	op, err := api.Patch(ctx, state.ProjectID, state.InstanceName, activationPolicyPatch(state.OriginalActivationPolicy))
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, operationPoller(api, state.ProjectID), op.Name)
+}
+
+func activationPolicyPatch(policy string) *sqladmin.DatabaseInstance {
+	return &sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{ActivationPolicy: policy}}
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+const instanceTypeReadReplica = "READ_REPLICA_INSTANCE"
+
+// CloudSqlStopReplicaState tracks the replica whose replication is paused. The attack is reversible: Stop calls
+// StartReplica, after which the replica catches up with its primary.
+type CloudSqlStopReplicaState struct {
+	ProjectID       string
+	InstanceName    string
+	PrimaryName     string
+	StopOperation   string
+	StoppedReported bool
+}
+
+type cloudSqlStopReplicaAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (sqlInstancesApi, error)
+}
+
+var _ action_kit_sdk.Action[CloudSqlStopReplicaState] = (*cloudSqlStopReplicaAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[CloudSqlStopReplicaState] = (*cloudSqlStopReplicaAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[CloudSqlStopReplicaState] = (*cloudSqlStopReplicaAttack)(nil)
+
+func NewInstanceStopReplicaAction() action_kit_sdk.ActionWithStop[CloudSqlStopReplicaState] {
+	return &cloudSqlStopReplicaAttack{clientProvider: newSqlInstancesApi}
+}
+
+func (a *cloudSqlStopReplicaAttack) NewEmptyState() CloudSqlStopReplicaState {
+	return CloudSqlStopReplicaState{}
+}
+
+func (a *cloudSqlStopReplicaAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          InstanceStopReplicaId,
+		Label:       "Stop Cloud SQL replication",
+		Description: "Stops replication on a Cloud SQL read replica for the given duration, so replication lag builds up. Replication is started again on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDInstance,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by Cloud SQL replica name",
+					Description: extutil.Ptr("Find Cloud SQL read replica by name"),
+					Query:       "gcp.cloudsql.instance.name=\"\" AND gcp.cloudsql.instance-type=\"READ_REPLICA_INSTANCE\"",
+				},
+				{
+					Label:       "by Cloud SQL primary name",
+					Description: extutil.Ptr("Find the read replicas of a Cloud SQL primary"),
+					Query:       "gcp.cloudsql.master-instance-name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Cloud SQL"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long replication stays stopped. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *cloudSqlStopReplicaAttack) Prepare(ctx context.Context, state *CloudSqlStopReplicaState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, "gcp.project.id")
+	state.InstanceName = mustHave(request.Target.Attributes, "gcp.cloudsql.instance.name")
+	if state.ProjectID == "" || state.InstanceName == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.cloudsql.instance.name", nil)
+	}
+
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	instance, err := api.Get(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Cloud SQL instance %s", state.InstanceName), err)
+	}
+	if instance.InstanceType != instanceTypeReadReplica {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud SQL instance %s is a %s; replication can only be stopped on a read replica", state.InstanceName, instance.InstanceType), nil)
+	}
+	if instance.State != "RUNNABLE" {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cloud SQL replica %s is %s; only RUNNABLE replicas can be attacked", state.InstanceName, instance.State), nil)
+	}
+	// Starting replication on Stop would resume a pause someone else made deliberately, so refuse instead.
+	if instance.Settings != nil && !instance.Settings.DatabaseReplicationEnabled {
+		return nil, extension_kit.ToError(fmt.Sprintf("Replication of Cloud SQL replica %s is already stopped", state.InstanceName), nil)
+	}
+	state.PrimaryName = instance.MasterInstanceName
+	return nil, nil
+}
+
+func (a *cloudSqlStopReplicaAttack) Start(ctx context.Context, state *CloudSqlStopReplicaState) (*action_kit_api.StartResult, error) {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	op, err := api.StopReplica(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to stop replication of Cloud SQL replica %s", state.InstanceName), err)
+	}
+	state.StopOperation = op.Name
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Stop of replication from %s to Cloud SQL replica %s requested", state.PrimaryName, state.InstanceName),
+		}}),
+	}, nil
+}
+
+func (a *cloudSqlStopReplicaAttack) Status(ctx context.Context, state *CloudSqlStopReplicaState) (*action_kit_api.StatusResult, error) {
+	if state.StoppedReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Cloud SQL client for project %s", state.ProjectID), err)
+	}
+	op, err := api.GetOperation(ctx, state.ProjectID, state.StopOperation)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get operation %s of Cloud SQL replica %s", state.StopOperation, state.InstanceName), err)
+	}
+	if op.Status != "DONE" {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	if failed := failedOperationResult(op, fmt.Sprintf("Stopping replication of Cloud SQL replica %s failed", state.InstanceName)); failed != nil {
+		return failed, nil
+	}
+	state.StoppedReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Replication of Cloud SQL replica %s stopped", state.InstanceName),
+		}}),
+	}, nil
+}
+
+func (a *cloudSqlStopReplicaAttack) Stop(ctx context.Context, state *CloudSqlStopReplicaState) (*action_kit_api.StopResult, error) {
+	if state.StopOperation == "" {
+		return nil, nil
+	}
+	if err := a.startReplication(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to start replication of Cloud SQL replica %s", state.InstanceName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to start replication of Cloud SQL replica %s", state.InstanceName), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Replication of Cloud SQL replica %s started again", state.InstanceName),
+		}}),
+	}, nil
+}
+
+// startReplication lets the stop operation finish before starting replication, since Cloud SQL serializes
+// operations per instance. A replica that is already replicating again is left alone.
+func (a *cloudSqlStopReplicaAttack) startReplication(ctx context.Context, state *CloudSqlStopReplicaState) error {
+	api, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	if err := utils.WaitForOperation(ctx, operationPoller(api, state.ProjectID), state.StopOperation); err != nil {
+		log.Warn().Err(err).Msgf("Stopping replication of Cloud SQL replica %s did not complete cleanly", state.InstanceName)
+	}
+	instance, err := api.Get(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return err
+	}
+	if instance.Settings != nil && instance.Settings.DatabaseReplicationEnabled {
+		return nil
+	}
+	op, err := api.StartReplica(ctx, state.ProjectID, state.InstanceName)
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, operationPoller(api, state.ProjectID), op.Name)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/sqladmin/v1"
+)
+
+func replicaPrepareRequest() action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 60000},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"gcp.project.id":             {"proj-a"},
+				"gcp.cloudsql.instance.name": {"replica-1"},
+			},
+		}),
+	})
+}
+
+func replicatingReplica() *sqladmin.DatabaseInstance {
+	return &sqladmin.DatabaseInstance{
+		Name:               "replica-1",
+		State:              "RUNNABLE",
+		InstanceType:       instanceTypeReadReplica,
+		MasterInstanceName: "primary",
+		Settings:           &sqladmin.Settings{DatabaseReplicationEnabled: true},
+	}
+}
+
+func TestCloudSqlStopReplica_StopsAndStartsReplication(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeSqlInstances()
+	api.instances["replica-1"] = replicatingReplica()
+	a := &cloudSqlStopReplicaAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, replicaPrepareRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "primary", state.PrimaryName)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, api.instances["replica-1"].Settings.DatabaseReplicationEnabled)
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.True(t, state.StoppedReported)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, api.instances["replica-1"].Settings.DatabaseReplicationEnabled)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stop", "start"}, api.replicaOps)
+}
+
+func TestCloudSqlStopReplica_Prepare_RejectsInstances(t *testing.T) {
+	primary := replicatingReplica()
+	primary.InstanceType = "CLOUD_SQL_INSTANCE"
+	paused := replicatingReplica()
+	paused.Settings.DatabaseReplicationEnabled = false
+
+	tests := []struct {
+		name     string
+		instance *sqladmin.DatabaseInstance
+		wantErr  string
+	}{
+		{name: "primary instance", instance: primary, wantErr: "only be stopped on a read replica"},
+		{name: "replication already stopped", instance: paused, wantErr: "already stopped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeSqlInstances()
+			api.instances["replica-1"] = tt.instance
+			a := &cloudSqlStopReplicaAttack{clientProvider: api.provider()}
+			state := a.NewEmptyState()
+
+			_, err := a.Prepare(context.Background(), &state, replicaPrepareRequest())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
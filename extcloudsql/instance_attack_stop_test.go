@@ -0,0 +1,118 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extcloudsql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/sqladmin/v1"
+)
+
+func stopPrepareRequest() action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 60000},
+		Target: extutil.Ptr(action_kit_api.Target{
+			Attributes: map[string][]string{
+				"gcp.project.id":             {"proj-a"},
+				"gcp.cloudsql.instance.name": {"primary"},
+			},
+		}),
+	})
+}
+
+func runnableInstance(policy string) *sqladmin.DatabaseInstance {
+	return &sqladmin.DatabaseInstance{Name: "primary", State: "RUNNABLE", Settings: &sqladmin.Settings{ActivationPolicy: policy}}
+}
+
+func TestCloudSqlStop_StopsAndRestoresActivationPolicy(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeSqlInstances()
+	api.instances["primary"] = runnableInstance("ALWAYS")
+	a := &cloudSqlStopAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, stopPrepareRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "ALWAYS", state.OriginalActivationPolicy)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, "NEVER", api.instances["primary"].Settings.ActivationPolicy)
+
+	api.opStatuses[state.StopOperation] = []string{"RUNNING"}
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.Nil(t, status.Messages)
+
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	require.NotNil(t, status.Messages)
+	assert.True(t, state.StoppedReported)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, "ALWAYS", api.instances["primary"].Settings.ActivationPolicy)
+	require.Len(t, api.patches, 2)
+
+	// A retried stop finds the policy already restored and does not patch again.
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Len(t, api.patches, 2)
+}
+
+func TestCloudSqlStop_StatusEndsOnFailedStop(t *testing.T) {
+	api := newFakeSqlInstances()
+	api.opErrors["op-1"] = &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "INVALID_REQUEST", Message: "nope"}}}
+	a := &cloudSqlStopAttack{clientProvider: api.provider()}
+	state := CloudSqlStopState{ProjectID: "proj-a", InstanceName: "primary", OriginalActivationPolicy: "ALWAYS", StopOperation: "op-1"}
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, action_kit_api.Failed, *status.Error.Status)
+}
+
+func TestCloudSqlStop_Prepare_RejectsInstances(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance *sqladmin.DatabaseInstance
+		wantErr  string
+	}{
+		{name: "already stopped", instance: runnableInstance("NEVER"), wantErr: "already has activation policy NEVER"},
+		{name: "not runnable", instance: &sqladmin.DatabaseInstance{State: "MAINTENANCE", Settings: &sqladmin.Settings{ActivationPolicy: "ALWAYS"}}, wantErr: "only RUNNABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeSqlInstances()
+			api.instances["primary"] = tt.instance
+			a := &cloudSqlStopAttack{clientProvider: api.provider()}
+			state := a.NewEmptyState()
+
+			_, err := a.Prepare(context.Background(), &state, stopPrepareRequest())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCloudSqlStop_StopWithoutStartIsNoop(t *testing.T) {
+	a := &cloudSqlStopAttack{}
+	state := CloudSqlStopState{ProjectID: "proj-a", InstanceName: "primary", OriginalActivationPolicy: "ALWAYS"}
+
+	result, err := a.Stop(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -73,7 +73,9 @@ func (d *instanceDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDe
 		{Attribute: "gcp.cloudsql.gce-zone", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL GCE zone", Other: "Cloud SQL GCE zones"}},
 		{Attribute: "gcp.cloudsql.secondary-gce-zone", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL secondary zone", Other: "Cloud SQL secondary zones"}},
 		{Attribute: "gcp.cloudsql.state", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL state", Other: "Cloud SQL states"}},
-		{Attribute: "gcp.cloudsql.instance-type", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL instance type", Other: "Cloud SQL instance types"}},
+		{Attribute: attrInstanceType, Label: discovery_kit_api.PluralLabel{One: "Cloud SQL instance type", Other: "Cloud SQL instance types"}},
+		{Attribute: attrMasterInstance, Label: discovery_kit_api.PluralLabel{One: "Cloud SQL primary instance", Other: "Cloud SQL primary instances"}},
+		{Attribute: attrReplicaNames, Label: discovery_kit_api.PluralLabel{One: "Cloud SQL replica", Other: "Cloud SQL replicas"}},
 		{Attribute: "gcp.cloudsql.backup-enabled", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL backups enabled", Other: "Cloud SQL backups enabled"}},
 		{Attribute: "gcp.cloudsql.point-in-time-recovery-enabled", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL PITR enabled", Other: "Cloud SQL PITR enabled"}},
 		{Attribute: "gcp.cloudsql.deletion-protection-enabled", Label: discovery_kit_api.PluralLabel{One: "Cloud SQL deletion protection", Other: "Cloud SQL deletion protection"}},
@@ -131,7 +133,13 @@ func toInstanceTarget(inst *sqladmin.DatabaseInstance, projectID string) discove
 		attributes["gcp.cloudsql.state"] = []string{inst.State}
 	}
 	if inst.InstanceType != "" {
-		attributes["gcp.cloudsql.instance-type"] = []string{inst.InstanceType}
+		attributes[attrInstanceType] = []string{inst.InstanceType}
+	}
+	if inst.MasterInstanceName != "" {
+		attributes[attrMasterInstance] = []string{inst.MasterInstanceName}
+	}
+	if len(inst.ReplicaNames) > 0 {
+		attributes[attrReplicaNames] = inst.ReplicaNames
 	}
 	if inst.Settings != nil {
 		if inst.Settings.Tier != "" {
@@ -69,6 +69,24 @@ func TestToInstanceTarget_Populated(t *testing.T) {
 	assert.Equal(t, []string{"core"}, target.Attributes["gcp.cloudsql.label.team"])
 }
 
+func TestToInstanceTarget_ReplicationLinks(t *testing.T) {
+	primary := toInstanceTarget(&sqladmin.DatabaseInstance{
+		Name:         "primary",
+		InstanceType: "CLOUD_SQL_INSTANCE",
+		ReplicaNames: []string{"replica-1", "replica-2"},
+	}, "proj-a")
+	replica := toInstanceTarget(&sqladmin.DatabaseInstance{
+		Name:               "replica-1",
+		InstanceType:       "READ_REPLICA_INSTANCE",
+		MasterInstanceName: "primary",
+	}, "proj-a")
+
+	assert.Equal(t, []string{"replica-1", "replica-2"}, primary.Attributes[attrReplicaNames])
+	assert.NotContains(t, primary.Attributes, attrMasterInstance)
+	assert.Equal(t, []string{"primary"}, replica.Attributes[attrMasterInstance])
+	assert.NotContains(t, replica.Attributes, attrReplicaNames)
+}
+
 func TestToInstanceTarget_Sparse(t *testing.T) {
 	inst := &sqladmin.DatabaseInstance{
 		Name: "sparse",
@@ -0,0 +1,21 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extdisk
+
+const (
+	TargetIDDisk         = "com.steadybit.extension_gcp.persistent-disk"
+	DiskDetachActionId   = "com.steadybit.extension_gcp.persistent-disk.detach"
+	DiskThrottleActionId = "com.steadybit.extension_gcp.persistent-disk.throttle-performance"
+	targetIcon           = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNNDI3LjQ5MSwyNTUuODRsLS4wOTQuMDc4LjA1NC4wODItMTEzLjIzMSwxNzBoLTg5LjY0OWwtMTguNTcyLDEwLjg1Mi0yLjc2MiwyMS4xNDhoMTA3LjAzNGMxMy4xMDYsMCwyNS4zNTUtNi42MzcsMzIuNzY3LTE3Ljc1NWwxMDMuNjg5LTE1NS41NTEtMS45MjktMjQuNzMtMTcuMzA3LTQuMTI0aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTE5OS4zNTQsMjM0LjEyMmwtNTAuMTQtNzUuMjA5LTE5LjIxNiw2LjMzNnYyMi41MDVsNDUuNTA2LDY4LjI0Ni00NS41MDYsNjguMjQ2djIwLjUzMmwxOS4yMTYsOC4zMSw1MC4xMzktNzUuMjA5YzguODYxLTEzLjI5MSw4Ljg2MS0zMC40NjcsMC00My43NTdoLjAwMVoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMTkxLjkzOSw0MTcuMjE0aDBsLTEwNy40MS0xNjEuMjE0LDEwNy40MjItMTYxLjIzMi4wMjMtMjAuOTMzLTE4LjkyNi03LjQyYy0xLjQ4NCwxLjU4MS0yLjg2LDMuMjg0LTQuMDg3LDUuMTI0TDYwLjYzNiwyMzQuMDQ0Yy04Ljg0OCwxMy4yNzMtOC44NDgsMzAuNDI1LDAsNDMuNjk0bDEwOC4zMjYsMTYyLjUwOGMxLjI0OCwxLjg3MSwyLjY0MywzLjYwMyw0LjE0NSw1LjIxLDAsLjAwMS4wMDIuMDAyLjAwMy4wMDNsMTguODY1LTYuOTU3LS4wMzUtMjEuMjg3aC0uMDAxWiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0yOTkuNDQ1LDI1NmwtMTA3LjUwNSwxNjEuMjE0aDBsLTE4LjgzLDI4LjI0NWM3LjQyNSw3LjkzOSwxNy43MiwxMi41NDEsMjguNjE5LDEyLjU0MWgxLjUwOWwyMS4zMzQtMzIsOTQuMTM5LTE0MS4xMDMtMy4xNzMtMjMuOTQ0LTE2LjA5My00Ljk1M1oiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDUxLjM2NCwyMzQuMDQ1bC0xMDguMzI2LTE2Mi41MDhjLTcuMzIyLTEwLjk4MS0xOS41Ny0xNy41MzctMzIuNzY3LTE3LjUzN2gtMTA4LjU0M2MtMTAuOTczLDAtMjEuMjc5LDQuNTQ0LTI4LjY1NCwxMi4zODVsMTguODk5LDI4LjM0OS0uMDIzLjAzNGgwbC4wMTItLjAxOSwxMDcuNDgxLDE2MS4yNTEsMTkuMjY2LDI4Ljg5Nyw0LjY0NS02Ljk2MmM4Ljg1OS0xMy4yOSw4Ljg1OS0zMC40NjYsMC00My43NTVsLTk4Ljc2Ny0xNDguMTgxaDg5LjYzMmwxMTMuMTc3LDE2OS45MTguMDk0LS4wNzgsMTkuMjM2LDI4Ljg1NCw0LjYzNi02Ljk1NGM4Ljg0OS0xMy4yNzEsOC44NDktMzAuNDIyLjAwMS00My42OTRoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KPC9zdmc+"
+
+	// Attribute names extracted per Sonar go:S1192.
+	attrType      = "gcp.persistent-disk.type"
+	attrSizeGB    = "gcp.persistent-disk.size-gb"
+	attrZone      = "gcp.persistent-disk.zone"
+	attrProjectID = "gcp.project.id"
+	attrName      = "gcp.persistent-disk.name"
+	attrRegion    = "gcp.persistent-disk.region"
+	attrUsers     = "gcp.persistent-disk.users"
+)
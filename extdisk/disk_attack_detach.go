@@ -0,0 +1,334 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extdisk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// DiskDetachState records every attachment of the disk as read live in Prepare, so Stop can attach it again with the
+// same device name, mode and auto-delete flag. Detached is set per attachment once its DetachDisk operation is done;
+// Stop only reattaches those, which keeps a partially failed Start reversible.
+type DiskDetachState struct {
+	ProjectID   string
+	DiskName    string
+	Attachments []DiskAttachment
+}
+
+// DiskAttachment is one instance's view of the disk. Zone is the instance's zone: a regional disk can be attached to
+// instances in either of its replica zones.
+type DiskAttachment struct {
+	Project    string
+	Zone       string
+	Instance   string
+	Source     string
+	DeviceName string
+	Mode       string
+	Boot       bool
+	AutoDelete bool
+	Detached   bool
+}
+
+type diskInstancesApi interface {
+	Get(ctx context.Context, req *computepb.GetInstanceRequest, opts ...gax.CallOption) (*computepb.Instance, error)
+	DetachDisk(ctx context.Context, req *computepb.DetachDiskInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	AttachDisk(ctx context.Context, req *computepb.AttachDiskInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type diskDetachAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (diskInstancesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[DiskDetachState] = (*diskDetachAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[DiskDetachState] = (*diskDetachAttack)(nil)
+
+func NewDiskDetachAction() action_kit_sdk.ActionWithStop[DiskDetachState] {
+	return &diskDetachAttack{
+		clientProvider: func(ctx context.Context, projectID string) (diskInstancesApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewInstancesRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+	}
+}
+
+func (a *diskDetachAttack) NewEmptyState() DiskDetachState {
+	return DiskDetachState{}
+}
+
+func (a *diskDetachAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          DiskDetachActionId,
+		Label:       "Detach Persistent Disk",
+		Description: "Detaches a persistent disk from every virtual machine it is attached to. Reattached with the original device name, mode and auto-delete setting on stop. Boot disks are refused.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDDisk,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by disk name",
+					Description: extutil.Ptr("Find persistent disk by name"),
+					Query:       "gcp.persistent-disk.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Persistent Disk"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the disk stays detached. Reattached on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *diskDetachAttack) Prepare(ctx context.Context, state *DiskDetachState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, attrProjectID)
+	state.DiskName = mustHave(request.Target.Attributes, attrName)
+	if state.ProjectID == "" || state.DiskName == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.persistent-disk.name", nil)
+	}
+	users := request.Target.Attributes[attrUsers]
+	if len(users) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Disk '%s' is not attached to any virtual machine", state.DiskName), nil)
+	}
+	diskPath := diskResourcePath(state.ProjectID, request.Target.Attributes)
+	if diskPath == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.persistent-disk.zone, gcp.persistent-disk.region", nil)
+	}
+
+	state.Attachments = nil
+	for _, user := range users {
+		project, zone, instance, ok := parseInstanceURL(user)
+		if !ok {
+			return nil, extension_kit.ToError(fmt.Sprintf("Cannot parse attached instance '%s' of disk '%s'", user, state.DiskName), nil)
+		}
+		attachment, err := a.readAttachment(ctx, project, zone, instance, diskPath)
+		if err != nil {
+			return nil, err
+		}
+		if attachment.Boot {
+			return nil, extension_kit.ToError(fmt.Sprintf("Disk '%s' is the boot disk of vm '%s' — detaching boot disks is not supported", state.DiskName, instance), nil)
+		}
+		state.Attachments = append(state.Attachments, *attachment)
+	}
+
+	return &action_kit_api.PrepareResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Will detach disk '%s' from %s", state.DiskName, describeAttachments(state.Attachments)),
+		}}),
+	}, nil
+}
+
+func (a *diskDetachAttack) readAttachment(ctx context.Context, project, zone, instance, diskPath string) (*DiskAttachment, error) {
+	client, closer, err := a.clientProvider(ctx, project)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create Instances client for project %s", project), err)
+	}
+	defer closer()
+	vm, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: project, Zone: zone, Instance: instance})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get vm '%s'", instance), err)
+	}
+	disk := findAttachedDisk(vm, diskPath)
+	if disk == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Disk '%s' is no longer attached to vm '%s'", diskPath, instance), nil)
+	}
+	return &DiskAttachment{
+		Project:    project,
+		Zone:       zone,
+		Instance:   instance,
+		Source:     disk.GetSource(),
+		DeviceName: disk.GetDeviceName(),
+		Mode:       disk.GetMode(),
+		Boot:       disk.GetBoot(),
+		AutoDelete: disk.GetAutoDelete(),
+	}, nil
+}
+
+func (a *diskDetachAttack) Start(ctx context.Context, state *DiskDetachState) (*action_kit_api.StartResult, error) {
+	var errs []error
+	for i := range state.Attachments {
+		attachment := &state.Attachments[i]
+		if attachment.Detached {
+			continue
+		}
+		if err := a.detach(ctx, attachment); err != nil {
+			errs = append(errs, fmt.Errorf("vm '%s': %w", attachment.Instance, err))
+			continue
+		}
+		attachment.Detached = true
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to detach disk '%s' (partially applied; stop reattaches what was detached)", state.DiskName), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Detached disk '%s' from %s until stop", state.DiskName, describeAttachments(state.Attachments)),
+		}}),
+	}, nil
+}
+
+func (a *diskDetachAttack) Stop(ctx context.Context, state *DiskDetachState) (*action_kit_api.StopResult, error) {
+	var errs []error
+	reattached := 0
+	for i := range state.Attachments {
+		attachment := &state.Attachments[i]
+		if !attachment.Detached {
+			continue
+		}
+		if err := a.reattach(ctx, attachment); err != nil {
+			errs = append(errs, fmt.Errorf("vm '%s': %w", attachment.Instance, err))
+			continue
+		}
+		attachment.Detached = false
+		reattached++
+	}
+	if err := errors.Join(errs...); err != nil {
+		log.Error().Err(err).Msgf("Failed to reattach disk '%s'", state.DiskName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to reattach disk '%s'", state.DiskName), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Reattached disk '%s' to %d vm(s)", state.DiskName, reattached),
+		}}),
+	}, nil
+}
+
+func (a *diskDetachAttack) detach(ctx context.Context, attachment *DiskAttachment) error {
+	client, closer, err := a.clientProvider(ctx, attachment.Project)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	op, err := client.DetachDisk(ctx, &computepb.DetachDiskInstanceRequest{
+		Project:    attachment.Project,
+		Zone:       attachment.Zone,
+		Instance:   attachment.Instance,
+		DeviceName: attachment.DeviceName,
+	})
+	if err != nil {
+		return err
+	}
+	return utils.WaitForComputeOperation(ctx, op)
+}
+
+// reattach is idempotent: a disk that is already attached again (Stop retried, or someone reattached it manually)
+// is left alone instead of failing the attach call.
+func (a *diskDetachAttack) reattach(ctx context.Context, attachment *DiskAttachment) error {
+	client, closer, err := a.clientProvider(ctx, attachment.Project)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	vm, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: attachment.Project, Zone: attachment.Zone, Instance: attachment.Instance})
+	if err != nil {
+		return fmt.Errorf("get vm: %w", err)
+	}
+	for _, disk := range vm.GetDisks() {
+		if disk.GetSource() == attachment.Source {
+			log.Info().Msgf("Disk '%s' is already attached to vm '%s' — nothing to restore", attachment.Source, attachment.Instance)
+			return nil
+		}
+	}
+	op, err := client.AttachDisk(ctx, &computepb.AttachDiskInstanceRequest{
+		Project:  attachment.Project,
+		Zone:     attachment.Zone,
+		Instance: attachment.Instance,
+		AttachedDiskResource: &computepb.AttachedDisk{
+			Source:     extutil.Ptr(attachment.Source),
+			DeviceName: extutil.Ptr(attachment.DeviceName),
+			Mode:       extutil.Ptr(attachment.Mode),
+			Boot:       extutil.Ptr(attachment.Boot),
+			AutoDelete: extutil.Ptr(attachment.AutoDelete),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return utils.WaitForComputeOperation(ctx, op)
+}
+
+// diskResourcePath is the project-relative path an attached disk's source URL ends with, e.g.
+// projects/p/zones/z/disks/d or projects/p/regions/r/disks/d for regional disks.
+func diskResourcePath(projectID string, attrs map[string][]string) string {
+	name := mustHave(attrs, attrName)
+	if zone := mustHave(attrs, attrZone); zone != "" {
+		return fmt.Sprintf("projects/%s/zones/%s/disks/%s", projectID, zone, name)
+	}
+	if region := mustHave(attrs, attrRegion); region != "" {
+		return fmt.Sprintf("projects/%s/regions/%s/disks/%s", projectID, region, name)
+	}
+	return ""
+}
+
+func findAttachedDisk(vm *computepb.Instance, diskPath string) *computepb.AttachedDisk {
+	for _, disk := range vm.GetDisks() {
+		if strings.HasSuffix(disk.GetSource(), "/"+diskPath) {
+			return disk
+		}
+	}
+	return nil
+}
+
+// parseInstanceURL splits an instance self link as found in a disk's users list.
+func parseInstanceURL(url string) (project, zone, instance string, ok bool) {
+	parts := strings.Split(url, "/")
+	for i := 0; i+5 < len(parts); i++ {
+		if parts[i] == "projects" && parts[i+2] == "zones" && parts[i+4] == "instances" {
+			return parts[i+1], parts[i+3], parts[i+5], true
+		}
+	}
+	return "", "", "", false
+}
+
+func describeAttachments(attachments []DiskAttachment) string {
+	parts := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		parts = append(parts, fmt.Sprintf("%s (device %s, %s)", a.Instance, a.DeviceName, a.Mode))
+	}
+	return fmt.Sprintf("%d vm(s): %s", len(attachments), strings.Join(parts, ", "))
+}
+
+func mustHave(attrs map[string][]string, key string) string {
+	v, ok := attrs[key]
+	if !ok || len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extdisk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDiskSource = "https://www.googleapis.com/compute/v1/projects/proj-a/zones/europe-west1-a/disks/data"
+
+type fakeDiskInstances struct {
+	instances map[string]*computepb.Instance
+	detachErr map[string]error
+	detached  []*computepb.DetachDiskInstanceRequest
+	attached  []*computepb.AttachDiskInstanceRequest
+}
+
+func (f *fakeDiskInstances) Get(_ context.Context, req *computepb.GetInstanceRequest, _ ...gax.CallOption) (*computepb.Instance, error) {
+	vm, ok := f.instances[req.Instance]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return vm, nil
+}
+
+func (f *fakeDiskInstances) DetachDisk(_ context.Context, req *computepb.DetachDiskInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	if err := f.detachErr[req.Instance]; err != nil {
+		return nil, err
+	}
+	f.detached = append(f.detached, req)
+	return nil, nil
+}
+
+func (f *fakeDiskInstances) AttachDisk(_ context.Context, req *computepb.AttachDiskInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	f.attached = append(f.attached, req)
+	return nil, nil
+}
+
+func newDetachTestAttack(api *fakeDiskInstances) *diskDetachAttack {
+	return &diskDetachAttack{
+		clientProvider: func(context.Context, string) (diskInstancesApi, func(), error) {
+			return api, func() {}, nil
+		},
+	}
+}
+
+func vmWithDisk(name string, boot bool) *computepb.Instance {
+	return &computepb.Instance{
+		Name: ptr(name),
+		Disks: []*computepb.AttachedDisk{
+			{Source: ptr("https://www.googleapis.com/compute/v1/projects/proj-a/zones/europe-west1-a/disks/" + name + "-boot"), DeviceName: ptr("boot"), Boot: ptr(true)},
+			{Source: ptr(testDiskSource), DeviceName: ptr("data-" + name), Mode: ptr("READ_ONLY"), Boot: ptr(boot), AutoDelete: ptr(true)},
+		},
+	}
+}
+
+func detachPrepareReq(attrs map[string][]string) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: attrs}),
+	})
+}
+
+func validDetachAttrs() map[string][]string {
+	return map[string][]string{
+		attrProjectID: {"proj-a"},
+		attrName:      {"data"},
+		attrZone:      {"europe-west1-a"},
+		attrUsers: {
+			"https://www.googleapis.com/compute/v1/projects/proj-a/zones/europe-west1-a/instances/vm-1",
+			"https://www.googleapis.com/compute/v1/projects/proj-a/zones/europe-west1-a/instances/vm-2",
+		},
+	}
+}
+
+func TestDiskDetach_Describe(t *testing.T) {
+	a := &diskDetachAttack{}
+	desc := a.Describe()
+	assert.Equal(t, DiskDetachActionId, desc.Id)
+	assert.Equal(t, TargetIDDisk, desc.TargetSelection.TargetType)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, DiskDetachState{}, a.NewEmptyState())
+}
+
+func TestDiskDetach_NewAction(t *testing.T) {
+	assert.NotNil(t, NewDiskDetachAction())
+}
+
+func TestDiskDetach_PrepareRecordsAttachments(t *testing.T) {
+	api := &fakeDiskInstances{instances: map[string]*computepb.Instance{"vm-1": vmWithDisk("vm-1", false), "vm-2": vmWithDisk("vm-2", false)}}
+	state := DiskDetachState{}
+
+	res, err := newDetachTestAttack(api).Prepare(context.Background(), &state, detachPrepareReq(validDetachAttrs()))
+
+	require.NoError(t, err)
+	assert.Contains(t, (*res.Messages)[0].Message, "2 vm(s)")
+	require.Len(t, state.Attachments, 2)
+	assert.Equal(t, DiskAttachment{
+		Project:    "proj-a",
+		Zone:       "europe-west1-a",
+		Instance:   "vm-1",
+		Source:     testDiskSource,
+		DeviceName: "data-vm-1",
+		Mode:       "READ_ONLY",
+		AutoDelete: true,
+	}, state.Attachments[0])
+}
+
+func TestDiskDetach_PrepareRefusesBootDisk(t *testing.T) {
+	api := &fakeDiskInstances{instances: map[string]*computepb.Instance{"vm-1": vmWithDisk("vm-1", true), "vm-2": vmWithDisk("vm-2", false)}}
+	state := DiskDetachState{}
+
+	_, err := newDetachTestAttack(api).Prepare(context.Background(), &state, detachPrepareReq(validDetachAttrs()))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boot disk")
+}
+
+func TestDiskDetach_PrepareRefusesUnattachedDisk(t *testing.T) {
+	attrs := validDetachAttrs()
+	delete(attrs, attrUsers)
+	state := DiskDetachState{}
+
+	_, err := newDetachTestAttack(&fakeDiskInstances{}).Prepare(context.Background(), &state, detachPrepareReq(attrs))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+}
+
+func TestDiskDetach_PrepareMissingAttributes(t *testing.T) {
+	for _, drop := range []string{attrProjectID, attrName, attrZone} {
+		attrs := validDetachAttrs()
+		delete(attrs, drop)
+		state := DiskDetachState{}
+		_, err := newDetachTestAttack(&fakeDiskInstances{}).Prepare(context.Background(), &state, detachPrepareReq(attrs))
+		require.Error(t, err, "dropping %s should fail Prepare", drop)
+		assert.Contains(t, err.Error(), "missing")
+	}
+}
+
+func TestDiskDetach_StartMarksOnlySuccessfulDetaches(t *testing.T) {
+	api := &fakeDiskInstances{detachErr: map[string]error{"vm-2": errors.New("boom")}}
+	state := &DiskDetachState{DiskName: "data", Attachments: []DiskAttachment{
+		{Project: "proj-a", Zone: "europe-west1-a", Instance: "vm-1", DeviceName: "data-vm-1"},
+		{Project: "proj-a", Zone: "europe-west1-a", Instance: "vm-2", DeviceName: "data-vm-2"},
+	}}
+
+	_, err := newDetachTestAttack(api).Start(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partially applied")
+	require.Len(t, api.detached, 1)
+	assert.Equal(t, "data-vm-1", api.detached[0].DeviceName)
+	assert.True(t, state.Attachments[0].Detached)
+	assert.False(t, state.Attachments[1].Detached)
+}
+
+func TestDiskDetach_StopReattachesWithOriginalSettings(t *testing.T) {
+	api := &fakeDiskInstances{instances: map[string]*computepb.Instance{
+		"vm-1": {Name: ptr("vm-1")},
+		"vm-2": vmWithDisk("vm-2", false),
+	}}
+	state := &DiskDetachState{DiskName: "data", Attachments: []DiskAttachment{
+		{Project: "proj-a", Zone: "europe-west1-a", Instance: "vm-1", Source: testDiskSource, DeviceName: "data-vm-1", Mode: "READ_ONLY", AutoDelete: true, Detached: true},
+		// Already attached again, e.g. a retried Stop.
+		{Project: "proj-a", Zone: "europe-west1-a", Instance: "vm-2", Source: testDiskSource, DeviceName: "data-vm-2", Detached: true},
+		// Never detached because Start failed for it.
+		{Project: "proj-a", Zone: "europe-west1-a", Instance: "vm-3", Source: testDiskSource, DeviceName: "data-vm-3"},
+	}}
+
+	res, err := newDetachTestAttack(api).Stop(context.Background(), state)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Len(t, api.attached, 1)
+	attached := api.attached[0]
+	assert.Equal(t, "vm-1", attached.Instance)
+	assert.Equal(t, testDiskSource, attached.AttachedDiskResource.GetSource())
+	assert.Equal(t, "data-vm-1", attached.AttachedDiskResource.GetDeviceName())
+	assert.Equal(t, "READ_ONLY", attached.AttachedDiskResource.GetMode())
+	assert.True(t, attached.AttachedDiskResource.GetAutoDelete())
+	assert.False(t, state.Attachments[0].Detached)
+	assert.False(t, state.Attachments[1].Detached)
+}
+
+func TestDiskDetach_StopReportsFailure(t *testing.T) {
+	state := &DiskDetachState{DiskName: "data", Attachments: []DiskAttachment{
+		{Project: "proj-a", Zone: "europe-west1-a", Instance: "gone", Source: testDiskSource, Detached: true},
+	}}
+
+	_, err := newDetachTestAttack(&fakeDiskInstances{}).Stop(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to reattach")
+	assert.True(t, state.Attachments[0].Detached)
+}
+
+func TestParseInstanceURL(t *testing.T) {
+	project, zone, instance, ok := parseInstanceURL("https://www.googleapis.com/compute/v1/projects/proj-a/zones/europe-west1-b/instances/vm-1")
+	assert.True(t, ok)
+	assert.Equal(t, "proj-a", project)
+	assert.Equal(t, "europe-west1-b", zone)
+	assert.Equal(t, "vm-1", instance)
+
+	_, _, _, ok = parseInstanceURL("vm-1")
+	assert.False(t, ok)
+}
+
+func TestDiskResourcePath(t *testing.T) {
+	assert.Equal(t, "projects/p/regions/europe-west1/disks/d", diskResourcePath("p", map[string][]string{attrName: {"d"}, attrRegion: {"europe-west1"}}))
+	assert.Equal(t, "projects/p/zones/europe-west1-a/disks/d", diskResourcePath("p", map[string][]string{attrName: {"d"}, attrZone: {"europe-west1-a"}}))
+	assert.Equal(t, "", diskResourcePath("p", map[string][]string{attrName: {"d"}}))
+}
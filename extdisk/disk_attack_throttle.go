@@ -0,0 +1,362 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extdisk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// DiskThrottleState holds the provisioned performance read live in Prepare and the reduced values Start applies.
+// A zero value means the field is not throttled, either because the disk type does not support changing it or
+// because nothing was provisioned. Stop writes the originals back unless the disk already reports them. ThrottledAt
+// is when Start's update went through, so a rejected restore can say when the next change is allowed.
+type DiskThrottleState struct {
+	ProjectID          string
+	DiskName           string
+	Zone               string // set for zonal disks
+	Region             string // set for regional disks
+	DiskType           string
+	Percentage         int
+	OriginalIops       int64
+	OriginalThroughput int64
+	TargetIops         int64
+	TargetThroughput   int64
+	ThrottledAt        time.Time
+}
+
+// performanceFields says which provisioned values a disk type lets you change after creation, the lowest values
+// Google Cloud accepts for them and how long it makes you wait between two changes.
+type performanceFields struct {
+	iops          bool
+	throughput    bool
+	minIops       int64
+	minThroughput int64 // MiB/s
+	minInterval   time.Duration
+}
+
+// Disk types whose provisioned performance can be modified in place. Everything else (pd-standard, pd-balanced,
+// pd-ssd, ...) derives its performance from size and is refused in Prepare. The minimums are the size-independent
+// lower bounds; larger disks may require more, in which case Start is rejected by the API.
+var modifiableDiskTypes = map[string]performanceFields{
+	"pd-extreme":                           {iops: true, minIops: 10000, minInterval: 6 * time.Hour},
+	"hyperdisk-extreme":                    {iops: true, minIops: 2500, minInterval: 4 * time.Hour},
+	"hyperdisk-balanced":                   {iops: true, throughput: true, minIops: 3000, minThroughput: 140, minInterval: 4 * time.Hour},
+	"hyperdisk-balanced-high-availability": {iops: true, throughput: true, minIops: 3000, minThroughput: 140, minInterval: 4 * time.Hour},
+	"hyperdisk-throughput":                 {throughput: true, minThroughput: 20, minInterval: 4 * time.Hour},
+	"hyperdisk-ml":                         {throughput: true, minThroughput: 400, minInterval: 4 * time.Hour},
+}
+
+type zonalDisksApi interface {
+	Get(ctx context.Context, req *computepb.GetDiskRequest, opts ...gax.CallOption) (*computepb.Disk, error)
+	Update(ctx context.Context, req *computepb.UpdateDiskRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type regionalDisksApi interface {
+	Get(ctx context.Context, req *computepb.GetRegionDiskRequest, opts ...gax.CallOption) (*computepb.Disk, error)
+	Update(ctx context.Context, req *computepb.UpdateRegionDiskRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type diskThrottleAttack struct {
+	zonalClientProvider    func(ctx context.Context, projectID string) (zonalDisksApi, func(), error)
+	regionalClientProvider func(ctx context.Context, projectID string) (regionalDisksApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[DiskThrottleState] = (*diskThrottleAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[DiskThrottleState] = (*diskThrottleAttack)(nil)
+
+func NewDiskThrottleAction() action_kit_sdk.ActionWithStop[DiskThrottleState] {
+	return &diskThrottleAttack{
+		zonalClientProvider: func(ctx context.Context, projectID string) (zonalDisksApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewDisksRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+		regionalClientProvider: func(ctx context.Context, projectID string) (regionalDisksApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewRegionDisksRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+	}
+}
+
+func (a *diskThrottleAttack) NewEmptyState() DiskThrottleState {
+	return DiskThrottleState{}
+}
+
+func (a *diskThrottleAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          DiskThrottleActionId,
+		Label:       "Throttle Persistent Disk Performance",
+		Description: "Lowers the provisioned IOPS and/or throughput of a Hyperdisk or pd-extreme volume to a percentage of its current value, but not below the minimum of its disk type. The original performance is restored on stop. Google Cloud allows a disk's performance to change only once every 4 hours (6 hours for pd-extreme), so the duration has to cover that interval or the restore is rejected.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDDisk,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by disk name",
+					Description: extutil.Ptr("Find persistent disk by name"),
+					Query:       "gcp.persistent-disk.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Persistent Disk"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the disk stays throttled. Restored on stop. Must be at least 4 hours (6 hours for pd-extreme), the interval Google Cloud enforces between two performance changes."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("6h"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "percentage",
+				Label:        "Remaining performance",
+				Description:  extutil.Ptr("Percentage (1-99) of the currently provisioned IOPS and throughput the disk keeps during the attack. Values below the disk type's minimum are raised to it."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("25"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(99),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *diskThrottleAttack) Prepare(ctx context.Context, state *DiskThrottleState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, attrProjectID)
+	state.DiskName = mustHave(request.Target.Attributes, attrName)
+	state.Zone = mustHave(request.Target.Attributes, attrZone)
+	state.Region = mustHave(request.Target.Attributes, attrRegion)
+	if state.ProjectID == "" || state.DiskName == "" || (state.Zone == "" && state.Region == "") {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.persistent-disk.name, gcp.persistent-disk.zone or gcp.persistent-disk.region", nil)
+	}
+	pct := extutil.ToInt(request.Config["percentage"])
+	if pct < 1 || pct > 99 {
+		return nil, extension_kit.ToError("percentage must be between 1 and 99.", nil)
+	}
+	state.Percentage = pct
+	duration := time.Duration(extutil.ToInt64(request.Config["duration"])) * time.Millisecond
+
+	disk, err := a.getDisk(ctx, state)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get disk '%s'", state.DiskName), err)
+	}
+	state.DiskType = lastPathSegment(disk.GetType())
+	fields, ok := modifiableDiskTypes[state.DiskType]
+	if !ok {
+		return nil, extension_kit.ToError(fmt.Sprintf("Disk '%s' is of type %s, whose performance cannot be changed. Supported types: %s", state.DiskName, state.DiskType, supportedDiskTypes()), nil)
+	}
+	if duration < fields.minInterval {
+		return nil, extension_kit.ToError(fmt.Sprintf("The duration must be at least %s: Google Cloud allows the performance of a %s disk to change only once every %s, so an earlier restore would be rejected.", fields.minInterval, state.DiskType, fields.minInterval), nil)
+	}
+	state.OriginalIops, state.TargetIops = 0, 0
+	state.OriginalThroughput, state.TargetThroughput = 0, 0
+	if target := scaleDown(disk.GetProvisionedIops(), pct, fields.minIops); fields.iops && target > 0 {
+		state.OriginalIops, state.TargetIops = disk.GetProvisionedIops(), target
+	}
+	if target := scaleDown(disk.GetProvisionedThroughput(), pct, fields.minThroughput); fields.throughput && target > 0 {
+		state.OriginalThroughput, state.TargetThroughput = disk.GetProvisionedThroughput(), target
+	}
+	if state.TargetIops == 0 && state.TargetThroughput == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Disk '%s' reports no provisioned IOPS or throughput above the minimum of its disk type to throttle", state.DiskName), nil)
+	}
+
+	return &action_kit_api.PrepareResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Will throttle %s disk '%s' to %d%%: %s", state.DiskType, state.DiskName, pct, describeThrottle(state)),
+		}}),
+	}, nil
+}
+
+func (a *diskThrottleAttack) Start(ctx context.Context, state *DiskThrottleState) (*action_kit_api.StartResult, error) {
+	if err := a.updatePerformance(ctx, state, state.TargetIops, state.TargetThroughput); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to throttle disk '%s'", state.DiskName), err)
+	}
+	state.ThrottledAt = time.Now()
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Throttled disk '%s' until stop: %s", state.DiskName, describeThrottle(state)),
+		}}),
+	}, nil
+}
+
+// Stop re-reads the disk first so a retried Stop, or a Start that never got through, does not issue a second
+// modification — Google Cloud limits how often a disk's provisioned performance may change.
+func (a *diskThrottleAttack) Stop(ctx context.Context, state *DiskThrottleState) (*action_kit_api.StopResult, error) {
+	disk, err := a.getDisk(ctx, state)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get disk '%s' before restoring its performance", state.DiskName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get disk '%s'", state.DiskName), err)
+	}
+	if performanceRestored(disk, state) {
+		return &action_kit_api.StopResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Disk '%s' already runs with its original performance", state.DiskName),
+			}}),
+		}, nil
+	}
+	if err := a.updatePerformance(ctx, state, state.OriginalIops, state.OriginalThroughput); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore performance of disk '%s'", state.DiskName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore performance of disk '%s' (original: %d IOPS, %d MiB/s)%s", state.DiskName, state.OriginalIops, state.OriginalThroughput, nextChangeHint(state)), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Restored performance of disk '%s'", state.DiskName),
+		}}),
+	}, nil
+}
+
+func (a *diskThrottleAttack) getDisk(ctx context.Context, state *DiskThrottleState) (*computepb.Disk, error) {
+	if state.Zone != "" {
+		client, closer, err := a.zonalClientProvider(ctx, state.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		defer closer()
+		return client.Get(ctx, &computepb.GetDiskRequest{Project: state.ProjectID, Zone: state.Zone, Disk: state.DiskName})
+	}
+	client, closer, err := a.regionalClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+	return client.Get(ctx, &computepb.GetRegionDiskRequest{Project: state.ProjectID, Region: state.Region, Disk: state.DiskName})
+}
+
+// updatePerformance patches only the fields the attack manages; zero values are left out of the update mask.
+func (a *diskThrottleAttack) updatePerformance(ctx context.Context, state *DiskThrottleState, iops, throughput int64) error {
+	resource := &computepb.Disk{}
+	var paths []string
+	if iops > 0 {
+		resource.ProvisionedIops = extutil.Ptr(iops)
+		paths = append(paths, "provisionedIops")
+	}
+	if throughput > 0 {
+		resource.ProvisionedThroughput = extutil.Ptr(throughput)
+		paths = append(paths, "provisionedThroughput")
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	mask := strings.Join(paths, ",")
+
+	var op *compute.Operation
+	if state.Zone != "" {
+		client, closer, err := a.zonalClientProvider(ctx, state.ProjectID)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		op, err = client.Update(ctx, &computepb.UpdateDiskRequest{Project: state.ProjectID, Zone: state.Zone, Disk: state.DiskName, UpdateMask: extutil.Ptr(mask), DiskResource: resource})
+		if err != nil {
+			return err
+		}
+	} else {
+		client, closer, err := a.regionalClientProvider(ctx, state.ProjectID)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		op, err = client.Update(ctx, &computepb.UpdateRegionDiskRequest{Project: state.ProjectID, Region: state.Region, Disk: state.DiskName, UpdateMask: extutil.Ptr(mask), DiskResource: resource})
+		if err != nil {
+			return err
+		}
+	}
+	return utils.WaitForComputeOperation(ctx, op)
+}
+
+func performanceRestored(disk *computepb.Disk, state *DiskThrottleState) bool {
+	iopsOk := state.OriginalIops == 0 || disk.GetProvisionedIops() == state.OriginalIops
+	throughputOk := state.OriginalThroughput == 0 || disk.GetProvisionedThroughput() == state.OriginalThroughput
+	return iopsOk && throughputOk
+}
+
+// scaleDown returns pct percent of value, raised to minimum. It returns 0, leaving the field alone, when value does
+// not exceed minimum (including nothing provisioned).
+func scaleDown(value int64, pct int, minimum int64) int64 {
+	if value <= minimum {
+		return 0
+	}
+	return max(value*int64(pct)/100, minimum, 1)
+}
+
+// nextChangeHint tells the operator from when Google Cloud accepts the restore, if Stop came too early for it.
+func nextChangeHint(state *DiskThrottleState) string {
+	interval := modifiableDiskTypes[state.DiskType].minInterval
+	if state.ThrottledAt.IsZero() || interval == 0 {
+		return ""
+	}
+	next := state.ThrottledAt.Add(interval)
+	if !time.Now().Before(next) {
+		return ""
+	}
+	return fmt.Sprintf(". Google Cloud accepts the next performance change from %s on; restore it manually then", next.UTC().Format(time.RFC3339))
+}
+
+func describeThrottle(state *DiskThrottleState) string {
+	var parts []string
+	if state.TargetIops > 0 {
+		parts = append(parts, fmt.Sprintf("IOPS %d → %d", state.OriginalIops, state.TargetIops))
+	}
+	if state.TargetThroughput > 0 {
+		parts = append(parts, fmt.Sprintf("throughput %d → %d MiB/s", state.OriginalThroughput, state.TargetThroughput))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func supportedDiskTypes() string {
+	types := make([]string, 0, len(modifiableDiskTypes))
+	for t := range modifiableDiskTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return strings.Join(types, ", ")
+}
+
+func lastPathSegment(url string) string {
+	if i := strings.LastIndex(url, "/"); i >= 0 {
+		return url[i+1:]
+	}
+	return url
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extdisk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeZonalDisks struct {
+	disk      *computepb.Disk
+	getErr    error
+	updateErr error
+	updates   []*computepb.UpdateDiskRequest
+}
+
+func (f *fakeZonalDisks) Get(context.Context, *computepb.GetDiskRequest, ...gax.CallOption) (*computepb.Disk, error) {
+	return f.disk, f.getErr
+}
+
+func (f *fakeZonalDisks) Update(_ context.Context, req *computepb.UpdateDiskRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	f.updates = append(f.updates, req)
+	return nil, nil
+}
+
+type fakeRegionalDisks struct {
+	disk    *computepb.Disk
+	updates []*computepb.UpdateRegionDiskRequest
+}
+
+func (f *fakeRegionalDisks) Get(context.Context, *computepb.GetRegionDiskRequest, ...gax.CallOption) (*computepb.Disk, error) {
+	return f.disk, nil
+}
+
+func (f *fakeRegionalDisks) Update(_ context.Context, req *computepb.UpdateRegionDiskRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	f.updates = append(f.updates, req)
+	return nil, nil
+}
+
+func newThrottleTestAttack(zonal *fakeZonalDisks, regional *fakeRegionalDisks) *diskThrottleAttack {
+	return &diskThrottleAttack{
+		zonalClientProvider: func(context.Context, string) (zonalDisksApi, func(), error) {
+			return zonal, func() {}, nil
+		},
+		regionalClientProvider: func(context.Context, string) (regionalDisksApi, func(), error) {
+			return regional, func() {}, nil
+		},
+	}
+}
+
+func hyperdisk(diskType string, iops, throughput int64) *computepb.Disk {
+	return &computepb.Disk{
+		Name:                  ptr("data"),
+		Type:                  ptr("https://www.googleapis.com/compute/v1/projects/proj-a/zones/europe-west1-a/diskTypes/" + diskType),
+		ProvisionedIops:       ptrInt64(iops),
+		ProvisionedThroughput: ptrInt64(throughput),
+	}
+}
+
+func throttlePrepareReq(attrs map[string][]string, pct int) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"percentage": pct, "duration": (6 * time.Hour).Milliseconds()},
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: attrs}),
+	})
+}
+
+func zonalDiskAttrs() map[string][]string {
+	return map[string][]string{attrProjectID: {"proj-a"}, attrName: {"data"}, attrZone: {"europe-west1-a"}}
+}
+
+func TestDiskThrottle_Describe(t *testing.T) {
+	a := &diskThrottleAttack{}
+	desc := a.Describe()
+	assert.Equal(t, DiskThrottleActionId, desc.Id)
+	assert.Equal(t, TargetIDDisk, desc.TargetSelection.TargetType)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, DiskThrottleState{}, a.NewEmptyState())
+}
+
+func TestDiskThrottle_NewAction(t *testing.T) {
+	assert.NotNil(t, NewDiskThrottleAction())
+}
+
+func TestDiskThrottle_PrepareRecordsOriginalsAndTargets(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("hyperdisk-balanced", 6000, 290)}
+	state := DiskThrottleState{}
+
+	res, err := newThrottleTestAttack(zonal, nil).Prepare(context.Background(), &state, throttlePrepareReq(zonalDiskAttrs(), 50))
+
+	require.NoError(t, err)
+	assert.Equal(t, "hyperdisk-balanced", state.DiskType)
+	assert.Equal(t, int64(6000), state.OriginalIops)
+	assert.Equal(t, int64(3000), state.TargetIops)
+	assert.Equal(t, int64(290), state.OriginalThroughput)
+	assert.Equal(t, int64(145), state.TargetThroughput)
+	assert.Contains(t, (*res.Messages)[0].Message, "IOPS 6000 → 3000, throughput 290 → 145 MiB/s")
+}
+
+func TestDiskThrottle_PrepareOnlyThrottlesModifiableFields(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("pd-extreme", 100000, 500)}
+	state := DiskThrottleState{}
+
+	_, err := newThrottleTestAttack(zonal, nil).Prepare(context.Background(), &state, throttlePrepareReq(zonalDiskAttrs(), 10))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), state.TargetIops)
+	assert.Zero(t, state.OriginalThroughput)
+	assert.Zero(t, state.TargetThroughput)
+}
+
+func TestDiskThrottle_PrepareRefusesUnsupportedType(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("pd-ssd", 0, 0)}
+	state := DiskThrottleState{}
+
+	_, err := newThrottleTestAttack(zonal, nil).Prepare(context.Background(), &state, throttlePrepareReq(zonalDiskAttrs(), 50))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pd-ssd")
+	assert.Contains(t, err.Error(), "cannot be changed")
+}
+
+func TestDiskThrottle_PrepareClampsToTypeMinimum(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("hyperdisk-balanced", 6000, 140)}
+	state := DiskThrottleState{}
+
+	_, err := newThrottleTestAttack(zonal, nil).Prepare(context.Background(), &state, throttlePrepareReq(zonalDiskAttrs(), 10))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), state.TargetIops)
+	assert.Zero(t, state.OriginalThroughput, "throughput already at the minimum is left alone")
+	assert.Zero(t, state.TargetThroughput)
+}
+
+func TestDiskThrottle_PrepareRefusesDiskAtTypeMinimum(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("hyperdisk-ml", 0, 400)}
+	state := DiskThrottleState{}
+
+	_, err := newThrottleTestAttack(zonal, nil).Prepare(context.Background(), &state, throttlePrepareReq(zonalDiskAttrs(), 50))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "above the minimum")
+}
+
+func TestDiskThrottle_PrepareRefusesDurationShorterThanChangeInterval(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("pd-extreme", 20000, 0)}
+	req := throttlePrepareReq(zonalDiskAttrs(), 50)
+	req.Config["duration"] = (4 * time.Hour).Milliseconds()
+
+	_, err := newThrottleTestAttack(zonal, nil).Prepare(context.Background(), &DiskThrottleState{}, req)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least 6h0m0s")
+}
+
+func TestDiskThrottle_PrepareValidatesInput(t *testing.T) {
+	_, err := newThrottleTestAttack(nil, nil).Prepare(context.Background(), &DiskThrottleState{}, throttlePrepareReq(map[string][]string{attrProjectID: {"proj-a"}, attrName: {"data"}}, 50))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+
+	_, err = newThrottleTestAttack(nil, nil).Prepare(context.Background(), &DiskThrottleState{}, throttlePrepareReq(zonalDiskAttrs(), 100))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "between 1 and 99")
+}
+
+func TestDiskThrottle_StartUpdatesZonalDisk(t *testing.T) {
+	zonal := &fakeZonalDisks{}
+	state := &DiskThrottleState{ProjectID: "proj-a", DiskName: "data", Zone: "europe-west1-a", OriginalIops: 6000, TargetIops: 3000, OriginalThroughput: 290, TargetThroughput: 145}
+
+	_, err := newThrottleTestAttack(zonal, nil).Start(context.Background(), state)
+
+	require.NoError(t, err)
+	assert.False(t, state.ThrottledAt.IsZero())
+	require.Len(t, zonal.updates, 1)
+	assert.Equal(t, "provisionedIops,provisionedThroughput", zonal.updates[0].GetUpdateMask())
+	assert.Equal(t, int64(3000), zonal.updates[0].DiskResource.GetProvisionedIops())
+	assert.Equal(t, int64(145), zonal.updates[0].DiskResource.GetProvisionedThroughput())
+}
+
+func TestDiskThrottle_StartUpdatesRegionalDisk(t *testing.T) {
+	regional := &fakeRegionalDisks{}
+	state := &DiskThrottleState{ProjectID: "proj-a", DiskName: "data", Region: "europe-west1", OriginalThroughput: 290, TargetThroughput: 29}
+
+	_, err := newThrottleTestAttack(nil, regional).Start(context.Background(), state)
+
+	require.NoError(t, err)
+	require.Len(t, regional.updates, 1)
+	assert.Equal(t, "europe-west1", regional.updates[0].Region)
+	assert.Equal(t, "provisionedThroughput", regional.updates[0].GetUpdateMask())
+}
+
+func TestDiskThrottle_StartForwardsError(t *testing.T) {
+	zonal := &fakeZonalDisks{updateErr: errors.New("rate limited")}
+	state := &DiskThrottleState{ProjectID: "proj-a", DiskName: "data", Zone: "europe-west1-a", OriginalIops: 6000, TargetIops: 3000}
+
+	_, err := newThrottleTestAttack(zonal, nil).Start(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to throttle")
+}
+
+func TestDiskThrottle_StopRestoresOriginals(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("hyperdisk-balanced", 3000, 145)}
+	state := &DiskThrottleState{ProjectID: "proj-a", DiskName: "data", Zone: "europe-west1-a", OriginalIops: 6000, TargetIops: 3000, OriginalThroughput: 290, TargetThroughput: 145}
+
+	res, err := newThrottleTestAttack(zonal, nil).Stop(context.Background(), state)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Len(t, zonal.updates, 1)
+	assert.Equal(t, int64(6000), zonal.updates[0].DiskResource.GetProvisionedIops())
+	assert.Equal(t, int64(290), zonal.updates[0].DiskResource.GetProvisionedThroughput())
+}
+
+func TestDiskThrottle_StopReportsRejectedRestore(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("hyperdisk-balanced", 3000, 145), updateErr: errors.New("disk performance was modified too recently")}
+	state := &DiskThrottleState{ProjectID: "proj-a", DiskName: "data", Zone: "europe-west1-a", DiskType: "hyperdisk-balanced", OriginalIops: 6000, TargetIops: 3000, OriginalThroughput: 290, TargetThroughput: 145, ThrottledAt: time.Now().Add(-time.Minute)}
+
+	res, err := newThrottleTestAttack(zonal, nil).Stop(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "Failed to restore performance of disk 'data' (original: 6000 IOPS, 290 MiB/s)")
+	assert.Contains(t, err.Error(), "Google Cloud accepts the next performance change from")
+}
+
+func TestDiskThrottle_StopSkipsUnchangedDisk(t *testing.T) {
+	zonal := &fakeZonalDisks{disk: hyperdisk("pd-extreme", 6000, 0)}
+	state := &DiskThrottleState{ProjectID: "proj-a", DiskName: "data", Zone: "europe-west1-a", OriginalIops: 6000, TargetIops: 3000}
+
+	res, err := newThrottleTestAttack(zonal, nil).Stop(context.Background(), state)
+
+	require.NoError(t, err)
+	assert.Contains(t, (*res.Messages)[0].Message, "already runs with its original performance")
+	assert.Empty(t, zonal.updates)
+}
+
+func TestScaleDown(t *testing.T) {
+	assert.Equal(t, int64(250), scaleDown(1000, 25, 0))
+	assert.Equal(t, int64(1), scaleDown(3, 10, 0))
+	assert.Equal(t, int64(3000), scaleDown(6000, 10, 3000))
+	assert.Zero(t, scaleDown(3000, 50, 3000))
+}
@@ -25,17 +25,6 @@ import (
 	"google.golang.org/api/iterator"
 )
 
-const (
-	TargetIDDisk = "com.steadybit.extension_gcp.persistent-disk"
-	targetIcon   = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNNDI3LjQ5MSwyNTUuODRsLS4wOTQuMDc4LjA1NC4wODItMTEzLjIzMSwxNzBoLTg5LjY0OWwtMTguNTcyLDEwLjg1Mi0yLjc2MiwyMS4xNDhoMTA3LjAzNGMxMy4xMDYsMCwyNS4zNTUtNi42MzcsMzIuNzY3LTE3Ljc1NWwxMDMuNjg5LTE1NS41NTEtMS45MjktMjQuNzMtMTcuMzA3LTQuMTI0aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTE5OS4zNTQsMjM0LjEyMmwtNTAuMTQtNzUuMjA5LTE5LjIxNiw2LjMzNnYyMi41MDVsNDUuNTA2LDY4LjI0Ni00NS41MDYsNjguMjQ2djIwLjUzMmwxOS4yMTYsOC4zMSw1MC4xMzktNzUuMjA5YzguODYxLTEzLjI5MSw4Ljg2MS0zMC40NjcsMC00My43NTdoLjAwMVoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMTkxLjkzOSw0MTcuMjE0aDBsLTEwNy40MS0xNjEuMjE0LDEwNy40MjItMTYxLjIzMi4wMjMtMjAuOTMzLTE4LjkyNi03LjQyYy0xLjQ4NCwxLjU4MS0yLjg2LDMuMjg0LTQuMDg3LDUuMTI0TDYwLjYzNiwyMzQuMDQ0Yy04Ljg0OCwxMy4yNzMtOC44NDgsMzAuNDI1LDAsNDMuNjk0bDEwOC4zMjYsMTYyLjUwOGMxLjI0OCwxLjg3MSwyLjY0MywzLjYwMyw0LjE0NSw1LjIxLDAsLjAwMS4wMDIuMDAyLjAwMy4wMDNsMTguODY1LTYuOTU3LS4wMzUtMjEuMjg3aC0uMDAxWiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0yOTkuNDQ1LDI1NmwtMTA3LjUwNSwxNjEuMjE0aDBsLTE4LjgzLDI4LjI0NWM3LjQyNSw3LjkzOSwxNy43MiwxMi41NDEsMjguNjE5LDEyLjU0MWgxLjUwOWwyMS4zMzQtMzIsOTQuMTM5LTE0MS4xMDMtMy4xNzMtMjMuOTQ0LTE2LjA5My00Ljk1M1oiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDUxLjM2NCwyMzQuMDQ1bC0xMDguMzI2LTE2Mi41MDhjLTcuMzIyLTEwLjk4MS0xOS41Ny0xNy41MzctMzIuNzY3LTE3LjUzN2gtMTA4LjU0M2MtMTAuOTczLDAtMjEuMjc5LDQuNTQ0LTI4LjY1NCwxMi4zODVsMTguODk5LDI4LjM0OS0uMDIzLjAzNGgwbC4wMTItLjAxOSwxMDcuNDgxLDE2MS4yNTEsMTkuMjY2LDI4Ljg5Nyw0LjY0NS02Ljk2MmM4Ljg1OS0xMy4yOSw4Ljg1OS0zMC40NjYsMC00My43NTVsLTk4Ljc2Ny0xNDguMTgxaDg5LjYzMmwxMTMuMTc3LDE2OS45MTguMDk0LS4wNzgsMTkuMjM2LDI4Ljg1NCw0LjYzNi02Ljk1NGM4Ljg0OS0xMy4yNzEsOC44NDktMzAuNDIyLjAwMS00My42OTRoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KPC9zdmc+"
-
-	// Attribute names extracted per Sonar go:S1192.
-	attrType      = "gcp.persistent-disk.type"
-	attrSizeGB    = "gcp.persistent-disk.size-gb"
-	attrZone      = "gcp.persistent-disk.zone"
-	attrProjectID = "gcp.project.id"
-)
-
 type diskDiscovery struct{}
 
 var (
@@ -79,13 +68,13 @@ func (d *diskDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
 
 func (d *diskDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
 	return []discovery_kit_api.AttributeDescription{
-		{Attribute: "gcp.persistent-disk.name", Label: discovery_kit_api.PluralLabel{One: "Disk name", Other: "Disk names"}},
+		{Attribute: attrName, Label: discovery_kit_api.PluralLabel{One: "Disk name", Other: "Disk names"}},
 		{Attribute: attrType, Label: discovery_kit_api.PluralLabel{One: "Disk type", Other: "Disk types"}},
 		{Attribute: attrSizeGB, Label: discovery_kit_api.PluralLabel{One: "Disk size (GiB)", Other: "Disk sizes (GiB)"}},
 		{Attribute: attrZone, Label: discovery_kit_api.PluralLabel{One: "Disk zone", Other: "Disk zones"}},
-		{Attribute: "gcp.persistent-disk.region", Label: discovery_kit_api.PluralLabel{One: "Disk region", Other: "Disk regions"}},
+		{Attribute: attrRegion, Label: discovery_kit_api.PluralLabel{One: "Disk region", Other: "Disk regions"}},
 		{Attribute: "gcp.persistent-disk.status", Label: discovery_kit_api.PluralLabel{One: "Disk status", Other: "Disk statuses"}},
-		{Attribute: "gcp.persistent-disk.users", Label: discovery_kit_api.PluralLabel{One: "Disk attached VM", Other: "Disk attached VMs"}},
+		{Attribute: attrUsers, Label: discovery_kit_api.PluralLabel{One: "Disk attached VM", Other: "Disk attached VMs"}},
 		{Attribute: "gcp.persistent-disk.source-image", Label: discovery_kit_api.PluralLabel{One: "Disk source image", Other: "Disk source images"}},
 		{Attribute: "gcp.persistent-disk.source-snapshot", Label: discovery_kit_api.PluralLabel{One: "Disk source snapshot", Other: "Disk source snapshots"}},
 		{Attribute: "gcp.persistent-disk.kms-key-name", Label: discovery_kit_api.PluralLabel{One: "Disk KMS key name", Other: "Disk KMS key names"}},
@@ -143,12 +132,12 @@ func classifyScope(key string) (zone, region string) {
 func toDiskTarget(disk *computepb.Disk, zone, region, projectID string) discovery_kit_api.Target {
 	attributes := make(map[string][]string)
 	attributes[attrProjectID] = []string{projectID}
-	attributes["gcp.persistent-disk.name"] = []string{disk.GetName()}
+	attributes[attrName] = []string{disk.GetName()}
 	if zone != "" {
 		attributes[attrZone] = []string{zone}
 	}
 	if region != "" {
-		attributes["gcp.persistent-disk.region"] = []string{region}
+		attributes[attrRegion] = []string{region}
 	}
 	if t := disk.GetType(); t != "" {
 		// type is a URL; surface the last path component for readability.
@@ -167,7 +156,7 @@ func toDiskTarget(disk *computepb.Disk, zone, region, projectID string) discover
 	if users := disk.GetUsers(); len(users) > 0 {
 		sorted := append([]string(nil), users...)
 		sort.Strings(sorted)
-		attributes["gcp.persistent-disk.users"] = sorted
+		attributes[attrUsers] = sorted
 	}
 	if v := disk.GetSourceImage(); v != "" {
 		attributes["gcp.persistent-disk.source-image"] = []string{v}
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extgke
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// NodePoolStopInstancesState holds the instances selected in Prepare and the MIG operations Start got accepted. The
+// attack is reversible: the instances are stopped through their MIG, which keeps them (and so the node names) instead
+// of repairing them, and Stop starts them again. StopOperations maps "<zone>/<migName>" to the zonal operation of
+// its stopInstances call, so Stop can wait for the VMs to be stopped before starting them.
+type NodePoolStopInstancesState struct {
+	ProjectID    string
+	ClusterName  string
+	NodePoolName string
+	Location     string // GKE cluster location (region or zone)
+	Percentage   int
+	// InstancesByMig maps "<zone>/<migName>" → list of full instance URLs selected for stopping.
+	InstancesByMig map[string][]string
+	StopOperations map[string]string
+}
+
+type nodePoolStopInstancesAttack struct {
+	migClientProvider        func(ctx context.Context, projectID string) (migInstancesApi, func(), error)
+	operationsClientProvider func(ctx context.Context, projectID string) (zoneOperationsApi, func(), error)
+	rng                      func(n int) []int
+}
+
+var _ action_kit_sdk.Action[NodePoolStopInstancesState] = (*nodePoolStopInstancesAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[NodePoolStopInstancesState] = (*nodePoolStopInstancesAttack)(nil)
+
+func NewNodePoolStopInstancesAction() action_kit_sdk.ActionWithStop[NodePoolStopInstancesState] {
+	return &nodePoolStopInstancesAttack{
+		migClientProvider:        newMigInstancesApi,
+		operationsClientProvider: newZoneOperationsApi,
+		rng:                      rand.Perm,
+	}
+}
+
+func (a *nodePoolStopInstancesAttack) NewEmptyState() NodePoolStopInstancesState {
+	return NodePoolStopInstancesState{}
+}
+
+func (a *nodePoolStopInstancesAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          NodePoolStopInstancesActionId,
+		Label:       "Stop GKE node pool instances",
+		Description: "Stops a percentage of node instances in a GKE node pool via the underlying MIG's StopInstances API for the given duration. The nodes become NotReady and their pods are evicted after the taint-based eviction timeout. The same instances are started again on stop, so the nodes keep their names.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDNodePool,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by cluster name and node pool name",
+					Description: extutil.Ptr("Find GKE node pool by cluster name and node pool name"),
+					Query:       "gcp.gke.cluster.name=\"\" and gcp.gke.nodepool.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("GKE"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the selected nodes stay stopped. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "percentage",
+				Label:        "Percentage of instances to stop",
+				Description:  extutil.Ptr("Percentage (1-100) of node pool's instances to stop. Defaults to 33%."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("33"),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(100),
+			},
+			{
+				Name:         "confirmHighImpact",
+				Label:        "Allow percentages above 50%",
+				Description:  extutil.Ptr("Required to enable percentages above 50%. Acknowledges that more than half the node pool will be stopped simultaneously."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("false"),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(false),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *nodePoolStopInstancesAttack) Prepare(ctx context.Context, state *NodePoolStopInstancesState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.ClusterName, state.NodePoolName, state.Location, err = resolveNodePool(request)
+	if err != nil {
+		return nil, err
+	}
+	pct := extutil.ToInt(request.Config["percentage"])
+	if pct < 1 || pct > 100 {
+		return nil, extension_kit.ToError("percentage must be between 1 and 100.", nil)
+	}
+	confirmHigh := extutil.ToBool(request.Config["confirmHighImpact"])
+	if pct > 50 && !confirmHigh {
+		return nil, extension_kit.ToError("Percentages above 50% require the 'Allow percentages above 50%' flag — half the node pool will be stopped at once.", nil)
+	}
+	state.Percentage = pct
+
+	np, err := getNodePool(ctx, state.ProjectID, state.Location, state.ClusterName, state.NodePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	migClient, closer, err := a.migClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create MIG client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+
+	allInstances, err := listRunningNodePoolInstances(ctx, migClient, state.ProjectID, np.InstanceGroupUrls)
+	if err != nil {
+		return nil, err
+	}
+	if len(allInstances) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("GKE node pool %s/%s has no RUNNING instances to stop", state.ClusterName, state.NodePoolName), nil)
+	}
+	var sampleSize int
+	state.InstancesByMig, sampleSize, err = sampleNodePoolInstances(allInstances, pct, confirmHigh, a.rng)
+	if err != nil {
+		return nil, err
+	}
+	return &action_kit_api.PrepareResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Selected %d of %d RUNNING instance(s) (%d%%) in GKE node pool %s/%s to stop across %d MIG(s)", sampleSize, len(allInstances), pct, state.ClusterName, state.NodePoolName, len(state.InstancesByMig)),
+		}}),
+	}, nil
+}
+
+func (a *nodePoolStopInstancesAttack) Start(ctx context.Context, state *NodePoolStopInstancesState) (*action_kit_api.StartResult, error) {
+	if len(state.InstancesByMig) == 0 {
+		return nil, extension_kit.ToError("No instances selected for stopping.", nil)
+	}
+	client, closer, err := a.migClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create MIG client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+
+	if state.StopOperations == nil {
+		state.StopOperations = make(map[string]string, len(state.InstancesByMig))
+	}
+	total, err := stopMigInstances(ctx, client, state.ProjectID, state.InstancesByMig, state.StopOperations)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Stopping instances in GKE node pool %s/%s was only partially applied (%d instance(s) stopped)", state.ClusterName, state.NodePoolName, total), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Stop requested for %d instance(s) in GKE node pool %s/%s", total, state.ClusterName, state.NodePoolName),
+		}}),
+	}, nil
+}
+
+func (a *nodePoolStopInstancesAttack) Stop(ctx context.Context, state *NodePoolStopInstancesState) (*action_kit_api.StopResult, error) {
+	if len(state.StopOperations) == 0 {
+		return nil, nil
+	}
+	started, err := a.startInstances(ctx, state)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to start instances stopped in GKE node pool %s/%s", state.ClusterName, state.NodePoolName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to start instances stopped in GKE node pool %s/%s", state.ClusterName, state.NodePoolName), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Start requested for %d instance(s) in GKE node pool %s/%s", started, state.ClusterName, state.NodePoolName),
+		}}),
+	}, nil
+}
+
+func (a *nodePoolStopInstancesAttack) startInstances(ctx context.Context, state *NodePoolStopInstancesState) (int, error) {
+	client, closer, err := a.migClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	defer closer()
+	ops, closeOps, err := a.operationsClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	defer closeOps()
+	return startMigInstances(ctx, client, ops, state.ProjectID, state.InstancesByMig, state.StopOperations)
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extgke
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMigs struct {
+	stopped   map[string][]string
+	started   map[string][]string
+	recreated map[string][]string
+	stopErr   map[string]error
+	startErr  error
+}
+
+func newFakeMigs() *fakeMigs {
+	return &fakeMigs{stopped: map[string][]string{}, started: map[string][]string{}, recreated: map[string][]string{}, stopErr: map[string]error{}}
+}
+
+func (f *fakeMigs) ListManagedInstances(context.Context, *computepb.ListManagedInstancesInstanceGroupManagersRequest, ...gax.CallOption) *compute.ManagedInstanceIterator {
+	return nil
+}
+
+func (f *fakeMigs) RecreateInstances(_ context.Context, req *computepb.RecreateInstancesInstanceGroupManagerRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	f.recreated[req.Zone+"/"+req.InstanceGroupManager] = req.InstanceGroupManagersRecreateInstancesRequestResource.Instances
+	return nil, nil
+}
+
+func (f *fakeMigs) StopInstances(_ context.Context, req *computepb.StopInstancesInstanceGroupManagerRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	key := req.Zone + "/" + req.InstanceGroupManager
+	if err := f.stopErr[key]; err != nil {
+		return nil, err
+	}
+	f.stopped[key] = req.InstanceGroupManagersStopInstancesRequestResource.Instances
+	return nil, nil
+}
+
+func (f *fakeMigs) StartInstances(_ context.Context, req *computepb.StartInstancesInstanceGroupManagerRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	if f.startErr != nil {
+		return nil, f.startErr
+	}
+	f.started[req.Zone+"/"+req.InstanceGroupManager] = req.InstanceGroupManagersStartInstancesRequestResource.Instances
+	return nil, nil
+}
+
+type fakeZoneOperations struct {
+	waited []string
+	// pending is the number of Wait calls answered with RUNNING before DONE.
+	pending int
+	// failed holds the error message of operations that end DONE but unsuccessful.
+	failed map[string]string
+}
+
+func (f *fakeZoneOperations) Wait(_ context.Context, req *computepb.WaitZoneOperationRequest, _ ...gax.CallOption) (*computepb.Operation, error) {
+	f.waited = append(f.waited, req.Zone+"/"+req.Operation)
+	if f.pending > 0 {
+		f.pending--
+		return &computepb.Operation{Status: extutil.Ptr(computepb.Operation_RUNNING)}, nil
+	}
+	op := &computepb.Operation{Status: extutil.Ptr(computepb.Operation_DONE)}
+	if msg, ok := f.failed[req.Operation]; ok {
+		op.Error = &computepb.Error{Errors: []*computepb.Errors{{Code: extutil.Ptr("CONDITION_NOT_MET"), Message: extutil.Ptr(msg)}}}
+	}
+	return op, nil
+}
+
+func newStopTestAttack(migs *fakeMigs, ops *fakeZoneOperations) *nodePoolStopInstancesAttack {
+	return &nodePoolStopInstancesAttack{
+		migClientProvider: func(context.Context, string) (migInstancesApi, func(), error) {
+			return migs, func() {}, nil
+		},
+		operationsClientProvider: func(context.Context, string) (zoneOperationsApi, func(), error) {
+			return ops, func() {}, nil
+		},
+	}
+}
+
+func stopTestState() NodePoolStopInstancesState {
+	return NodePoolStopInstancesState{
+		ProjectID:    "proj-a",
+		ClusterName:  "prod",
+		NodePoolName: "default-pool",
+		Location:     "europe-west1",
+		Percentage:   33,
+		InstancesByMig: map[string][]string{
+			"europe-west1-b/mig-b": {"zones/europe-west1-b/instances/node-1"},
+			"europe-west1-c/mig-c": {"zones/europe-west1-c/instances/node-2", "zones/europe-west1-c/instances/node-3"},
+		},
+	}
+}
+
+func TestNodePoolStop_Describe(t *testing.T) {
+	a := &nodePoolStopInstancesAttack{}
+	desc := a.Describe()
+	assert.Equal(t, NodePoolStopInstancesActionId, desc.Id)
+	assert.Equal(t, TargetIDNodePool, desc.TargetSelection.TargetType)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, NodePoolStopInstancesState{}, a.NewEmptyState())
+	assert.NotNil(t, NewNodePoolStopInstancesAction())
+}
+
+func TestNodePoolStop_Prepare_MissingRequiredAttr(t *testing.T) {
+	for _, drop := range []string{"gcp.project.id", attrClusterName, "gcp.gke.nodepool.name", "gcp.gke.cluster.location"} {
+		attrs := map[string][]string{}
+		for k, v := range validNodePoolAttrs {
+			if k != drop {
+				attrs[k] = v
+			}
+		}
+		a := &nodePoolStopInstancesAttack{}
+		state := NodePoolStopInstancesState{}
+		_, err := a.Prepare(context.Background(), &state, gkePrepareReq(attrs, map[string]interface{}{"percentage": 33}))
+		require.Error(t, err, "dropping %s should fail Prepare", drop)
+		assert.Contains(t, err.Error(), "missing")
+	}
+}
+
+func TestNodePoolStop_Prepare_PercentageGates(t *testing.T) {
+	a := &nodePoolStopInstancesAttack{}
+	state := NodePoolStopInstancesState{}
+
+	_, err := a.Prepare(context.Background(), &state, gkePrepareReq(validNodePoolAttrs, map[string]interface{}{"percentage": 0}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "percentage")
+
+	_, err = a.Prepare(context.Background(), &state, gkePrepareReq(validNodePoolAttrs, map[string]interface{}{"percentage": 75}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Allow percentages above 50%")
+}
+
+func TestNodePoolStop_StartStopsAndStopStartsSameInstances(t *testing.T) {
+	migs := newFakeMigs()
+	ops := &fakeZoneOperations{}
+	a := newStopTestAttack(migs, ops)
+	state := stopTestState()
+
+	_, err := a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstancesByMig, migs.stopped)
+	assert.Len(t, state.StopOperations, 2)
+
+	result, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstancesByMig, migs.started)
+	assert.Contains(t, (*result.Messages)[0].Message, "3 instance(s)")
+}
+
+func TestNodePoolStop_StartRecordsAcceptedStopsOnPartialFailure(t *testing.T) {
+	migs := newFakeMigs()
+	migs.stopErr["europe-west1-c/mig-c"] = errors.New("boom")
+	a := newStopTestAttack(migs, &fakeZoneOperations{})
+	state := stopTestState()
+
+	_, err := a.Start(context.Background(), &state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partially applied")
+	assert.Equal(t, map[string]string{"europe-west1-b/mig-b": ""}, state.StopOperations)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"europe-west1-b/mig-b": {"zones/europe-west1-b/instances/node-1"}}, migs.started)
+}
+
+func TestNodePoolStop_StopWaitsForStopOperation(t *testing.T) {
+	migs := newFakeMigs()
+	ops := &fakeZoneOperations{pending: 2}
+	a := newStopTestAttack(migs, ops)
+	state := stopTestState()
+	state.StopOperations = map[string]string{"europe-west1-b/mig-b": "op-1"}
+
+	_, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"europe-west1-b/op-1", "europe-west1-b/op-1", "europe-west1-b/op-1"}, ops.waited)
+	assert.Contains(t, migs.started, "europe-west1-b/mig-b")
+}
+
+func TestNodePoolStop_StopStartsInstancesOfFailedStopOperation(t *testing.T) {
+	migs := newFakeMigs()
+	ops := &fakeZoneOperations{failed: map[string]string{"op-b": "instance node-1 is not running"}}
+	a := newStopTestAttack(migs, ops)
+	state := stopTestState()
+	state.StopOperations = map[string]string{"europe-west1-b/mig-b": "op-b", "europe-west1-c/mig-c": "op-c"}
+
+	_, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zones/europe-west1-b/instances/node-1"}, migs.started["europe-west1-b/mig-b"])
+	assert.Contains(t, migs.started, "europe-west1-c/mig-c")
+}
+
+func TestNodePoolStop_StopWithoutStartIsNoop(t *testing.T) {
+	migs := newFakeMigs()
+	a := newStopTestAttack(migs, &fakeZoneOperations{})
+	state := stopTestState()
+
+	result, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Empty(t, migs.started)
+}
+
+func TestNodePoolStop_StopFailsWhenStartFails(t *testing.T) {
+	migs := newFakeMigs()
+	migs.startErr = errors.New("boom")
+	a := newStopTestAttack(migs, &fakeZoneOperations{})
+	state := stopTestState()
+	state.StopOperations = map[string]string{"europe-west1-b/mig-b": ""}
+
+	_, err := a.Stop(context.Background(), &state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to start instances")
+}
+
+func TestSampleNodePoolInstances(t *testing.T) {
+	all := []nodePoolInstance{
+		{migZone: "europe-west1-b", migName: "mig-b", url: "u1"},
+		{migZone: "europe-west1-b", migName: "mig-b", url: "u2"},
+		{migZone: "europe-west1-c", migName: "mig-c", url: "u3"},
+	}
+	identity := func(n int) []int {
+		perm := make([]int, n)
+		for i := range perm {
+			perm[i] = i
+		}
+		return perm
+	}
+
+	byMig, size, err := sampleNodePoolInstances(all, 33, false, identity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, size)
+	assert.Equal(t, map[string][]string{"europe-west1-b/mig-b": {"u1"}}, byMig)
+
+	// 50% of 3 floors to 1 node.
+	_, size, err = sampleNodePoolInstances(all, 50, false, identity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, size)
+
+	// A single node is always more than half the pool.
+	_, _, err = sampleNodePoolInstances(all[:1], 10, false, identity)
+	require.Error(t, err)
+	_, size, err = sampleNodePoolInstances(all[:1], 10, true, identity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, size)
+}
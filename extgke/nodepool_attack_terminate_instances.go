@@ -7,23 +7,15 @@ package extgke
 import (
 	"context"
 	"fmt"
-	"math"
 	"math/rand"
-	"sort"
 	"strings"
 
-	compute "cloud.google.com/go/compute/apiv1"
 	"cloud.google.com/go/compute/apiv1/computepb"
-	container "cloud.google.com/go/container/apiv1"
-	"cloud.google.com/go/container/apiv1/containerpb"
-	"github.com/googleapis/gax-go/v2"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
-	"github.com/steadybit/extension-gcp/utils"
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
-	"google.golang.org/api/iterator"
 )
 
 // NodePoolTerminateInstancesState captures enough state to execute the recreate-instances attack.
@@ -43,11 +35,6 @@ type NodePoolTerminateInstancesState struct {
 	InstancesByMig map[string][]string
 }
 
-type migInstancesApi interface {
-	ListManagedInstances(ctx context.Context, req *computepb.ListManagedInstancesInstanceGroupManagersRequest, opts ...gax.CallOption) *compute.ManagedInstanceIterator
-	RecreateInstances(ctx context.Context, req *computepb.RecreateInstancesInstanceGroupManagerRequest, opts ...gax.CallOption) (*compute.Operation, error)
-}
-
 type nodePoolTerminateInstancesAttack struct {
 	migClientProvider func(ctx context.Context, projectID string) (migInstancesApi, func(), error)
 	rng               func(n int) []int
@@ -57,18 +44,8 @@ var _ action_kit_sdk.Action[NodePoolTerminateInstancesState] = (*nodePoolTermina
 
 func NewNodePoolTerminateInstancesAction() action_kit_sdk.Action[NodePoolTerminateInstancesState] {
 	return &nodePoolTerminateInstancesAttack{
-		migClientProvider: func(ctx context.Context, projectID string) (migInstancesApi, func(), error) {
-			access, err := utils.GetGcpAccess(projectID)
-			if err != nil {
-				return nil, nil, err
-			}
-			c, err := compute.NewInstanceGroupManagersRESTClient(ctx, access.ClientOptions...)
-			if err != nil {
-				return nil, nil, err
-			}
-			return c, func() { _ = c.Close() }, nil
-		},
-		rng: rand.Perm,
+		migClientProvider: newMigInstancesApi,
+		rng:               rand.Perm,
 	}
 }
 
@@ -123,12 +100,10 @@ func (a *nodePoolTerminateInstancesAttack) Describe() action_kit_api.ActionDescr
 }
 
 func (a *nodePoolTerminateInstancesAttack) Prepare(ctx context.Context, state *NodePoolTerminateInstancesState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
-	state.ProjectID = mustHave(request.Target.Attributes, "gcp.project.id")
-	state.ClusterName = mustHave(request.Target.Attributes, attrClusterName)
-	state.NodePoolName = mustHave(request.Target.Attributes, "gcp.gke.nodepool.name")
-	state.Location = mustHave(request.Target.Attributes, "gcp.gke.cluster.location")
-	if state.ProjectID == "" || state.ClusterName == "" || state.NodePoolName == "" || state.Location == "" {
-		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.gke.cluster.name, gcp.gke.nodepool.name, gcp.gke.cluster.location", nil)
+	var err error
+	state.ProjectID, state.ClusterName, state.NodePoolName, state.Location, err = resolveNodePool(request)
+	if err != nil {
+		return nil, err
 	}
 	pct := extutil.ToInt(request.Config["percentage"])
 	if pct < 1 || pct > 100 {
@@ -140,24 +115,9 @@ func (a *nodePoolTerminateInstancesAttack) Prepare(ctx context.Context, state *N
 	}
 	state.Percentage = pct
 
-	// Fetch fresh InstanceGroupUrls for the node pool — they may have changed since discovery.
-	access, err := utils.GetGcpAccess(state.ProjectID)
-	if err != nil {
-		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get GCP access for project %s", state.ProjectID), err)
-	}
-	gke, err := container.NewClusterManagerClient(ctx, access.ClientOptions...)
+	np, err := getNodePool(ctx, state.ProjectID, state.Location, state.ClusterName, state.NodePoolName)
 	if err != nil {
-		return nil, extension_kit.ToError("Failed to create GKE client", err)
-	}
-	defer func() { _ = gke.Close() }()
-	np, err := gke.GetNodePool(ctx, &containerpb.GetNodePoolRequest{
-		Name: fmt.Sprintf("projects/%s/locations/%s/clusters/%s/nodePools/%s", state.ProjectID, state.Location, state.ClusterName, state.NodePoolName),
-	})
-	if err != nil {
-		return nil, extension_kit.ToError(fmt.Sprintf("Failed to describe GKE node pool %s/%s", state.ClusterName, state.NodePoolName), err)
-	}
-	if len(np.InstanceGroupUrls) == 0 {
-		return nil, extension_kit.ToError(fmt.Sprintf("GKE node pool %s/%s has no underlying instance groups", state.ClusterName, state.NodePoolName), nil)
+		return nil, err
 	}
 
 	migClient, closer, err := a.migClientProvider(ctx, state.ProjectID)
@@ -166,71 +126,17 @@ func (a *nodePoolTerminateInstancesAttack) Prepare(ctx context.Context, state *N
 	}
 	defer closer()
 
-	type instanceRef struct {
-		migZone string
-		migName string
-		url     string
-	}
-	allInstances := make([]instanceRef, 0)
-	for _, igURL := range np.InstanceGroupUrls {
-		zone, name, ok := parseZonalMIGUrl(igURL)
-		if !ok {
-			// Regional MIGs aren't supported by GKE for node pools, but skip unknown URL shapes defensively.
-			continue
-		}
-		it := migClient.ListManagedInstances(ctx, &computepb.ListManagedInstancesInstanceGroupManagersRequest{
-			Project:              state.ProjectID,
-			Zone:                 zone,
-			InstanceGroupManager: name,
-		})
-		for {
-			mi, err := it.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				return nil, extension_kit.ToError(fmt.Sprintf("Failed to list instances of MIG %s/%s", zone, name), err)
-			}
-			// Only target currently running instances; skip those already being created/deleted/repaired.
-			if mi.GetInstanceStatus() != "RUNNING" {
-				continue
-			}
-			if mi.GetInstance() == "" {
-				continue
-			}
-			allInstances = append(allInstances, instanceRef{migZone: zone, migName: name, url: mi.GetInstance()})
-		}
+	allInstances, err := listRunningNodePoolInstances(ctx, migClient, state.ProjectID, np.InstanceGroupUrls)
+	if err != nil {
+		return nil, err
 	}
 	if len(allInstances) == 0 {
 		return nil, extension_kit.ToError(fmt.Sprintf("GKE node pool %s/%s has no RUNNING instances to recreate", state.ClusterName, state.NodePoolName), nil)
 	}
-
-	// Sort for determinism, then random-sample N%.
-	sort.Slice(allInstances, func(i, j int) bool { return allInstances[i].url < allInstances[j].url })
-	// Use math.Floor (not math.Ceil) so the sample never exceeds the requested
-	// percentage — math.Ceil silently amplifies the effective impact past the
-	// 50 % gate on small node pools.
-	sampleSize := int(math.Floor(float64(len(allInstances)) * float64(pct) / 100.0))
-	if sampleSize < 1 {
-		sampleSize = 1
-	}
-	if sampleSize > len(allInstances) {
-		sampleSize = len(allInstances)
-	}
-	// Small-pool guard: the >=1 clamp can still push the effective ratio above
-	// 50 % (e.g. pct=50 on 1 node → 100 %). Refuse unless confirmHighImpact was
-	// explicitly set.
-	if sampleSize*2 > len(allInstances) && !confirmHigh {
-		return nil, extension_kit.ToError(fmt.Sprintf(
-			"Effective impact %d of %d node(s) exceeds 50%% (small node pool rounds up to a full node). Set 'Allow percentages above 50%%' to acknowledge.",
-			sampleSize, len(allInstances)), nil)
-	}
-	perm := a.rng(len(allInstances))
-	state.InstancesByMig = make(map[string][]string)
-	for i := 0; i < sampleSize; i++ {
-		ref := allInstances[perm[i]]
-		key := fmt.Sprintf("%s/%s", ref.migZone, ref.migName)
-		state.InstancesByMig[key] = append(state.InstancesByMig[key], ref.url)
+	var sampleSize int
+	state.InstancesByMig, sampleSize, err = sampleNodePoolInstances(allInstances, pct, confirmHigh, a.rng)
+	if err != nil {
+		return nil, err
 	}
 	return &action_kit_api.PrepareResult{
 		Messages: extutil.Ptr([]action_kit_api.Message{{
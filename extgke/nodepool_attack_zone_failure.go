@@ -0,0 +1,258 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extgke
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+const (
+	zoneFailureModeStop     = "stop"
+	zoneFailureModeRecreate = "recreate"
+)
+
+// NodePoolZoneFailureState holds every RUNNING instance of the node pool in the chosen zone. In stop mode the attack
+// is reversible: StopOperations maps "<zone>/<migName>" to the accepted stopInstances operation and Stop starts those
+// instances again. In recreate mode the instances are replaced by their MIG and nothing is restored.
+type NodePoolZoneFailureState struct {
+	ProjectID    string
+	ClusterName  string
+	NodePoolName string
+	Location     string // GKE cluster location (region or zone)
+	Zone         string
+	Mode         string
+	// InstancesByMig maps "<zone>/<migName>" → list of full instance URLs in the attacked zone.
+	InstancesByMig map[string][]string
+	StopOperations map[string]string
+}
+
+type nodePoolZoneFailureAttack struct {
+	migClientProvider        func(ctx context.Context, projectID string) (migInstancesApi, func(), error)
+	operationsClientProvider func(ctx context.Context, projectID string) (zoneOperationsApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[NodePoolZoneFailureState] = (*nodePoolZoneFailureAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[NodePoolZoneFailureState] = (*nodePoolZoneFailureAttack)(nil)
+
+func NewNodePoolZoneFailureAction() action_kit_sdk.ActionWithStop[NodePoolZoneFailureState] {
+	return &nodePoolZoneFailureAttack{
+		migClientProvider:        newMigInstancesApi,
+		operationsClientProvider: newZoneOperationsApi,
+	}
+}
+
+func (a *nodePoolZoneFailureAttack) NewEmptyState() NodePoolZoneFailureState {
+	return NodePoolZoneFailureState{}
+}
+
+func (a *nodePoolZoneFailureAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          NodePoolZoneFailureActionId,
+		Label:       "Fail GKE node pool zone",
+		Description: "Takes down all instances of a GKE node pool in one of its zones via the underlying MIGs, either by stopping them for the given duration or by recreating them. Proves that workloads survive the loss of a zone. Stopped instances are started again on stop; recreated instances are not reversible.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDNodePool,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by cluster name and node pool name",
+					Description: extutil.Ptr("Find GKE node pool by cluster name and node pool name"),
+					Query:       "gcp.gke.cluster.name=\"\" and gcp.gke.nodepool.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("GKE"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the zone stays down. Restored on stop in stop mode; recreated instances heal through their MIG."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "zone",
+				Label:       "Zone",
+				Description: extutil.Ptr("Zone of the node pool whose instances are taken down."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ParameterOptionsFromTargetAttribute{Attribute: attrNodePoolLocations},
+				}),
+			},
+			{
+				Name:         "mode",
+				Label:        "Mode",
+				Description:  extutil.Ptr("Stop keeps the instances and starts them again on stop; recreate replaces them from the instance template."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: extutil.Ptr(zoneFailureModeStop),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Stop instances", Value: zoneFailureModeStop},
+					action_kit_api.ExplicitParameterOption{Label: "Recreate instances", Value: zoneFailureModeRecreate},
+				}),
+			},
+			{
+				Name:         "confirmHighImpact",
+				Label:        "Allow taking down the whole node pool",
+				Description:  extutil.Ptr("Required when the zone holds all of the node pool's running instances, e.g. for a single-zone node pool."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("false"),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(false),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *nodePoolZoneFailureAttack) Prepare(ctx context.Context, state *NodePoolZoneFailureState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.ClusterName, state.NodePoolName, state.Location, err = resolveNodePool(request)
+	if err != nil {
+		return nil, err
+	}
+	state.Zone = extutil.ToString(request.Config["zone"])
+	if state.Zone == "" {
+		return nil, extension_kit.ToError("zone is required.", nil)
+	}
+	if locations := request.Target.Attributes[attrNodePoolLocations]; len(locations) > 0 && !slices.Contains(locations, state.Zone) {
+		return nil, extension_kit.ToError(fmt.Sprintf("GKE node pool %s/%s does not span zone %s (locations: %v)", state.ClusterName, state.NodePoolName, state.Zone, locations), nil)
+	}
+	state.Mode = extutil.ToString(request.Config["mode"])
+	if state.Mode == "" {
+		state.Mode = zoneFailureModeStop
+	}
+	if state.Mode != zoneFailureModeStop && state.Mode != zoneFailureModeRecreate {
+		return nil, extension_kit.ToError(fmt.Sprintf("mode must be %q or %q.", zoneFailureModeStop, zoneFailureModeRecreate), nil)
+	}
+	confirmHigh := extutil.ToBool(request.Config["confirmHighImpact"])
+
+	np, err := getNodePool(ctx, state.ProjectID, state.Location, state.ClusterName, state.NodePoolName)
+	if err != nil {
+		return nil, err
+	}
+
+	migClient, closer, err := a.migClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create MIG client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+
+	allInstances, err := listRunningNodePoolInstances(ctx, migClient, state.ProjectID, np.InstanceGroupUrls)
+	if err != nil {
+		return nil, err
+	}
+	var inZone int
+	state.InstancesByMig, inZone, err = instancesInZone(allInstances, state.Zone, confirmHigh)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Cannot fail zone %s of GKE node pool %s/%s", state.Zone, state.ClusterName, state.NodePoolName), err)
+	}
+	return &action_kit_api.PrepareResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Selected %d of %d RUNNING instance(s) of GKE node pool %s/%s in zone %s to %s", inZone, len(allInstances), state.ClusterName, state.NodePoolName, state.Zone, state.Mode),
+		}}),
+	}, nil
+}
+
+// instancesInZone groups the instances living in zone by "<zone>/<migName>". Taking down a zone that holds every
+// running instance is a full node pool outage and needs confirmHigh.
+func instancesInZone(allInstances []nodePoolInstance, zone string, confirmHigh bool) (map[string][]string, int, error) {
+	byMig := make(map[string][]string)
+	count := 0
+	for _, i := range allInstances {
+		if i.migZone != zone {
+			continue
+		}
+		byMig[i.migKey()] = append(byMig[i.migKey()], i.url)
+		count++
+	}
+	if count == 0 {
+		return nil, 0, fmt.Errorf("no RUNNING instances in zone %s", zone)
+	}
+	if count == len(allInstances) && !confirmHigh {
+		return nil, 0, fmt.Errorf("zone %s holds all %d RUNNING instance(s); set 'Allow taking down the whole node pool' to acknowledge", zone, count)
+	}
+	return byMig, count, nil
+}
+
+func (a *nodePoolZoneFailureAttack) Start(ctx context.Context, state *NodePoolZoneFailureState) (*action_kit_api.StartResult, error) {
+	if len(state.InstancesByMig) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("No instances selected in zone %s.", state.Zone), nil)
+	}
+	client, closer, err := a.migClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create MIG client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+
+	var affected int
+	if state.Mode == zoneFailureModeRecreate {
+		affected, err = recreateMigInstances(ctx, client, state.ProjectID, state.InstancesByMig)
+	} else {
+		if state.StopOperations == nil {
+			state.StopOperations = make(map[string]string, len(state.InstancesByMig))
+		}
+		affected, err = stopMigInstances(ctx, client, state.ProjectID, state.InstancesByMig, state.StopOperations)
+	}
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Zone failure of GKE node pool %s/%s in %s was only partially applied (%s requested for %d instance(s))", state.ClusterName, state.NodePoolName, state.Zone, state.Mode, affected), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Zone failure of GKE node pool %s/%s in %s: %s requested for %d instance(s)", state.ClusterName, state.NodePoolName, state.Zone, state.Mode, affected),
+		}}),
+	}, nil
+}
+
+func (a *nodePoolZoneFailureAttack) Stop(ctx context.Context, state *NodePoolZoneFailureState) (*action_kit_api.StopResult, error) {
+	if len(state.StopOperations) == 0 {
+		return nil, nil
+	}
+	started, err := a.startInstances(ctx, state)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to start instances of GKE node pool %s/%s stopped in zone %s", state.ClusterName, state.NodePoolName, state.Zone)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to start instances of GKE node pool %s/%s stopped in zone %s", state.ClusterName, state.NodePoolName, state.Zone), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Start requested for %d instance(s) of GKE node pool %s/%s in zone %s", started, state.ClusterName, state.NodePoolName, state.Zone),
+		}}),
+	}, nil
+}
+
+func (a *nodePoolZoneFailureAttack) startInstances(ctx context.Context, state *NodePoolZoneFailureState) (int, error) {
+	client, closer, err := a.migClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	defer closer()
+	ops, closeOps, err := a.operationsClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	defer closeOps()
+	return startMigInstances(ctx, client, ops, state.ProjectID, state.InstancesByMig, state.StopOperations)
+}
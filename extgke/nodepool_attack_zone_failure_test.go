@@ -0,0 +1,165 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extgke
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newZoneFailureTestAttack(migs *fakeMigs, ops *fakeZoneOperations) *nodePoolZoneFailureAttack {
+	return &nodePoolZoneFailureAttack{
+		migClientProvider: func(context.Context, string) (migInstancesApi, func(), error) {
+			return migs, func() {}, nil
+		},
+		operationsClientProvider: func(context.Context, string) (zoneOperationsApi, func(), error) {
+			return ops, func() {}, nil
+		},
+	}
+}
+
+func zoneFailureTestState(mode string) NodePoolZoneFailureState {
+	return NodePoolZoneFailureState{
+		ProjectID:    "proj-a",
+		ClusterName:  "prod",
+		NodePoolName: "default-pool",
+		Location:     "europe-west1",
+		Zone:         "europe-west1-b",
+		Mode:         mode,
+		InstancesByMig: map[string][]string{
+			"europe-west1-b/mig-b": {"zones/europe-west1-b/instances/node-1", "zones/europe-west1-b/instances/node-2"},
+		},
+	}
+}
+
+func TestNodePoolZoneFailure_Describe(t *testing.T) {
+	a := &nodePoolZoneFailureAttack{}
+	desc := a.Describe()
+	assert.Equal(t, NodePoolZoneFailureActionId, desc.Id)
+	assert.Equal(t, TargetIDNodePool, desc.TargetSelection.TargetType)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, NodePoolZoneFailureState{}, a.NewEmptyState())
+	assert.NotNil(t, NewNodePoolZoneFailureAction())
+
+	var zone action_kit_api.ActionParameter
+	for _, p := range desc.Parameters {
+		if p.Name == "zone" {
+			zone = p
+		}
+	}
+	require.NotNil(t, zone.Options)
+	assert.Equal(t, []action_kit_api.ParameterOption{action_kit_api.ParameterOptionsFromTargetAttribute{Attribute: attrNodePoolLocations}}, *zone.Options)
+}
+
+func TestNodePoolZoneFailure_Prepare_MissingRequiredAttr(t *testing.T) {
+	for _, drop := range []string{"gcp.project.id", attrClusterName, "gcp.gke.nodepool.name", "gcp.gke.cluster.location"} {
+		attrs := map[string][]string{}
+		for k, v := range validNodePoolAttrs {
+			if k != drop {
+				attrs[k] = v
+			}
+		}
+		a := &nodePoolZoneFailureAttack{}
+		state := NodePoolZoneFailureState{}
+		_, err := a.Prepare(context.Background(), &state, gkePrepareReq(attrs, map[string]interface{}{"zone": "europe-west1-b"}))
+		require.Error(t, err, "dropping %s should fail Prepare", drop)
+		assert.Contains(t, err.Error(), "missing")
+	}
+}
+
+func TestNodePoolZoneFailure_Prepare_RejectsInvalidConfig(t *testing.T) {
+	attrs := map[string][]string{attrNodePoolLocations: {"europe-west1-b", "europe-west1-c"}}
+	for k, v := range validNodePoolAttrs {
+		attrs[k] = v
+	}
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		want   string
+	}{
+		{name: "no zone", config: map[string]interface{}{}, want: "zone is required"},
+		{name: "zone outside node pool", config: map[string]interface{}{"zone": "europe-west1-d"}, want: "does not span zone europe-west1-d"},
+		{name: "unknown mode", config: map[string]interface{}{"zone": "europe-west1-b", "mode": "delete"}, want: "mode must be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &nodePoolZoneFailureAttack{}
+			state := NodePoolZoneFailureState{}
+			_, err := a.Prepare(context.Background(), &state, gkePrepareReq(attrs, tt.config))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestInstancesInZone(t *testing.T) {
+	all := []nodePoolInstance{
+		{migZone: "europe-west1-b", migName: "mig-b", url: "u1"},
+		{migZone: "europe-west1-b", migName: "mig-b", url: "u2"},
+		{migZone: "europe-west1-c", migName: "mig-c", url: "u3"},
+	}
+
+	byMig, count, err := instancesInZone(all, "europe-west1-b", false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, map[string][]string{"europe-west1-b/mig-b": {"u1", "u2"}}, byMig)
+
+	_, _, err = instancesInZone(all, "europe-west1-d", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no RUNNING instances")
+
+	// A zone holding the whole pool needs confirmation.
+	_, _, err = instancesInZone(all[:2], "europe-west1-b", false)
+	require.Error(t, err)
+	_, count, err = instancesInZone(all[:2], "europe-west1-b", true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestNodePoolZoneFailure_StopModeRestartsInstances(t *testing.T) {
+	migs := newFakeMigs()
+	a := newZoneFailureTestAttack(migs, &fakeZoneOperations{})
+	state := zoneFailureTestState(zoneFailureModeStop)
+
+	_, err := a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstancesByMig, migs.stopped)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstancesByMig, migs.started)
+}
+
+func TestNodePoolZoneFailure_StopModeRestartsInstancesAfterFailedStop(t *testing.T) {
+	migs := newFakeMigs()
+	a := newZoneFailureTestAttack(migs, &fakeZoneOperations{failed: map[string]string{"op-b": "instance node-2 is not running"}})
+	state := zoneFailureTestState(zoneFailureModeStop)
+	state.StopOperations = map[string]string{"europe-west1-b/mig-b": "op-b"}
+
+	_, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstancesByMig, migs.started)
+}
+
+func TestNodePoolZoneFailure_RecreateModeRestoresNothing(t *testing.T) {
+	migs := newFakeMigs()
+	a := newZoneFailureTestAttack(migs, &fakeZoneOperations{})
+	state := zoneFailureTestState(zoneFailureModeRecreate)
+
+	_, err := a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstancesByMig, migs.recreated)
+	assert.Empty(t, migs.stopped)
+
+	result, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Empty(t, migs.started)
+}
@@ -79,7 +79,7 @@ func (d *nodePoolDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDe
 		{Attribute: attrNodePoolAutoscalingEnabled, Label: discovery_kit_api.PluralLabel{One: "GKE node pool autoscaling", Other: "GKE node pool autoscaling"}},
 		{Attribute: "gcp.gke.nodepool.autoscaling.min-node-count", Label: discovery_kit_api.PluralLabel{One: "GKE node pool min node count", Other: "GKE node pool min node counts"}},
 		{Attribute: "gcp.gke.nodepool.autoscaling.max-node-count", Label: discovery_kit_api.PluralLabel{One: "GKE node pool max node count", Other: "GKE node pool max node counts"}},
-		{Attribute: "gcp.gke.nodepool.locations", Label: discovery_kit_api.PluralLabel{One: "GKE node pool location", Other: "GKE node pool locations"}},
+		{Attribute: attrNodePoolLocations, Label: discovery_kit_api.PluralLabel{One: "GKE node pool location", Other: "GKE node pool locations"}},
 		{Attribute: "gcp.gke.nodepool.max-pods-per-node", Label: discovery_kit_api.PluralLabel{One: "GKE node pool max pods per node", Other: "GKE node pool max pods per nodes"}},
 		{Attribute: "gcp.gke.nodepool.management.auto-upgrade", Label: discovery_kit_api.PluralLabel{One: "GKE node pool auto-upgrade", Other: "GKE node pool auto-upgrade"}},
 		{Attribute: "gcp.gke.nodepool.management.auto-repair", Label: discovery_kit_api.PluralLabel{One: "GKE node pool auto-repair", Other: "GKE node pool auto-repair"}},
@@ -173,7 +173,7 @@ func toNodePoolTarget(np *containerpb.NodePool, cluster *containerpb.Cluster, pr
 	if len(np.Locations) > 0 {
 		locs := append([]string(nil), np.Locations...)
 		sort.Strings(locs)
-		attributes["gcp.gke.nodepool.locations"] = locs
+		attributes[attrNodePoolLocations] = locs
 	}
 	if len(np.InstanceGroupUrls) > 0 {
 		urls := append([]string(nil), np.InstanceGroupUrls...)
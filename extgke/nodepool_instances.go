@@ -0,0 +1,269 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extgke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"google.golang.org/api/iterator"
+)
+
+type migInstancesApi interface {
+	ListManagedInstances(ctx context.Context, req *computepb.ListManagedInstancesInstanceGroupManagersRequest, opts ...gax.CallOption) *compute.ManagedInstanceIterator
+	RecreateInstances(ctx context.Context, req *computepb.RecreateInstancesInstanceGroupManagerRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	StopInstances(ctx context.Context, req *computepb.StopInstancesInstanceGroupManagerRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	StartInstances(ctx context.Context, req *computepb.StartInstancesInstanceGroupManagerRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type zoneOperationsApi interface {
+	Wait(ctx context.Context, req *computepb.WaitZoneOperationRequest, opts ...gax.CallOption) (*computepb.Operation, error)
+}
+
+func newMigInstancesApi(ctx context.Context, projectID string) (migInstancesApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := compute.NewInstanceGroupManagersRESTClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, func() { _ = c.Close() }, nil
+}
+
+func newZoneOperationsApi(ctx context.Context, projectID string) (zoneOperationsApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := compute.NewZoneOperationsRESTClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, func() { _ = c.Close() }, nil
+}
+
+// nodePoolInstance is a RUNNING VM of one of the node pool's MIGs.
+type nodePoolInstance struct {
+	migZone string
+	migName string
+	url     string
+}
+
+func (i nodePoolInstance) migKey() string {
+	return fmt.Sprintf("%s/%s", i.migZone, i.migName)
+}
+
+// resolveNodePool reads the node pool and its cluster from the target attributes.
+func resolveNodePool(request action_kit_api.PrepareActionRequestBody) (projectID, clusterName, nodePoolName, location string, err error) {
+	projectID = mustHave(request.Target.Attributes, "gcp.project.id")
+	clusterName = mustHave(request.Target.Attributes, attrClusterName)
+	nodePoolName = mustHave(request.Target.Attributes, "gcp.gke.nodepool.name")
+	location = mustHave(request.Target.Attributes, "gcp.gke.cluster.location")
+	if projectID == "" || clusterName == "" || nodePoolName == "" || location == "" {
+		return "", "", "", "", extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.gke.cluster.name, gcp.gke.nodepool.name, gcp.gke.cluster.location", nil)
+	}
+	return projectID, clusterName, nodePoolName, location, nil
+}
+
+// getNodePool fetches the node pool live, as its InstanceGroupUrls may have changed since discovery.
+func getNodePool(ctx context.Context, projectID, location, clusterName, nodePoolName string) (*containerpb.NodePool, error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get GCP access for project %s", projectID), err)
+	}
+	gke, err := container.NewClusterManagerClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to create GKE client", err)
+	}
+	defer func() { _ = gke.Close() }()
+	np, err := gke.GetNodePool(ctx, &containerpb.GetNodePoolRequest{
+		Name: fmt.Sprintf("projects/%s/locations/%s/clusters/%s/nodePools/%s", projectID, location, clusterName, nodePoolName),
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to describe GKE node pool %s/%s", clusterName, nodePoolName), err)
+	}
+	if len(np.InstanceGroupUrls) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("GKE node pool %s/%s has no underlying instance groups", clusterName, nodePoolName), nil)
+	}
+	return np, nil
+}
+
+// listRunningNodePoolInstances lists the RUNNING instances of the node pool's zonal MIGs, sorted by URL so sampling
+// with a given permutation is deterministic.
+func listRunningNodePoolInstances(ctx context.Context, migClient migInstancesApi, projectID string, instanceGroupUrls []string) ([]nodePoolInstance, error) {
+	allInstances := make([]nodePoolInstance, 0)
+	for _, igURL := range instanceGroupUrls {
+		zone, name, ok := parseZonalMIGUrl(igURL)
+		if !ok {
+			// Regional MIGs aren't supported by GKE for node pools, but skip unknown URL shapes defensively.
+			continue
+		}
+		it := migClient.ListManagedInstances(ctx, &computepb.ListManagedInstancesInstanceGroupManagersRequest{
+			Project:              projectID,
+			Zone:                 zone,
+			InstanceGroupManager: name,
+		})
+		for {
+			mi, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, extension_kit.ToError(fmt.Sprintf("Failed to list instances of MIG %s/%s", zone, name), err)
+			}
+			// Only target currently running instances; skip those already being created/deleted/repaired.
+			if mi.GetInstanceStatus() != "RUNNING" {
+				continue
+			}
+			if mi.GetInstance() == "" {
+				continue
+			}
+			allInstances = append(allInstances, nodePoolInstance{migZone: zone, migName: name, url: mi.GetInstance()})
+		}
+	}
+	sort.Slice(allInstances, func(i, j int) bool { return allInstances[i].url < allInstances[j].url })
+	return allInstances, nil
+}
+
+// sampleNodePoolInstances picks pct percent of the instances using the permutation from rng and groups them by
+// "<zone>/<migName>". It returns the number of selected instances.
+func sampleNodePoolInstances(allInstances []nodePoolInstance, pct int, confirmHigh bool, rng func(n int) []int) (map[string][]string, int, error) {
+	// Use math.Floor (not math.Ceil) so the sample never exceeds the requested
+	// percentage — math.Ceil silently amplifies the effective impact past the
+	// 50 % gate on small node pools.
+	sampleSize := int(math.Floor(float64(len(allInstances)) * float64(pct) / 100.0))
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+	if sampleSize > len(allInstances) {
+		sampleSize = len(allInstances)
+	}
+	// Small-pool guard: the >=1 clamp can still push the effective ratio above
+	// 50 % (e.g. pct=50 on 1 node → 100 %). Refuse unless confirmHighImpact was
+	// explicitly set.
+	if sampleSize*2 > len(allInstances) && !confirmHigh {
+		return nil, 0, extension_kit.ToError(fmt.Sprintf(
+			"Effective impact %d of %d node(s) exceeds 50%% (small node pool rounds up to a full node). Set 'Allow percentages above 50%%' to acknowledge.",
+			sampleSize, len(allInstances)), nil)
+	}
+	perm := rng(len(allInstances))
+	byMig := make(map[string][]string)
+	for i := 0; i < sampleSize; i++ {
+		ref := allInstances[perm[i]]
+		byMig[ref.migKey()] = append(byMig[ref.migKey()], ref.url)
+	}
+	return byMig, sampleSize, nil
+}
+
+// stopMigInstances stops the instances through their MIGs, which keeps them stopped instead of repairing them. Every
+// MIG is attempted even if one fails; each accepted stop is recorded in stopOperations under its "<zone>/<migName>"
+// key, so the caller can still start those instances again. It returns the number of instances stopped.
+func stopMigInstances(ctx context.Context, client migInstancesApi, projectID string, instancesByMig map[string][]string, stopOperations map[string]string) (int, error) {
+	var errs []error
+	stopped := 0
+	for _, key := range sortedMigKeys(instancesByMig) {
+		zone, name, _ := strings.Cut(key, "/")
+		urls := instancesByMig[key]
+		op, err := client.StopInstances(ctx, &computepb.StopInstancesInstanceGroupManagerRequest{
+			Project:              projectID,
+			Zone:                 zone,
+			InstanceGroupManager: name,
+			InstanceGroupManagersStopInstancesRequestResource: &computepb.InstanceGroupManagersStopInstancesRequest{
+				Instances: urls,
+			},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stop instances in MIG %s: %w", key, err))
+			continue
+		}
+		stopOperations[key] = ""
+		if op != nil {
+			stopOperations[key] = op.Name()
+		}
+		stopped += len(urls)
+	}
+	return stopped, errors.Join(errs...)
+}
+
+// startMigInstances starts the instances of every MIG in stopOperations again. It waits for the MIG's stop operation
+// first, as the MIG only starts instances once they reached TERMINATED. A stop that failed or could not be waited for
+// is logged, not skipped: some of its instances may have stopped anyway. Starting instances that are already running
+// is accepted, so calling it twice does no harm.
+func startMigInstances(ctx context.Context, client migInstancesApi, ops zoneOperationsApi, projectID string, instancesByMig map[string][]string, stopOperations map[string]string) (int, error) {
+	var errs []error
+	started := 0
+	for _, key := range sortedMigKeys(stopOperations) {
+		zone, name, _ := strings.Cut(key, "/")
+		if opName := stopOperations[key]; opName != "" {
+			if err := utils.WaitForZoneOperation(ctx, ops, projectID, zone, opName); err != nil {
+				log.Warn().Err(err).Msgf("Stop of instances in MIG %s did not complete cleanly; starting them anyway", key)
+			}
+		}
+		urls := instancesByMig[key]
+		_, err := client.StartInstances(ctx, &computepb.StartInstancesInstanceGroupManagerRequest{
+			Project:              projectID,
+			Zone:                 zone,
+			InstanceGroupManager: name,
+			InstanceGroupManagersStartInstancesRequestResource: &computepb.InstanceGroupManagersStartInstancesRequest{
+				Instances: urls,
+			},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("start instances in MIG %s: %w", key, err))
+			continue
+		}
+		started += len(urls)
+	}
+	return started, errors.Join(errs...)
+}
+
+// recreateMigInstances recreates the instances through their MIGs, attempting every MIG even if one fails. It returns
+// the number of instances whose recreation was accepted.
+func recreateMigInstances(ctx context.Context, client migInstancesApi, projectID string, instancesByMig map[string][]string) (int, error) {
+	var errs []error
+	recreated := 0
+	for _, key := range sortedMigKeys(instancesByMig) {
+		zone, name, _ := strings.Cut(key, "/")
+		urls := instancesByMig[key]
+		_, err := client.RecreateInstances(ctx, &computepb.RecreateInstancesInstanceGroupManagerRequest{
+			Project:              projectID,
+			Zone:                 zone,
+			InstanceGroupManager: name,
+			InstanceGroupManagersRecreateInstancesRequestResource: &computepb.InstanceGroupManagersRecreateInstancesRequest{
+				Instances: urls,
+			},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recreate instances in MIG %s: %w", key, err))
+			continue
+		}
+		recreated += len(urls)
+	}
+	return recreated, errors.Join(errs...)
+}
+
+func sortedMigKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
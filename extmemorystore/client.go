@@ -0,0 +1,133 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+
+	memorystore "cloud.google.com/go/memorystore/apiv1"
+	"cloud.google.com/go/memorystore/apiv1/memorystorepb"
+	redis "cloud.google.com/go/redis/apiv1"
+	"cloud.google.com/go/redis/apiv1/redispb"
+	"github.com/steadybit/extension-gcp/utils"
+)
+
+// redisInstancesApi is the part of CloudRedis the operation-tracking actions use. Long-running operations are handed
+// around by name, so a later Status call can pick up an operation started in Start.
+type redisInstancesApi interface {
+	GetInstance(ctx context.Context, name string) (*redispb.Instance, error)
+	FailoverInstance(ctx context.Context, req *redispb.FailoverInstanceRequest) (string, error)
+	// PollFailover reports whether the operation is done. An error with done=true is the operation's own failure;
+	// with done=false the poll itself failed.
+	PollFailover(ctx context.Context, operation string) (bool, error)
+	RescheduleMaintenance(ctx context.Context, req *redispb.RescheduleMaintenanceRequest) (string, error)
+	PollRescheduleMaintenance(ctx context.Context, operation string) (bool, error)
+	UpgradeInstance(ctx context.Context, req *redispb.UpgradeInstanceRequest) (string, error)
+	PollUpgrade(ctx context.Context, operation string) (bool, error)
+}
+
+type cloudRedisClient struct {
+	client *redis.CloudRedisClient
+}
+
+func newRedisInstancesApi(ctx context.Context, projectID string) (redisInstancesApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := redis.NewCloudRedisClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cloudRedisClient{client: c}, func() { _ = c.Close() }, nil
+}
+
+func (c *cloudRedisClient) GetInstance(ctx context.Context, name string) (*redispb.Instance, error) {
+	return c.client.GetInstance(ctx, &redispb.GetInstanceRequest{Name: name})
+}
+
+func (c *cloudRedisClient) FailoverInstance(ctx context.Context, req *redispb.FailoverInstanceRequest) (string, error) {
+	op, err := c.client.FailoverInstance(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *cloudRedisClient) PollFailover(ctx context.Context, operation string) (bool, error) {
+	op := c.client.FailoverInstanceOperation(operation)
+	_, err := op.Poll(ctx)
+	return op.Done(), err
+}
+
+func (c *cloudRedisClient) RescheduleMaintenance(ctx context.Context, req *redispb.RescheduleMaintenanceRequest) (string, error) {
+	op, err := c.client.RescheduleMaintenance(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *cloudRedisClient) PollRescheduleMaintenance(ctx context.Context, operation string) (bool, error) {
+	op := c.client.RescheduleMaintenanceOperation(operation)
+	_, err := op.Poll(ctx)
+	return op.Done(), err
+}
+
+func (c *cloudRedisClient) UpgradeInstance(ctx context.Context, req *redispb.UpgradeInstanceRequest) (string, error) {
+	op, err := c.client.UpgradeInstance(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *cloudRedisClient) PollUpgrade(ctx context.Context, operation string) (bool, error) {
+	op := c.client.UpgradeInstanceOperation(operation)
+	_, err := op.Poll(ctx)
+	return op.Done(), err
+}
+
+// valkeyInstancesApi is the part of the Memorystore (Valkey) API the Valkey actions use. Like redisInstancesApi it
+// hands operations around by name.
+type valkeyInstancesApi interface {
+	GetInstance(ctx context.Context, name string) (*memorystorepb.Instance, error)
+	UpdateInstance(ctx context.Context, req *memorystorepb.UpdateInstanceRequest) (string, error)
+	PollUpdate(ctx context.Context, operation string) (bool, error)
+}
+
+type memorystoreClient struct {
+	client *memorystore.Client
+}
+
+func newValkeyInstancesApi(ctx context.Context, projectID string) (valkeyInstancesApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := memorystore.NewClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &memorystoreClient{client: c}, func() { _ = c.Close() }, nil
+}
+
+func (c *memorystoreClient) GetInstance(ctx context.Context, name string) (*memorystorepb.Instance, error) {
+	return c.client.GetInstance(ctx, &memorystorepb.GetInstanceRequest{Name: name})
+}
+
+func (c *memorystoreClient) UpdateInstance(ctx context.Context, req *memorystorepb.UpdateInstanceRequest) (string, error) {
+	op, err := c.client.UpdateInstance(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *memorystoreClient) PollUpdate(ctx context.Context, operation string) (bool, error) {
+	op := c.client.UpdateInstanceOperation(operation)
+	_, err := op.Poll(ctx)
+	return op.Done(), err
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/memorystore/apiv1/memorystorepb"
+	"cloud.google.com/go/redis/apiv1/redispb"
+)
+
+// fakeRedisInstances serves instances from a map. Operations finish on their first poll unless pendingPolls says
+// otherwise; opErrors fails them once done.
+type fakeRedisInstances struct {
+	instances    map[string]*redispb.Instance
+	pendingPolls map[string]int
+	opErrors     map[string]error
+	pollErr      error
+	failovers    []*redispb.FailoverInstanceRequest
+	reschedules  []*redispb.RescheduleMaintenanceRequest
+	upgrades     []*redispb.UpgradeInstanceRequest
+	nextOp       int
+}
+
+func newFakeRedisInstances() *fakeRedisInstances {
+	return &fakeRedisInstances{
+		instances:    map[string]*redispb.Instance{},
+		pendingPolls: map[string]int{},
+		opErrors:     map[string]error{},
+	}
+}
+
+func (f *fakeRedisInstances) provider() func(context.Context, string) (redisInstancesApi, func(), error) {
+	return func(context.Context, string) (redisInstancesApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeRedisInstances) GetInstance(_ context.Context, name string) (*redispb.Instance, error) {
+	if inst, ok := f.instances[name]; ok {
+		return inst, nil
+	}
+	return nil, fmt.Errorf("instance %s not found", name)
+}
+
+func (f *fakeRedisInstances) FailoverInstance(_ context.Context, req *redispb.FailoverInstanceRequest) (string, error) {
+	f.failovers = append(f.failovers, req)
+	return f.newOperation(), nil
+}
+
+func (f *fakeRedisInstances) PollFailover(_ context.Context, operation string) (bool, error) {
+	return f.poll(operation)
+}
+
+func (f *fakeRedisInstances) RescheduleMaintenance(_ context.Context, req *redispb.RescheduleMaintenanceRequest) (string, error) {
+	f.reschedules = append(f.reschedules, req)
+	return f.newOperation(), nil
+}
+
+func (f *fakeRedisInstances) PollRescheduleMaintenance(_ context.Context, operation string) (bool, error) {
+	return f.poll(operation)
+}
+
+func (f *fakeRedisInstances) UpgradeInstance(_ context.Context, req *redispb.UpgradeInstanceRequest) (string, error) {
+	f.upgrades = append(f.upgrades, req)
+	return f.newOperation(), nil
+}
+
+func (f *fakeRedisInstances) PollUpgrade(_ context.Context, operation string) (bool, error) {
+	return f.poll(operation)
+}
+
+func (f *fakeRedisInstances) poll(operation string) (bool, error) {
+	if f.pollErr != nil {
+		return false, f.pollErr
+	}
+	if f.pendingPolls[operation] > 0 {
+		f.pendingPolls[operation]--
+		return false, nil
+	}
+	return true, f.opErrors[operation]
+}
+
+func (f *fakeRedisInstances) newOperation() string {
+	f.nextOp++
+	return fmt.Sprintf("operations/op-%d", f.nextOp)
+}
+
+// fakeValkeyInstances is the valkeyInstancesApi counterpart of fakeRedisInstances.
+type fakeValkeyInstances struct {
+	instances    map[string]*memorystorepb.Instance
+	pendingPolls map[string]int
+	opErrors     map[string]error
+	updates      []*memorystorepb.UpdateInstanceRequest
+	nextOp       int
+}
+
+func newFakeValkeyInstances() *fakeValkeyInstances {
+	return &fakeValkeyInstances{
+		instances:    map[string]*memorystorepb.Instance{},
+		pendingPolls: map[string]int{},
+		opErrors:     map[string]error{},
+	}
+}
+
+func (f *fakeValkeyInstances) provider() func(context.Context, string) (valkeyInstancesApi, func(), error) {
+	return func(context.Context, string) (valkeyInstancesApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeValkeyInstances) GetInstance(_ context.Context, name string) (*memorystorepb.Instance, error) {
+	if inst, ok := f.instances[name]; ok {
+		return inst, nil
+	}
+	return nil, fmt.Errorf("instance %s not found", name)
+}
+
+func (f *fakeValkeyInstances) UpdateInstance(_ context.Context, req *memorystorepb.UpdateInstanceRequest) (string, error) {
+	f.updates = append(f.updates, req)
+	f.nextOp++
+	return fmt.Sprintf("operations/op-%d", f.nextOp), nil
+}
+
+func (f *fakeValkeyInstances) PollUpdate(_ context.Context, operation string) (bool, error) {
+	if f.pendingPolls[operation] > 0 {
+		f.pendingPolls[operation]--
+		return false, nil
+	}
+	return true, f.opErrors[operation]
+}
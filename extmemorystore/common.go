@@ -5,13 +5,28 @@
 package extmemorystore
 
 const (
-	TargetIDRedisInstance = "com.steadybit.extension_gcp.memorystore.redis-instance"
-	RedisFailoverActionId = "com.steadybit.extension_gcp.memorystore.redis-instance.failover"
-	targetIcon            = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMjU2LDQ5MGMtNDQuMiwwLTg1LjktMTEuMy0xMTcuNi0zMS44LTMzLjgtMjEuOS01Mi40LTUxLjctNTIuNC04NHYtMTE4LjJoMzJ2MTE4LjJjMCwyMSwxMy40LDQxLjMsMzcuOCw1Ny4xLDI2LjUsMTcuMiw2Mi4xLDI2LjcsMTAwLjIsMjYuN3M3My43LTkuNSwxMDAuMi0yNi43YzI0LjQtMTUuOCwzNy44LTM2LjEsMzcuOC01Ny4xaDMyYzAsMzIuMy0xOC42LDYyLjEtNTIuNCw4NC0zMS42LDIwLjUtNzMuNCwzMS44LTExNy42LDMxLjhaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTI1NiwzODFjLTQ0LjIsMC04NS45LTExLjMtMTE3LjYtMzEuOC0zMy44LTIxLjktNTIuNC01MS43LTUyLjQtODR2LTEyNy4zaDMydjEyNy4zYzAsMjEsMTMuNCw0MS4zLDM3LjgsNTcuMSwyNi41LDE3LjIsNjIuMSwyNi43LDEwMC4yLDI2LjdzNzMuNy05LjUsMTAwLjItMjYuN2MyNC40LTE1LjgsMzcuOC0zNi4xLDM3LjgtNTcuMWgzMmMwLDMyLjMtMTguNiw2Mi4xLTUyLjQsODQtMzEuNiwyMC41LTczLjQsMzEuOC0xMTcuNiwzMS44WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0yNTYsNDkwdi0zMmMzOC4xLDAsNzMuNy05LjUsMTAwLjItMjYuNywyNC40LTE1LjgsMzcuOC0zNi4xLDM3LjgtNTcuMnYtMTE4LjFoMzJ2MTE4LjFjMCwzMi4zLTE4LjYsNjIuMS01Mi40LDg0LjEtMzEuNiwyMC41LTczLjQsMzEuOC0xMTcuNiwzMS44aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTI1NiwzODF2LTMyYzM4LjEsMCw3My43LTkuNSwxMDAuMi0yNi43LDI0LjQtMTUuOCwzNy44LTM2LjEsMzcuOC01Ny4ydi0xMjcuMmgzMnYxMjcuMmMwLDMyLjMtMTguNiw2Mi4xLTUyLjQsODQuMS0zMS42LDIwLjUtNzMuNCwzMS44LTExNy42LDMxLjhoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDI1My42Yy00NC4yLDAtODUuOS0xMS4zLTExNy42LTMxLjgtMzMuOC0yMS45LTUyLjQtNTEuNy01Mi40LTg0czE4LjYtNjIuMSw1Mi40LTg0YzMxLjYtMjAuNSw3My40LTMxLjgsMTE3LjYtMzEuOHM4NiwxMS4zLDExNy42LDMxLjhjMzMuOCwyMS45LDUyLjQsNTEuNyw1Mi40LDg0cy0xOC42LDYyLjEtNTIuNCw4NGMtMzEuNiwyMC41LTczLjQsMzEuOC0xMTcuNiwzMS44Wk0yNTYsNTRjLTM4LjEsMC03My43LDkuNS0xMDAuMiwyNi43LTI0LjQsMTUuOC0zNy44LDM2LjEtMzcuOCw1Ny4xczEzLjQsNDEuMywzNy44LDU3LjFjMjYuNSwxNy4yLDYyLjEsMjYuNywxMDAuMiwyNi43czczLjctOS41LDEwMC4yLTI2LjdjMjQuNC0xNS44LDM3LjgtMzYuMSwzNy44LTU3LjFzLTEzLjQtNDEuMy0zNy44LTU3LjFjLTI2LjUtMTcuMi02Mi4xLTI2LjctMTAwLjItMjYuN1oiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDI1My44di0zMmMzOC4xLDAsNzMuNy05LjUsMTAwLjItMjYuNywyNC40LTE1LjgsMzcuOC0zNi4yLDM3LjgtNTcuMnMtMTMuNC00MS40LTM3LjgtNTcuMmMtMjYuNS0xNy4yLTYyLjEtMjYuNy0xMDAuMi0yNi43di0zMmM0NC4yLDAsODYsMTEuMywxMTcuNiwzMS44LDMzLjgsMjEuOSw1Mi40LDUxLjgsNTIuNCw4NC4xcy0xOC42LDYyLjEtNTIuNCw4NC4xYy0zMS42LDIwLjUtNzMuNCwzMS44LTExNy42LDMxLjhoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KPC9zdmc+"
+	TargetIDRedisInstance    = "com.steadybit.extension_gcp.memorystore.redis-instance"
+	RedisFailoverActionId    = "com.steadybit.extension_gcp.memorystore.redis-instance.failover"
+	RedisMaintenanceActionId = "com.steadybit.extension_gcp.memorystore.redis-instance.reschedule-maintenance"
+	RedisUpgradeActionId     = "com.steadybit.extension_gcp.memorystore.redis-instance.upgrade"
+
+	TargetIDRedisCluster              = "com.steadybit.extension_gcp.memorystore.redis-cluster"
+	TargetIDValkeyInstance            = "com.steadybit.extension_gcp.memorystore.valkey-instance"
+	ValkeySimulateMaintenanceActionId = "com.steadybit.extension_gcp.memorystore.valkey-instance.simulate-maintenance"
+	targetIcon                        = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMjU2LDQ5MGMtNDQuMiwwLTg1LjktMTEuMy0xMTcuNi0zMS44LTMzLjgtMjEuOS01Mi40LTUxLjctNTIuNC04NHYtMTE4LjJoMzJ2MTE4LjJjMCwyMSwxMy40LDQxLjMsMzcuOCw1Ny4xLDI2LjUsMTcuMiw2Mi4xLDI2LjcsMTAwLjIsMjYuN3M3My43LTkuNSwxMDAuMi0yNi43YzI0LjQtMTUuOCwzNy44LTM2LjEsMzcuOC01Ny4xaDMyYzAsMzIuMy0xOC42LDYyLjEtNTIuNCw4NC0zMS42LDIwLjUtNzMuNCwzMS44LTExNy42LDMxLjhaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTI1NiwzODFjLTQ0LjIsMC04NS45LTExLjMtMTE3LjYtMzEuOC0zMy44LTIxLjktNTIuNC01MS43LTUyLjQtODR2LTEyNy4zaDMydjEyNy4zYzAsMjEsMTMuNCw0MS4zLDM3LjgsNTcuMSwyNi41LDE3LjIsNjIuMSwyNi43LDEwMC4yLDI2LjdzNzMuNy05LjUsMTAwLjItMjYuN2MyNC40LTE1LjgsMzcuOC0zNi4xLDM3LjgtNTcuMWgzMmMwLDMyLjMtMTguNiw2Mi4xLTUyLjQsODQtMzEuNiwyMC41LTczLjQsMzEuOC0xMTcuNiwzMS44WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0yNTYsNDkwdi0zMmMzOC4xLDAsNzMuNy05LjUsMTAwLjItMjYuNywyNC40LTE1LjgsMzcuOC0zNi4xLDM3LjgtNTcuMnYtMTE4LjFoMzJ2MTE4LjFjMCwzMi4zLTE4LjYsNjIuMS01Mi40LDg0LjEtMzEuNiwyMC41LTczLjQsMzEuOC0xMTcuNiwzMS44aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTI1NiwzODF2LTMyYzM4LjEsMCw3My43LTkuNSwxMDAuMi0yNi43LDI0LjQtMTUuOCwzNy44LTM2LjEsMzcuOC01Ny4ydi0xMjcuMmgzMnYxMjcuMmMwLDMyLjMtMTguNiw2Mi4xLTUyLjQsODQuMS0zMS42LDIwLjUtNzMuNCwzMS44LTExNy42LDMxLjhoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDI1My42Yy00NC4yLDAtODUuOS0xMS4zLTExNy42LTMxLjgtMzMuOC0yMS45LTUyLjQtNTEuNy01Mi40LTg0czE4LjYtNjIuMSw1Mi40LTg0YzMxLjYtMjAuNSw3My40LTMxLjgsMTE3LjYtMzEuOHM4NiwxMS4zLDExNy42LDMxLjhjMzMuOCwyMS45LDUyLjQsNTEuNyw1Mi40LDg0cy0xOC42LDYyLjEtNTIuNCw4NGMtMzEuNiwyMC41LTczLjQsMzEuOC0xMTcuNiwzMS44Wk0yNTYsNTRjLTM4LjEsMC03My43LDkuNS0xMDAuMiwyNi43LTI0LjQsMTUuOC0zNy44LDM2LjEtMzcuOCw1Ny4xczEzLjQsNDEuMywzNy44LDU3LjFjMjYuNSwxNy4yLDYyLjEsMjYuNywxMDAuMiwyNi43czczLjctOS41LDEwMC4yLTI2LjdjMjQuNC0xNS44LDM3LjgtMzYuMSwzNy44LTU3LjFzLTEzLjQtNDEuMy0zNy44LTU3LjFjLTI2LjUtMTcuMi02Mi4xLTI2LjctMTAwLjItMjYuN1oiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDI1My44di0zMmMzOC4xLDAsNzMuNy05LjUsMTAwLjItMjYuNywyNC40LTE1LjgsMzcuOC0zNi4yLDM3LjgtNTcuMnMtMTMuNC00MS40LTM3LjgtNTcuMmMtMjYuNS0xNy4yLTYyLjEtMjYuNy0xMDAuMi0yNi43di0zMmM0NC4yLDAsODYsMTEuMywxMTcuNiwzMS44LDMzLjgsMjEuOSw1Mi40LDUxLjgsNTIuNCw4NC4xcy0xOC42LDYyLjEtNTIuNCw4NC4xYy0zMS42LDIwLjUtNzMuNCwzMS44LTExNy42LDMxLjhoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KPC9zdmc+"
 
 	// Attribute names extracted per Sonar go:S1192.
 	attrTier         = "gcp.memorystore.tier"
 	attrRedisVersion = "gcp.memorystore.redis-version"
 	attrRegion       = "gcp.memorystore.region"
 	attrProjectID    = "gcp.project.id"
+	attrState        = "gcp.memorystore.state"
+	attrReplicaCount = "gcp.memorystore.replica-count"
+	attrShardCount   = "gcp.memorystore.shard-count"
+	attrNodeType     = "gcp.memorystore.node-type"
+	attrZoneMode     = "gcp.memorystore.zone-distribution-mode"
+	attrZone         = "gcp.memorystore.zone-distribution-zone"
+	attrPscEndpoints = "gcp.memorystore.psc-endpoints"
+	attrClusterID    = "gcp.memorystore.redis-cluster.id"
+	attrValkeyID     = "gcp.memorystore.valkey-instance.id"
 )
@@ -7,8 +7,8 @@ package extmemorystore
 import (
 	"context"
 	"fmt"
+	"time"
 
-	redis "cloud.google.com/go/redis/apiv1"
 	"cloud.google.com/go/redis/apiv1/redispb"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
@@ -18,36 +18,28 @@ import (
 	"github.com/steadybit/extension-kit/extutil"
 )
 
-// RedisFailoverState is enough to trigger a failover. Only STANDARD_HA tier instances support failover.
-// The attack is instantaneous and is not reversible: it exercises the same code path as a real zonal
-// outage on the primary node.
+// RedisFailoverState is enough to trigger a failover and to verify it afterwards. Only STANDARD_HA tier instances
+// support failover. The attack is not reversible: it exercises the same code path as a real zonal outage on the
+// primary node.
 type RedisFailoverState struct {
 	ProjectID          string
 	InstanceName       string // fully-qualified: projects/<p>/locations/<region>/instances/<id>
 	InstanceID         string
 	DataProtectionMode string
+	LocationBefore     string
+	OperationName      string
+	StartedAt          time.Time
 }
 
 type redisFailoverAttack struct {
-	clientProvider func(ctx context.Context, projectID string) (*redis.CloudRedisClient, func(), error)
+	clientProvider func(ctx context.Context, projectID string) (redisInstancesApi, func(), error)
 }
 
 var _ action_kit_sdk.Action[RedisFailoverState] = (*redisFailoverAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[RedisFailoverState] = (*redisFailoverAttack)(nil)
 
-func NewRedisFailoverAction() action_kit_sdk.Action[RedisFailoverState] {
-	return &redisFailoverAttack{
-		clientProvider: func(ctx context.Context, projectID string) (*redis.CloudRedisClient, func(), error) {
-			access, err := utils.GetGcpAccess(projectID)
-			if err != nil {
-				return nil, nil, err
-			}
-			c, err := redis.NewCloudRedisClient(ctx, access.ClientOptions...)
-			if err != nil {
-				return nil, nil, err
-			}
-			return c, func() { _ = c.Close() }, nil
-		},
-	}
+func NewRedisFailoverAction() action_kit_sdk.ActionWithStatus[RedisFailoverState] {
+	return &redisFailoverAttack{clientProvider: newRedisInstancesApi}
 }
 
 func (a *redisFailoverAttack) NewEmptyState() RedisFailoverState { return RedisFailoverState{} }
@@ -56,7 +48,7 @@ func (a *redisFailoverAttack) Describe() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:    RedisFailoverActionId,
 		Label: "Trigger Memorystore for Redis failover",
-		Description: "Fails over a STANDARD_HA Memorystore Redis instance's primary to its replica. Not reversible.",
+		Description: "Fails over a STANDARD_HA Memorystore Redis instance's primary to its replica, waits for the operation and verifies the primary moved to the alternative zone. Not reversible.",
 		Version: extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:    extutil.Ptr(targetIcon),
 		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
@@ -71,7 +63,7 @@ func (a *redisFailoverAttack) Describe() action_kit_api.ActionDescription {
 		}),
 		Technology:  extutil.Ptr("GCP"),
 		Category:    extutil.Ptr("Memorystore"),
-		TimeControl: action_kit_api.TimeControlInstantaneous,
+		TimeControl: action_kit_api.TimeControlInternal,
 		Kind:        action_kit_api.Attack,
 		Parameters: []action_kit_api.ActionParameter{
 			{
@@ -88,6 +80,9 @@ func (a *redisFailoverAttack) Describe() action_kit_api.ActionDescription {
 				}),
 			},
 		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
 	}
 }
 
@@ -139,8 +134,13 @@ func (a *redisFailoverAttack) Start(ctx context.Context, state *RedisFailoverSta
 		// Prepare validated this — belt & suspenders for a rehydrated / mutated state.
 		return nil, extension_kit.ToError(fmt.Sprintf("Unknown dataProtectionMode %q at Start; expected FORCE_DATA_LOSS or LIMITED_DATA_LOSS", state.DataProtectionMode), nil)
 	}
-	// FailoverInstance returns a long-running operation; we don't wait — chaos = fire-and-forget.
-	_, err = client.FailoverInstance(ctx, &redispb.FailoverInstanceRequest{
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore instance %s", state.InstanceID), err)
+	}
+	state.LocationBefore = inst.GetCurrentLocationId()
+	state.StartedAt = time.Now()
+	state.OperationName, err = client.FailoverInstance(ctx, &redispb.FailoverInstanceRequest{
 		Name:               state.InstanceName,
 		DataProtectionMode: mode,
 	})
@@ -150,11 +150,55 @@ func (a *redisFailoverAttack) Start(ctx context.Context, state *RedisFailoverSta
 	return &action_kit_api.StartResult{
 		Messages: extutil.Ptr([]action_kit_api.Message{{
 			Level:   extutil.Ptr(action_kit_api.Info),
-			Message: fmt.Sprintf("Failover triggered for Memorystore Redis instance %s", state.InstanceID),
+			Message: fmt.Sprintf("Failover triggered for Memorystore Redis instance %s (primary in %s)", state.InstanceID, state.LocationBefore),
 		}}),
 	}, nil
 }
 
+// Status waits for the failover operation, then re-reads the instance: a successful failover leaves the primary in
+// the other one of LocationId and AlternativeLocationId.
+func (a *redisFailoverAttack) Status(ctx context.Context, state *RedisFailoverState) (*action_kit_api.StatusResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollFailover(ctx, state.OperationName)
+	elapsed := time.Since(state.StartedAt).Round(time.Second)
+	if result, err := utils.OperationStatus(done, err, "Memorystore", fmt.Sprintf("Failover of Memorystore instance %s failed after %s", state.InstanceID, elapsed)); result != nil || err != nil {
+		return result, err
+	}
+
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore instance %s", state.InstanceID), err)
+	}
+	if expected := swappedLocation(inst, state.LocationBefore); inst.GetCurrentLocationId() != expected {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Failover of Memorystore instance %s completed after %s, but the primary is in %s instead of %s", state.InstanceID, elapsed, inst.GetCurrentLocationId(), expected),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Memorystore Redis instance %s failed over from %s to %s in %s", state.InstanceID, state.LocationBefore, inst.GetCurrentLocationId(), elapsed),
+		}}),
+	}, nil
+}
+
+// swappedLocation is the zone the primary is expected in after a failover away from before.
+func swappedLocation(inst *redispb.Instance, before string) string {
+	if before == inst.GetLocationId() {
+		return inst.GetAlternativeLocationId()
+	}
+	return inst.GetLocationId()
+}
+
 func mustHaveAttr(attrs map[string][]string, key string) string {
 	v, ok := attrs[key]
 	if !ok || len(v) == 0 {
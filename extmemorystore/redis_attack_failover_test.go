@@ -6,8 +6,10 @@ package extmemorystore
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"cloud.google.com/go/redis/apiv1/redispb"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/extension-kit/extutil"
 	"github.com/stretchr/testify/assert"
@@ -99,6 +101,84 @@ func TestRedisFailover_Prepare_UnknownDataProtectionMode(t *testing.T) {
 	assert.Contains(t, err.Error(), "Unknown dataProtectionMode")
 }
 
+const redisInstanceName = "projects/proj-a/locations/europe-west1/instances/cache-01"
+
+func haInstance(current string) *redispb.Instance {
+	return &redispb.Instance{
+		Name:                  redisInstanceName,
+		LocationId:            "europe-west1-b",
+		AlternativeLocationId: "europe-west1-c",
+		CurrentLocationId:     current,
+	}
+}
+
+func startedRedisFailover(t *testing.T, api *fakeRedisInstances) (*redisFailoverAttack, RedisFailoverState) {
+	a := &redisFailoverAttack{clientProvider: api.provider()}
+	state := RedisFailoverState{ProjectID: "proj-a", InstanceID: "cache-01", InstanceName: redisInstanceName, DataProtectionMode: "LIMITED_DATA_LOSS"}
+	_, err := a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	return a, state
+}
+
+func TestRedisFailover_WaitsForOperationAndVerifiesSwap(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = haInstance("europe-west1-b")
+	a, state := startedRedisFailover(t, api)
+	require.Len(t, api.failovers, 1)
+	assert.Equal(t, redispb.FailoverInstanceRequest_LIMITED_DATA_LOSS, api.failovers[0].DataProtectionMode)
+	assert.Equal(t, "europe-west1-b", state.LocationBefore)
+
+	api.pendingPolls[state.OperationName] = 1
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	api.instances[redisInstanceName] = haInstance("europe-west1-c")
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.Nil(t, status.Error)
+	assert.Contains(t, (*status.Messages)[0].Message, "from europe-west1-b to europe-west1-c")
+}
+
+func TestRedisFailover_FailsWhenPrimaryDidNotMove(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = haInstance("europe-west1-b")
+	a, state := startedRedisFailover(t, api)
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Contains(t, status.Error.Title, "primary is in europe-west1-b instead of europe-west1-c")
+}
+
+func TestRedisFailover_ReportsOperationError(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = haInstance("europe-west1-c")
+	a, state := startedRedisFailover(t, api)
+	api.opErrors[state.OperationName] = errors.New("replica not in sync")
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, "replica not in sync", *status.Error.Detail)
+}
+
+func TestRedisFailover_PollErrorIsNotAFailedFailover(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = haInstance("europe-west1-b")
+	a, state := startedRedisFailover(t, api)
+	api.pollErr = errors.New("unavailable")
+
+	_, err := a.Status(context.Background(), &state)
+
+	require.Error(t, err)
+}
+
 func TestRedisFailover_Describe(t *testing.T) {
 	a := &redisFailoverAttack{}
 	desc := a.Describe()
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/redis/apiv1/redispb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// RedisMaintenanceState tracks the maintenance pulled forward to now. The attack is not reversible: maintenance
+// cannot be undone once it ran, but it leaves the instance on its current configuration.
+type RedisMaintenanceState struct {
+	ProjectID     string
+	InstanceName  string
+	InstanceID    string
+	OperationName string
+	StartedAt     time.Time
+}
+
+type redisMaintenanceAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (redisInstancesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[RedisMaintenanceState] = (*redisMaintenanceAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[RedisMaintenanceState] = (*redisMaintenanceAttack)(nil)
+
+func NewRedisMaintenanceAction() action_kit_sdk.ActionWithStatus[RedisMaintenanceState] {
+	return &redisMaintenanceAttack{clientProvider: newRedisInstancesApi}
+}
+
+func (a *redisMaintenanceAttack) NewEmptyState() RedisMaintenanceState {
+	return RedisMaintenanceState{}
+}
+
+func (a *redisMaintenanceAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          RedisMaintenanceActionId,
+		Label:       "Run Memorystore for Redis maintenance now",
+		Description: "Reschedules the pending maintenance of a Memorystore Redis instance to IMMEDIATE and waits for the operation and for the instance to leave MAINTENANCE. Clients see the same connection drops as during regular maintenance. Not reversible.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDRedisInstance,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by instance ID",
+					Description: extutil.Ptr("Find Memorystore Redis instance by ID"),
+					Query:       "gcp.memorystore.instance.id=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Memorystore"),
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Attack,
+		Parameters:  []action_kit_api.ActionParameter{allowBasicTierParameter(1)},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("10s"),
+		}),
+	}
+}
+
+// allowBasicTierParameter is the explicit opt-in for attacks that make a BASIC instance unavailable.
+func allowBasicTierParameter(order int) action_kit_api.ActionParameter {
+	return action_kit_api.ActionParameter{
+		Name:         "allowBasicTier",
+		Label:        "Allow BASIC tier",
+		Description:  extutil.Ptr("BASIC instances have no replica: they are unavailable during the operation and lose their cached data. They are refused unless this is set."),
+		Type:         action_kit_api.ActionParameterTypeBoolean,
+		DefaultValue: extutil.Ptr("false"),
+		Order:        extutil.Ptr(order),
+		Advanced:     extutil.Ptr(true),
+	}
+}
+
+// resolveRedisTarget reads the instance from the target attributes and refuses BASIC instances unless the
+// experiment opted in.
+func resolveRedisTarget(request action_kit_api.PrepareActionRequestBody) (projectID, instanceID, instanceName string, err error) {
+	projectID = mustHaveAttr(request.Target.Attributes, attrProjectID)
+	instanceID = mustHaveAttr(request.Target.Attributes, "gcp.memorystore.instance.id")
+	region := mustHaveAttr(request.Target.Attributes, attrRegion)
+	if projectID == "" || instanceID == "" || region == "" {
+		return "", "", "", extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.memorystore.instance.id, gcp.memorystore.region", nil)
+	}
+	tier := mustHaveAttr(request.Target.Attributes, attrTier)
+	if tier != redispb.Instance_STANDARD_HA.String() && !extutil.ToBool(request.Config["allowBasicTier"]) {
+		return "", "", "", extension_kit.ToError(fmt.Sprintf("Memorystore instance %s is %s, not STANDARD_HA; enable 'Allow BASIC tier' to run this attack anyway", instanceID, tier), nil)
+	}
+	return projectID, instanceID, fmt.Sprintf("projects/%s/locations/%s/instances/%s", projectID, region, instanceID), nil
+}
+
+func (a *redisMaintenanceAttack) Prepare(ctx context.Context, state *RedisMaintenanceState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.InstanceID, state.InstanceName, err = resolveRedisTarget(request)
+	if err != nil {
+		return nil, err
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore instance %s", state.InstanceID), err)
+	}
+	// Only maintenance Google already scheduled can be pulled forward.
+	if inst.GetMaintenanceSchedule() == nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Memorystore instance %s has no scheduled maintenance to run now", state.InstanceID), nil)
+	}
+	return nil, nil
+}
+
+func (a *redisMaintenanceAttack) Start(ctx context.Context, state *RedisMaintenanceState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	state.StartedAt = time.Now()
+	state.OperationName, err = client.RescheduleMaintenance(ctx, &redispb.RescheduleMaintenanceRequest{
+		Name:           state.InstanceName,
+		RescheduleType: redispb.RescheduleMaintenanceRequest_IMMEDIATE,
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to reschedule maintenance of Memorystore instance %s", state.InstanceID), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Maintenance of Memorystore Redis instance %s rescheduled to now", state.InstanceID),
+		}}),
+	}, nil
+}
+
+func (a *redisMaintenanceAttack) Status(ctx context.Context, state *RedisMaintenanceState) (*action_kit_api.StatusResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollRescheduleMaintenance(ctx, state.OperationName)
+	elapsed := time.Since(state.StartedAt).Round(time.Second)
+	if result, err := utils.OperationStatus(done, err, "Memorystore", fmt.Sprintf("Maintenance of Memorystore instance %s failed after %s", state.InstanceID, elapsed)); result != nil || err != nil {
+		return result, err
+	}
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore instance %s", state.InstanceID), err)
+	}
+	if inst.GetState() == redispb.Instance_MAINTENANCE {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Maintenance of Memorystore Redis instance %s finished after %s (state %s)", state.InstanceID, elapsed, inst.GetState()),
+		}}),
+	}, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/redis/apiv1/redispb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attrsWithTier(tier string) map[string][]string {
+	attrs := map[string][]string{}
+	for k, v := range validRedisAttrs {
+		attrs[k] = v
+	}
+	attrs[attrTier] = []string{tier}
+	return attrs
+}
+
+func TestRedisMaintenance_RunsScheduledMaintenanceNow(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = &redispb.Instance{MaintenanceSchedule: &redispb.MaintenanceSchedule{}}
+	a := &redisMaintenanceAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.reschedules, 1)
+	assert.Equal(t, redispb.RescheduleMaintenanceRequest_IMMEDIATE, api.reschedules[0].RescheduleType)
+
+	api.instances[redisInstanceName].State = redispb.Instance_MAINTENANCE
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	api.instances[redisInstanceName].State = redispb.Instance_READY
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.Nil(t, status.Error)
+}
+
+func TestRedisMaintenance_Prepare_RequiresScheduledMaintenance(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = &redispb.Instance{}
+	a := &redisMaintenanceAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no scheduled maintenance")
+}
+
+func TestResolveRedisTarget_BasicTierNeedsConfirmation(t *testing.T) {
+	_, _, _, err := resolveRedisTarget(redisReq(attrsWithTier("BASIC"), map[string]interface{}{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Allow BASIC tier")
+
+	_, _, name, err := resolveRedisTarget(redisReq(attrsWithTier("BASIC"), map[string]interface{}{"allowBasicTier": true}))
+	require.NoError(t, err)
+	assert.Equal(t, redisInstanceName, name)
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/redis/apiv1/redispb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// redisVersionOptions lists the versions an instance can be upgraded to, newest first. Prepare compares versions by
+// their position in this list.
+var redisVersionOptions = []action_kit_api.ParameterOption{
+	action_kit_api.ExplicitParameterOption{Label: "Redis 7.2", Value: "REDIS_7_2"},
+	action_kit_api.ExplicitParameterOption{Label: "Redis 7.0", Value: "REDIS_7_0"},
+	action_kit_api.ExplicitParameterOption{Label: "Redis 6.x", Value: "REDIS_6_X"},
+	action_kit_api.ExplicitParameterOption{Label: "Redis 5.0", Value: "REDIS_5_0"},
+	action_kit_api.ExplicitParameterOption{Label: "Redis 4.0", Value: "REDIS_4_0"},
+	action_kit_api.ExplicitParameterOption{Label: "Redis 3.2", Value: "REDIS_3_2"},
+}
+
+// RedisUpgradeState tracks an in-place version upgrade. The attack is not reversible: Memorystore does not support
+// downgrading an instance.
+type RedisUpgradeState struct {
+	ProjectID     string
+	InstanceName  string
+	InstanceID    string
+	VersionBefore string
+	TargetVersion string
+	OperationName string
+	StartedAt     time.Time
+}
+
+type redisUpgradeAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (redisInstancesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[RedisUpgradeState] = (*redisUpgradeAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[RedisUpgradeState] = (*redisUpgradeAttack)(nil)
+
+func NewRedisUpgradeAction() action_kit_sdk.ActionWithStatus[RedisUpgradeState] {
+	return &redisUpgradeAttack{clientProvider: newRedisInstancesApi}
+}
+
+func (a *redisUpgradeAttack) NewEmptyState() RedisUpgradeState { return RedisUpgradeState{} }
+
+func (a *redisUpgradeAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          RedisUpgradeActionId,
+		Label:       "Upgrade Memorystore for Redis version",
+		Description: "Upgrades a Memorystore Redis instance to the chosen Redis version and waits until the upgrade finished. Clients see the connection drops of a maintenance. Not reversible.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDRedisInstance,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by instance ID",
+					Description: extutil.Ptr("Find Memorystore Redis instance by ID"),
+					Query:       "gcp.memorystore.instance.id=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Memorystore"),
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:        "redisVersion",
+				Label:       "Redis version",
+				Description: extutil.Ptr("Version to upgrade to. Must be newer than the instance's current version."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       extutil.Ptr(1),
+				Required:    extutil.Ptr(true),
+				Options:     extutil.Ptr(redisVersionOptions),
+			},
+			allowBasicTierParameter(2),
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("10s"),
+		}),
+	}
+}
+
+func (a *redisUpgradeAttack) Prepare(ctx context.Context, state *RedisUpgradeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.InstanceID, state.InstanceName, err = resolveRedisTarget(request)
+	if err != nil {
+		return nil, err
+	}
+	state.TargetVersion = extutil.ToString(request.Config["redisVersion"])
+	if state.TargetVersion == "" {
+		return nil, extension_kit.ToError("Parameter redisVersion is required", nil)
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore instance %s", state.InstanceID), err)
+	}
+	state.VersionBefore = inst.GetRedisVersion()
+	if state.VersionBefore == state.TargetVersion {
+		return nil, extension_kit.ToError(fmt.Sprintf("Memorystore instance %s already runs %s", state.InstanceID, state.TargetVersion), nil)
+	}
+	target, current := redisVersionRank(state.TargetVersion), redisVersionRank(state.VersionBefore)
+	if target < 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported Redis version %s", state.TargetVersion), nil)
+	}
+	if current < 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Memorystore instance %s runs %s, which cannot be compared with %s", state.InstanceID, state.VersionBefore, state.TargetVersion), nil)
+	}
+	if target > current {
+		return nil, extension_kit.ToError(fmt.Sprintf("Memorystore instance %s runs %s; %s is older and Memorystore cannot downgrade", state.InstanceID, state.VersionBefore, state.TargetVersion), nil)
+	}
+	return nil, nil
+}
+
+// redisVersionRank is the position of version in redisVersionOptions, so a lower rank is a newer version. It is -1 for
+// versions not in the list.
+func redisVersionRank(version string) int {
+	return slices.IndexFunc(redisVersionOptions, func(option action_kit_api.ParameterOption) bool {
+		explicit, ok := option.(action_kit_api.ExplicitParameterOption)
+		return ok && explicit.Value == version
+	})
+}
+
+func (a *redisUpgradeAttack) Start(ctx context.Context, state *RedisUpgradeState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	state.StartedAt = time.Now()
+	state.OperationName, err = client.UpgradeInstance(ctx, &redispb.UpgradeInstanceRequest{
+		Name:         state.InstanceName,
+		RedisVersion: state.TargetVersion,
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to upgrade Memorystore instance %s to %s", state.InstanceID, state.TargetVersion), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Upgrade of Memorystore Redis instance %s from %s to %s requested", state.InstanceID, state.VersionBefore, state.TargetVersion),
+		}}),
+	}, nil
+}
+
+func (a *redisUpgradeAttack) Status(ctx context.Context, state *RedisUpgradeState) (*action_kit_api.StatusResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize CloudRedis client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollUpgrade(ctx, state.OperationName)
+	elapsed := time.Since(state.StartedAt).Round(time.Second)
+	if result, err := utils.OperationStatus(done, err, "Memorystore", fmt.Sprintf("Upgrade of Memorystore instance %s to %s failed after %s", state.InstanceID, state.TargetVersion, elapsed)); result != nil || err != nil {
+		return result, err
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Memorystore Redis instance %s upgraded from %s to %s in %s", state.InstanceID, state.VersionBefore, state.TargetVersion, elapsed),
+		}}),
+	}, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/redis/apiv1/redispb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisUpgrade_UpgradesAndWaitsForOperation(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = &redispb.Instance{RedisVersion: "REDIS_6_X"}
+	a := &redisUpgradeAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{"redisVersion": "REDIS_7_2"}))
+	require.NoError(t, err)
+	assert.Equal(t, "REDIS_6_X", state.VersionBefore)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.upgrades, 1)
+	assert.Equal(t, "REDIS_7_2", api.upgrades[0].RedisVersion)
+
+	api.pendingPolls[state.OperationName] = 1
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.Contains(t, (*status.Messages)[0].Message, "from REDIS_6_X to REDIS_7_2")
+}
+
+func TestRedisUpgrade_ReportsOperationError(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.opErrors["operations/op-1"] = errors.New("downgrade not supported")
+	a := &redisUpgradeAttack{clientProvider: api.provider()}
+	state := RedisUpgradeState{ProjectID: "proj-a", InstanceID: "cache-01", InstanceName: redisInstanceName, TargetVersion: "REDIS_5_0", OperationName: "operations/op-1"}
+
+	status, err := a.Status(context.Background(), &state)
+
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Equal(t, "downgrade not supported", *status.Error.Detail)
+}
+
+func TestRedisUpgrade_Prepare_RejectsCurrentVersion(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = &redispb.Instance{RedisVersion: "REDIS_7_2"}
+	a := &redisUpgradeAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{"redisVersion": "REDIS_7_2"}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already runs REDIS_7_2")
+}
+
+func TestRedisUpgrade_Prepare_RejectsOlderVersion(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = &redispb.Instance{RedisVersion: "REDIS_7_0"}
+	a := &redisUpgradeAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{"redisVersion": "REDIS_6_X"}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "REDIS_6_X is older")
+}
+
+func TestRedisUpgrade_Prepare_RejectsUnknownVersions(t *testing.T) {
+	api := newFakeRedisInstances()
+	api.instances[redisInstanceName] = &redispb.Instance{RedisVersion: "REDIS_6_X"}
+	a := &redisUpgradeAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{"redisVersion": "REDIS_99"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported Redis version")
+
+	api.instances[redisInstanceName] = &redispb.Instance{RedisVersion: "REDIS_99"}
+	_, err = a.Prepare(context.Background(), &state, redisReq(validRedisAttrs, map[string]interface{}{"redisVersion": "REDIS_7_2"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be compared")
+}
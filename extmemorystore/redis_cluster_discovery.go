@@ -0,0 +1,183 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cluster "cloud.google.com/go/redis/cluster/apiv1"
+	"cloud.google.com/go/redis/cluster/apiv1/clusterpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+type redisClusterDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*redisClusterDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*redisClusterDiscovery)(nil)
+)
+
+func NewRedisClusterDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&redisClusterDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *redisClusterDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDRedisCluster,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *redisClusterDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDRedisCluster,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Memorystore for Redis Cluster", Other: "Memorystore for Redis Clusters"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrShardCount},
+				{Attribute: attrReplicaCount},
+				{Attribute: attrNodeType},
+				{Attribute: attrRegion},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *redisClusterDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrClusterID, Label: discovery_kit_api.PluralLabel{One: "Memorystore Redis Cluster ID", Other: "Memorystore Redis Cluster IDs"}},
+		{Attribute: attrShardCount, Label: discovery_kit_api.PluralLabel{One: "Memorystore shard count", Other: "Memorystore shard counts"}},
+		{Attribute: attrNodeType, Label: discovery_kit_api.PluralLabel{One: "Memorystore node type", Other: "Memorystore node types"}},
+		{Attribute: attrZoneMode, Label: discovery_kit_api.PluralLabel{One: "Memorystore zone distribution mode", Other: "Memorystore zone distribution modes"}},
+		{Attribute: attrZone, Label: discovery_kit_api.PluralLabel{One: "Memorystore zone", Other: "Memorystore zones"}},
+		{Attribute: attrPscEndpoints, Label: discovery_kit_api.PluralLabel{One: "Memorystore PSC endpoint", Other: "Memorystore PSC endpoints"}},
+		{Attribute: "gcp.memorystore.discovery-endpoint", Label: discovery_kit_api.PluralLabel{One: "Memorystore discovery endpoint", Other: "Memorystore discovery endpoints"}},
+		{Attribute: "gcp.memorystore.size-gb", Label: discovery_kit_api.PluralLabel{One: "Memorystore size (GiB)", Other: "Memorystore sizes (GiB)"}},
+		{Attribute: "gcp.memorystore.authorization-mode", Label: discovery_kit_api.PluralLabel{One: "Memorystore authorization mode", Other: "Memorystore authorization modes"}},
+	}
+}
+
+func (d *redisClusterDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		client, err := cluster.NewCloudRedisClusterClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CloudRedisCluster client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = client.Close() }()
+		return getAllRedisClusters(ctx, client, access.ProjectID)
+	}, ctx, "memorystore-redis-cluster")
+}
+
+func getAllRedisClusters(ctx context.Context, client *cluster.CloudRedisClusterClient, projectID string) ([]discovery_kit_api.Target, error) {
+	targets := make([]discovery_kit_api.Target, 0)
+	it := client.ListClusters(ctx, &clusterpb.ListClustersRequest{Parent: fmt.Sprintf("projects/%s/locations/-", projectID)})
+	for {
+		c, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).Msg("Failed to list Memorystore Redis clusters")
+			return nil, err
+		}
+		targets = append(targets, toRedisClusterTarget(c, projectID))
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesMemorystoreRedisCluster), nil
+}
+
+// splitResourceName returns region and ID of a projects/<p>/locations/<region>/<collection>/<id> name. Unknown
+// layouts yield no region and the full name as ID.
+func splitResourceName(name string) (region, id string) {
+	if parts := strings.Split(name, "/"); len(parts) >= 6 {
+		return parts[3], parts[5]
+	}
+	return "", name
+}
+
+func toRedisClusterTarget(c *clusterpb.Cluster, projectID string) discovery_kit_api.Target {
+	region, clusterID := splitResourceName(c.Name)
+
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrClusterID] = []string{clusterID}
+	if region != "" {
+		attributes[attrRegion] = []string{region}
+	}
+	if c.State != clusterpb.Cluster_STATE_UNSPECIFIED {
+		attributes[attrState] = []string{c.State.String()}
+	}
+	if c.ShardCount != nil {
+		attributes[attrShardCount] = []string{strconv.Itoa(int(c.GetShardCount()))}
+	}
+	if c.ReplicaCount != nil {
+		attributes[attrReplicaCount] = []string{strconv.Itoa(int(c.GetReplicaCount()))}
+	}
+	if c.NodeType != clusterpb.NodeType_NODE_TYPE_UNSPECIFIED {
+		attributes[attrNodeType] = []string{c.NodeType.String()}
+	}
+	if c.SizeGb != nil {
+		attributes["gcp.memorystore.size-gb"] = []string{strconv.Itoa(int(c.GetSizeGb()))}
+	}
+	if c.AuthorizationMode != clusterpb.AuthorizationMode_AUTH_MODE_UNSPECIFIED {
+		attributes["gcp.memorystore.authorization-mode"] = []string{c.AuthorizationMode.String()}
+	}
+	if c.TransitEncryptionMode != clusterpb.TransitEncryptionMode_TRANSIT_ENCRYPTION_MODE_UNSPECIFIED {
+		attributes["gcp.memorystore.transit-encryption-mode"] = []string{c.TransitEncryptionMode.String()}
+	}
+	if zd := c.GetZoneDistributionConfig(); zd != nil {
+		if zd.Mode != clusterpb.ZoneDistributionConfig_ZONE_DISTRIBUTION_MODE_UNSPECIFIED {
+			attributes[attrZoneMode] = []string{zd.Mode.String()}
+		}
+		if zd.Zone != "" {
+			attributes[attrZone] = []string{zd.Zone}
+		}
+	}
+	var psc []string
+	for _, conn := range c.GetPscConnections() {
+		if conn.GetAddress() != "" {
+			psc = append(psc, conn.GetAddress())
+		}
+	}
+	if len(psc) > 0 {
+		attributes[attrPscEndpoints] = psc
+	}
+	var endpoints []string
+	for _, e := range c.GetDiscoveryEndpoints() {
+		if e.GetAddress() != "" {
+			endpoints = append(endpoints, fmt.Sprintf("%s:%d", e.GetAddress(), e.GetPort()))
+		}
+	}
+	if len(endpoints) > 0 {
+		attributes["gcp.memorystore.discovery-endpoint"] = endpoints
+	}
+
+	return discovery_kit_api.Target{
+		Id:         c.Name,
+		TargetType: TargetIDRedisCluster,
+		Label:      clusterID,
+		Attributes: attributes,
+	}
+}
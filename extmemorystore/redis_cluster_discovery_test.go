@@ -0,0 +1,73 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"testing"
+
+	"cloud.google.com/go/redis/cluster/apiv1/clusterpb"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToRedisClusterTarget_Populated(t *testing.T) {
+	c := &clusterpb.Cluster{
+		Name:                  "projects/proj-a/locations/europe-west1/clusters/sessions",
+		State:                 clusterpb.Cluster_ACTIVE,
+		ShardCount:            extutil.Ptr(int32(3)),
+		ReplicaCount:          extutil.Ptr(int32(1)),
+		SizeGb:                extutil.Ptr(int32(39)),
+		NodeType:              clusterpb.NodeType_REDIS_HIGHMEM_MEDIUM,
+		AuthorizationMode:     clusterpb.AuthorizationMode_AUTH_MODE_IAM_AUTH,
+		TransitEncryptionMode: clusterpb.TransitEncryptionMode_TRANSIT_ENCRYPTION_MODE_SERVER_AUTHENTICATION,
+		ZoneDistributionConfig: &clusterpb.ZoneDistributionConfig{
+			Mode: clusterpb.ZoneDistributionConfig_MULTI_ZONE,
+		},
+		PscConnections: []*clusterpb.PscConnection{
+			{PscConnectionId: "psc-1", Address: "10.0.0.5"},
+			{PscConnectionId: "psc-2", Address: "10.0.0.6"},
+		},
+		DiscoveryEndpoints: []*clusterpb.DiscoveryEndpoint{{Address: "10.0.0.5", Port: 6379}},
+	}
+
+	target := toRedisClusterTarget(c, "proj-a")
+
+	assert.Equal(t, TargetIDRedisCluster, target.TargetType)
+	assert.Equal(t, "sessions", target.Label)
+	assert.Equal(t, c.Name, target.Id)
+	assert.Equal(t, []string{"proj-a"}, target.Attributes[attrProjectID])
+	assert.Equal(t, []string{"sessions"}, target.Attributes[attrClusterID])
+	assert.Equal(t, []string{"europe-west1"}, target.Attributes[attrRegion])
+	assert.Equal(t, []string{"ACTIVE"}, target.Attributes[attrState])
+	assert.Equal(t, []string{"3"}, target.Attributes[attrShardCount])
+	assert.Equal(t, []string{"1"}, target.Attributes[attrReplicaCount])
+	assert.Equal(t, []string{"39"}, target.Attributes["gcp.memorystore.size-gb"])
+	assert.Equal(t, []string{"REDIS_HIGHMEM_MEDIUM"}, target.Attributes[attrNodeType])
+	assert.Equal(t, []string{"AUTH_MODE_IAM_AUTH"}, target.Attributes["gcp.memorystore.authorization-mode"])
+	assert.Equal(t, []string{"TRANSIT_ENCRYPTION_MODE_SERVER_AUTHENTICATION"}, target.Attributes["gcp.memorystore.transit-encryption-mode"])
+	assert.Equal(t, []string{"MULTI_ZONE"}, target.Attributes[attrZoneMode])
+	assert.NotContains(t, target.Attributes, attrZone)
+	assert.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, target.Attributes[attrPscEndpoints])
+	assert.Equal(t, []string{"10.0.0.5:6379"}, target.Attributes["gcp.memorystore.discovery-endpoint"])
+}
+
+func TestToRedisClusterTarget_ZeroReplicasStillReported(t *testing.T) {
+	c := &clusterpb.Cluster{
+		Name:         "projects/proj-a/locations/us-central1/clusters/cache",
+		ReplicaCount: extutil.Ptr(int32(0)),
+		ZoneDistributionConfig: &clusterpb.ZoneDistributionConfig{
+			Mode: clusterpb.ZoneDistributionConfig_SINGLE_ZONE,
+			Zone: "us-central1-b",
+		},
+	}
+
+	target := toRedisClusterTarget(c, "proj-a")
+
+	assert.Equal(t, []string{"0"}, target.Attributes[attrReplicaCount])
+	assert.Equal(t, []string{"SINGLE_ZONE"}, target.Attributes[attrZoneMode])
+	assert.Equal(t, []string{"us-central1-b"}, target.Attributes[attrZone])
+	assert.NotContains(t, target.Attributes, attrShardCount)
+	assert.NotContains(t, target.Attributes, attrPscEndpoints)
+}
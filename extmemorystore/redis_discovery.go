@@ -74,12 +74,12 @@ func (d *redisDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescr
 		{Attribute: "gcp.memorystore.location-id", Label: discovery_kit_api.PluralLabel{One: "Memorystore location", Other: "Memorystore locations"}},
 		{Attribute: "gcp.memorystore.alternative-location-id", Label: discovery_kit_api.PluralLabel{One: "Memorystore alternative location", Other: "Memorystore alternative locations"}},
 		{Attribute: "gcp.memorystore.memory-size-gb", Label: discovery_kit_api.PluralLabel{One: "Memorystore memory size (GiB)", Other: "Memorystore memory sizes (GiB)"}},
-		{Attribute: "gcp.memorystore.state", Label: discovery_kit_api.PluralLabel{One: "Memorystore state", Other: "Memorystore states"}},
+		{Attribute: attrState, Label: discovery_kit_api.PluralLabel{One: "Memorystore state", Other: "Memorystore states"}},
 		{Attribute: "gcp.memorystore.connect-mode", Label: discovery_kit_api.PluralLabel{One: "Memorystore connect mode", Other: "Memorystore connect modes"}},
 		{Attribute: "gcp.memorystore.auth-enabled", Label: discovery_kit_api.PluralLabel{One: "Memorystore AUTH enabled", Other: "Memorystore AUTH enabled"}},
 		{Attribute: "gcp.memorystore.transit-encryption-mode", Label: discovery_kit_api.PluralLabel{One: "Memorystore transit encryption", Other: "Memorystore transit encryption modes"}},
 		{Attribute: "gcp.memorystore.read-replicas-mode", Label: discovery_kit_api.PluralLabel{One: "Memorystore read replicas mode", Other: "Memorystore read replicas modes"}},
-		{Attribute: "gcp.memorystore.replica-count", Label: discovery_kit_api.PluralLabel{One: "Memorystore replica count", Other: "Memorystore replica counts"}},
+		{Attribute: attrReplicaCount, Label: discovery_kit_api.PluralLabel{One: "Memorystore replica count", Other: "Memorystore replica counts"}},
 		{Attribute: "gcp.memorystore.persistence-mode", Label: discovery_kit_api.PluralLabel{One: "Memorystore persistence mode", Other: "Memorystore persistence modes"}},
 		{Attribute: "gcp.memorystore.authorized-network", Label: discovery_kit_api.PluralLabel{One: "Memorystore authorized network", Other: "Memorystore authorized networks"}},
 	}
@@ -145,7 +145,7 @@ func toRedisTarget(inst *redispb.Instance, projectID string) discovery_kit_api.T
 		attributes["gcp.memorystore.memory-size-gb"] = []string{strconv.Itoa(int(inst.MemorySizeGb))}
 	}
 	if inst.State != redispb.Instance_STATE_UNSPECIFIED {
-		attributes["gcp.memorystore.state"] = []string{inst.State.String()}
+		attributes[attrState] = []string{inst.State.String()}
 	}
 	if inst.ConnectMode != redispb.Instance_CONNECT_MODE_UNSPECIFIED {
 		attributes["gcp.memorystore.connect-mode"] = []string{inst.ConnectMode.String()}
@@ -158,7 +158,7 @@ func toRedisTarget(inst *redispb.Instance, projectID string) discovery_kit_api.T
 		attributes["gcp.memorystore.read-replicas-mode"] = []string{inst.ReadReplicasMode.String()}
 	}
 	if inst.ReplicaCount > 0 {
-		attributes["gcp.memorystore.replica-count"] = []string{strconv.Itoa(int(inst.ReplicaCount))}
+		attributes[attrReplicaCount] = []string{strconv.Itoa(int(inst.ReplicaCount))}
 	}
 	if inst.PersistenceConfig != nil && inst.PersistenceConfig.PersistenceMode != redispb.PersistenceConfig_PERSISTENCE_MODE_UNSPECIFIED {
 		attributes["gcp.memorystore.persistence-mode"] = []string{inst.PersistenceConfig.PersistenceMode.String()}
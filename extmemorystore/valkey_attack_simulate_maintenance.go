@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/memorystore/apiv1/memorystorepb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ValkeySimulateMaintenanceState tracks a simulated maintenance event. The attack is not reversible, but the instance
+// keeps its configuration: every node is restarted the way a maintenance rollout would.
+type ValkeySimulateMaintenanceState struct {
+	ProjectID     string
+	InstanceName  string
+	InstanceID    string
+	OperationName string
+	StartedAt     time.Time
+}
+
+type valkeySimulateMaintenanceAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (valkeyInstancesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[ValkeySimulateMaintenanceState] = (*valkeySimulateMaintenanceAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[ValkeySimulateMaintenanceState] = (*valkeySimulateMaintenanceAttack)(nil)
+
+func NewValkeySimulateMaintenanceAction() action_kit_sdk.ActionWithStatus[ValkeySimulateMaintenanceState] {
+	return &valkeySimulateMaintenanceAttack{clientProvider: newValkeyInstancesApi}
+}
+
+func (a *valkeySimulateMaintenanceAttack) NewEmptyState() ValkeySimulateMaintenanceState {
+	return ValkeySimulateMaintenanceState{}
+}
+
+func (a *valkeySimulateMaintenanceAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ValkeySimulateMaintenanceActionId,
+		Label:       "Simulate Memorystore for Valkey maintenance",
+		Description: "Triggers a simulated maintenance event on a Memorystore Valkey instance, restarting its nodes shard by shard with failover to the replicas, and waits until the instance is ACTIVE again. Not reversible.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDValkeyInstance,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by instance ID",
+					Description: extutil.Ptr("Find Memorystore Valkey instance by ID"),
+					Query:       attrValkeyID + "=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Memorystore"),
+		TimeControl: action_kit_api.TimeControlInternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "allowWithoutReplicas",
+				Label:        "Allow instances without replicas",
+				Description:  extutil.Ptr("Without replicas a shard is unavailable while its node restarts and loses the data not yet persisted. Such instances are refused unless this is set."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("false"),
+				Order:        extutil.Ptr(1),
+				Advanced:     extutil.Ptr(true),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("10s"),
+		}),
+	}
+}
+
+func (a *valkeySimulateMaintenanceAttack) Prepare(ctx context.Context, state *ValkeySimulateMaintenanceState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHaveAttr(request.Target.Attributes, attrProjectID)
+	state.InstanceID = mustHaveAttr(request.Target.Attributes, attrValkeyID)
+	region := mustHaveAttr(request.Target.Attributes, attrRegion)
+	if state.ProjectID == "" || state.InstanceID == "" || region == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.memorystore.valkey-instance.id, gcp.memorystore.region", nil)
+	}
+	state.InstanceName = fmt.Sprintf("projects/%s/locations/%s/instances/%s", state.ProjectID, region, state.InstanceID)
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Memorystore client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore Valkey instance %s", state.InstanceID), err)
+	}
+	if inst.GetState() != memorystorepb.Instance_ACTIVE {
+		return nil, extension_kit.ToError(fmt.Sprintf("Memorystore Valkey instance %s is %s, not ACTIVE", state.InstanceID, inst.GetState()), nil)
+	}
+	if inst.GetReplicaCount() == 0 && !extutil.ToBool(request.Config["allowWithoutReplicas"]) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Memorystore Valkey instance %s has no replicas; enable 'Allow instances without replicas' to run this attack anyway", state.InstanceID), nil)
+	}
+	return nil, nil
+}
+
+func (a *valkeySimulateMaintenanceAttack) Start(ctx context.Context, state *ValkeySimulateMaintenanceState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Memorystore client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	state.StartedAt = time.Now()
+	state.OperationName, err = client.UpdateInstance(ctx, &memorystorepb.UpdateInstanceRequest{
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"simulate_maintenance_event"}},
+		Instance: &memorystorepb.Instance{
+			Name:                     state.InstanceName,
+			SimulateMaintenanceEvent: extutil.Ptr(true),
+		},
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to simulate maintenance of Memorystore Valkey instance %s", state.InstanceID), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Simulated maintenance of Memorystore Valkey instance %s requested", state.InstanceID),
+		}}),
+	}, nil
+}
+
+func (a *valkeySimulateMaintenanceAttack) Status(ctx context.Context, state *ValkeySimulateMaintenanceState) (*action_kit_api.StatusResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Memorystore client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollUpdate(ctx, state.OperationName)
+	elapsed := time.Since(state.StartedAt).Round(time.Second)
+	if result, err := utils.OperationStatus(done, err, "Memorystore", fmt.Sprintf("Simulated maintenance of Memorystore Valkey instance %s failed after %s", state.InstanceID, elapsed)); result != nil || err != nil {
+		return result, err
+	}
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Memorystore Valkey instance %s", state.InstanceID), err)
+	}
+	if inst.GetState() != memorystorepb.Instance_ACTIVE {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	return &action_kit_api.StatusResult{
+		Completed: true,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Simulated maintenance of Memorystore Valkey instance %s finished after %s", state.InstanceID, elapsed),
+		}}),
+	}, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/memorystore/apiv1/memorystorepb"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const valkeyInstanceName = "projects/proj-a/locations/europe-west1/instances/valkey-01"
+
+var validValkeyAttrs = map[string][]string{
+	attrProjectID: {"proj-a"},
+	attrValkeyID:  {"valkey-01"},
+	attrRegion:    {"europe-west1"},
+}
+
+func TestValkeySimulateMaintenance_UpdatesAndWaitsForActive(t *testing.T) {
+	api := newFakeValkeyInstances()
+	api.instances[valkeyInstanceName] = &memorystorepb.Instance{State: memorystorepb.Instance_ACTIVE, ReplicaCount: extutil.Ptr(int32(1))}
+	a := &valkeySimulateMaintenanceAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validValkeyAttrs, map[string]interface{}{}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.updates, 1)
+	assert.Equal(t, []string{"simulate_maintenance_event"}, api.updates[0].UpdateMask.Paths)
+	assert.Equal(t, valkeyInstanceName, api.updates[0].Instance.Name)
+	assert.True(t, api.updates[0].Instance.GetSimulateMaintenanceEvent())
+
+	api.pendingPolls[state.OperationName] = 1
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	api.instances[valkeyInstanceName].State = memorystorepb.Instance_UPDATING
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	api.instances[valkeyInstanceName].State = memorystorepb.Instance_ACTIVE
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	assert.Nil(t, status.Error)
+}
+
+func TestValkeySimulateMaintenance_Prepare_RequiresReplicasUnlessAllowed(t *testing.T) {
+	api := newFakeValkeyInstances()
+	api.instances[valkeyInstanceName] = &memorystorepb.Instance{State: memorystorepb.Instance_ACTIVE}
+	a := &valkeySimulateMaintenanceAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validValkeyAttrs, map[string]interface{}{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no replicas")
+
+	_, err = a.Prepare(context.Background(), &state, redisReq(validValkeyAttrs, map[string]interface{}{"allowWithoutReplicas": true}))
+	require.NoError(t, err)
+}
+
+func TestValkeySimulateMaintenance_Prepare_RequiresActive(t *testing.T) {
+	api := newFakeValkeyInstances()
+	api.instances[valkeyInstanceName] = &memorystorepb.Instance{State: memorystorepb.Instance_UPDATING, ReplicaCount: extutil.Ptr(int32(1))}
+	a := &valkeySimulateMaintenanceAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, redisReq(validValkeyAttrs, map[string]interface{}{}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not ACTIVE")
+}
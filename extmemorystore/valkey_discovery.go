@@ -0,0 +1,179 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	memorystore "cloud.google.com/go/memorystore/apiv1"
+	"cloud.google.com/go/memorystore/apiv1/memorystorepb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+type valkeyDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*valkeyDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*valkeyDiscovery)(nil)
+)
+
+func NewValkeyDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&valkeyDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *valkeyDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDValkeyInstance,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *valkeyDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDValkeyInstance,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Memorystore for Valkey instance", Other: "Memorystore for Valkey instances"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: "gcp.memorystore.mode"},
+				{Attribute: attrShardCount},
+				{Attribute: attrReplicaCount},
+				{Attribute: attrRegion},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *valkeyDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrValkeyID, Label: discovery_kit_api.PluralLabel{One: "Memorystore Valkey instance ID", Other: "Memorystore Valkey instance IDs"}},
+		{Attribute: "gcp.memorystore.mode", Label: discovery_kit_api.PluralLabel{One: "Memorystore mode", Other: "Memorystore modes"}},
+		{Attribute: "gcp.memorystore.engine-version", Label: discovery_kit_api.PluralLabel{One: "Memorystore engine version", Other: "Memorystore engine versions"}},
+		{Attribute: "gcp.memorystore.node-size-gb", Label: discovery_kit_api.PluralLabel{One: "Memorystore node size (GiB)", Other: "Memorystore node sizes (GiB)"}},
+	}
+}
+
+func (d *valkeyDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		client, err := memorystore.NewClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Memorystore client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = client.Close() }()
+		return getAllValkeyInstances(ctx, client, access.ProjectID)
+	}, ctx, "memorystore-valkey")
+}
+
+func getAllValkeyInstances(ctx context.Context, client *memorystore.Client, projectID string) ([]discovery_kit_api.Target, error) {
+	targets := make([]discovery_kit_api.Target, 0)
+	it := client.ListInstances(ctx, &memorystorepb.ListInstancesRequest{Parent: fmt.Sprintf("projects/%s/locations/-", projectID)})
+	for {
+		inst, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).Msg("Failed to list Memorystore Valkey instances")
+			return nil, err
+		}
+		targets = append(targets, toValkeyTarget(inst, projectID))
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesMemorystoreValkey), nil
+}
+
+func toValkeyTarget(inst *memorystorepb.Instance, projectID string) discovery_kit_api.Target {
+	region, instanceID := splitResourceName(inst.Name)
+
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrValkeyID] = []string{instanceID}
+	if region != "" {
+		attributes[attrRegion] = []string{region}
+	}
+	if inst.State != memorystorepb.Instance_STATE_UNSPECIFIED {
+		attributes[attrState] = []string{inst.State.String()}
+	}
+	if inst.Mode != memorystorepb.Instance_MODE_UNSPECIFIED {
+		attributes["gcp.memorystore.mode"] = []string{inst.Mode.String()}
+	}
+	if inst.EngineVersion != "" {
+		attributes["gcp.memorystore.engine-version"] = []string{inst.EngineVersion}
+	}
+	if inst.ShardCount > 0 {
+		attributes[attrShardCount] = []string{strconv.Itoa(int(inst.ShardCount))}
+	}
+	if inst.ReplicaCount != nil {
+		attributes[attrReplicaCount] = []string{strconv.Itoa(int(inst.GetReplicaCount()))}
+	}
+	if inst.NodeType != memorystorepb.Instance_NODE_TYPE_UNSPECIFIED {
+		attributes[attrNodeType] = []string{inst.NodeType.String()}
+	}
+	if size := inst.GetNodeConfig().GetSizeGb(); size > 0 {
+		attributes["gcp.memorystore.node-size-gb"] = []string{strconv.FormatFloat(size, 'f', -1, 64)}
+	}
+	if inst.AuthorizationMode != memorystorepb.Instance_AUTHORIZATION_MODE_UNSPECIFIED {
+		attributes["gcp.memorystore.authorization-mode"] = []string{inst.AuthorizationMode.String()}
+	}
+	if inst.TransitEncryptionMode != memorystorepb.Instance_TRANSIT_ENCRYPTION_MODE_UNSPECIFIED {
+		attributes["gcp.memorystore.transit-encryption-mode"] = []string{inst.TransitEncryptionMode.String()}
+	}
+	if zd := inst.GetZoneDistributionConfig(); zd != nil {
+		if zd.Mode != memorystorepb.ZoneDistributionConfig_ZONE_DISTRIBUTION_MODE_UNSPECIFIED {
+			attributes[attrZoneMode] = []string{zd.Mode.String()}
+		}
+		if zd.Zone != "" {
+			attributes[attrZone] = []string{zd.Zone}
+		}
+	}
+	if psc := valkeyPscEndpoints(inst); len(psc) > 0 {
+		attributes[attrPscEndpoints] = psc
+	}
+	for k, v := range inst.Labels {
+		attributes[fmt.Sprintf("gcp.memorystore.label.%s", strings.ToLower(k))] = []string{v}
+	}
+
+	return discovery_kit_api.Target{
+		Id:         inst.Name,
+		TargetType: TargetIDValkeyInstance,
+		Label:      instanceID,
+		Attributes: attributes,
+	}
+}
+
+// valkeyPscEndpoints lists ip:port of the auto-created and user-created PSC connections of all endpoints.
+func valkeyPscEndpoints(inst *memorystorepb.Instance) []string {
+	var result []string
+	for _, endpoint := range inst.GetEndpoints() {
+		for _, conn := range endpoint.GetConnections() {
+			if auto := conn.GetPscAutoConnection(); auto != nil && auto.GetIpAddress() != "" {
+				result = append(result, fmt.Sprintf("%s:%d", auto.GetIpAddress(), auto.GetPort()))
+			}
+			if manual := conn.GetPscConnection(); manual != nil && manual.GetIpAddress() != "" {
+				result = append(result, fmt.Sprintf("%s:%d", manual.GetIpAddress(), manual.GetPort()))
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extmemorystore
+
+import (
+	"testing"
+
+	"cloud.google.com/go/memorystore/apiv1/memorystorepb"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToValkeyTarget_Populated(t *testing.T) {
+	inst := &memorystorepb.Instance{
+		Name:                  "projects/proj-a/locations/europe-west1/instances/valkey-01",
+		State:                 memorystorepb.Instance_ACTIVE,
+		Mode:                  memorystorepb.Instance_CLUSTER,
+		EngineVersion:         "VALKEY_8_0",
+		ShardCount:            3,
+		ReplicaCount:          extutil.Ptr(int32(2)),
+		NodeType:              memorystorepb.Instance_HIGHMEM_MEDIUM,
+		NodeConfig:            &memorystorepb.NodeConfig{SizeGb: 13.5},
+		AuthorizationMode:     memorystorepb.Instance_IAM_AUTH,
+		TransitEncryptionMode: memorystorepb.Instance_SERVER_AUTHENTICATION,
+		ZoneDistributionConfig: &memorystorepb.ZoneDistributionConfig{
+			Mode: memorystorepb.ZoneDistributionConfig_MULTI_ZONE,
+		},
+		Endpoints: []*memorystorepb.Instance_InstanceEndpoint{{
+			Connections: []*memorystorepb.Instance_ConnectionDetail{
+				{Connection: &memorystorepb.Instance_ConnectionDetail_PscAutoConnection{PscAutoConnection: &memorystorepb.PscAutoConnection{
+					IpAddress: "10.1.0.2",
+					Ports:     &memorystorepb.PscAutoConnection_Port{Port: 6379},
+				}}},
+				{Connection: &memorystorepb.Instance_ConnectionDetail_PscConnection{PscConnection: &memorystorepb.PscConnection{
+					IpAddress: "10.2.0.2",
+					Ports:     &memorystorepb.PscConnection_Port{Port: 6379},
+				}}},
+			},
+		}},
+		Labels: map[string]string{"Team": "core"},
+	}
+
+	target := toValkeyTarget(inst, "proj-a")
+
+	assert.Equal(t, TargetIDValkeyInstance, target.TargetType)
+	assert.Equal(t, "valkey-01", target.Label)
+	assert.Equal(t, inst.Name, target.Id)
+	assert.Equal(t, []string{"valkey-01"}, target.Attributes[attrValkeyID])
+	assert.Equal(t, []string{"europe-west1"}, target.Attributes[attrRegion])
+	assert.Equal(t, []string{"ACTIVE"}, target.Attributes[attrState])
+	assert.Equal(t, []string{"CLUSTER"}, target.Attributes["gcp.memorystore.mode"])
+	assert.Equal(t, []string{"VALKEY_8_0"}, target.Attributes["gcp.memorystore.engine-version"])
+	assert.Equal(t, []string{"3"}, target.Attributes[attrShardCount])
+	assert.Equal(t, []string{"2"}, target.Attributes[attrReplicaCount])
+	assert.Equal(t, []string{"HIGHMEM_MEDIUM"}, target.Attributes[attrNodeType])
+	assert.Equal(t, []string{"13.5"}, target.Attributes["gcp.memorystore.node-size-gb"])
+	assert.Equal(t, []string{"IAM_AUTH"}, target.Attributes["gcp.memorystore.authorization-mode"])
+	assert.Equal(t, []string{"SERVER_AUTHENTICATION"}, target.Attributes["gcp.memorystore.transit-encryption-mode"])
+	assert.Equal(t, []string{"MULTI_ZONE"}, target.Attributes[attrZoneMode])
+	assert.Equal(t, []string{"10.1.0.2:6379", "10.2.0.2:6379"}, target.Attributes[attrPscEndpoints])
+	assert.Equal(t, []string{"core"}, target.Attributes["gcp.memorystore.label.team"])
+}
+
+func TestToValkeyTarget_Sparse(t *testing.T) {
+	inst := &memorystorepb.Instance{Name: "valkey-02"}
+
+	target := toValkeyTarget(inst, "proj-a")
+
+	assert.Equal(t, "valkey-02", target.Label)
+	assert.Equal(t, []string{"valkey-02"}, target.Attributes[attrValkeyID])
+	assert.NotContains(t, target.Attributes, attrRegion)
+	assert.NotContains(t, target.Attributes, attrReplicaCount)
+	assert.NotContains(t, target.Attributes, attrPscEndpoints)
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+
+	pubsub "cloud.google.com/go/pubsub/v2/apiv1"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/steadybit/extension-gcp/utils"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// subscriptionsApi is the part of the Pub/Sub subscription admin API the subscription attacks use. All calls are
+// synchronous; Pub/Sub has no long-running operations for them.
+type subscriptionsApi interface {
+	GetSubscription(ctx context.Context, name string) (*pubsubpb.Subscription, error)
+	// UpdateSubscription writes only the given fields of subscription.
+	UpdateSubscription(ctx context.Context, subscription *pubsubpb.Subscription, paths ...string) (*pubsubpb.Subscription, error)
+	Seek(ctx context.Context, req *pubsubpb.SeekRequest) error
+	CreateSnapshot(ctx context.Context, name, subscription string, labels map[string]string) (*pubsubpb.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, name string) error
+}
+
+type subscriptionAdminClient struct {
+	client *pubsub.SubscriptionAdminClient
+}
+
+func newSubscriptionsApi(ctx context.Context, projectID string) (subscriptionsApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := pubsub.NewSubscriptionAdminClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &subscriptionAdminClient{client: c}, func() { _ = c.Close() }, nil
+}
+
+func (c *subscriptionAdminClient) GetSubscription(ctx context.Context, name string) (*pubsubpb.Subscription, error) {
+	return c.client.GetSubscription(ctx, &pubsubpb.GetSubscriptionRequest{Subscription: name})
+}
+
+func (c *subscriptionAdminClient) UpdateSubscription(ctx context.Context, subscription *pubsubpb.Subscription, paths ...string) (*pubsubpb.Subscription, error) {
+	return c.client.UpdateSubscription(ctx, &pubsubpb.UpdateSubscriptionRequest{
+		Subscription: subscription,
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: paths},
+	})
+}
+
+func (c *subscriptionAdminClient) Seek(ctx context.Context, req *pubsubpb.SeekRequest) error {
+	_, err := c.client.Seek(ctx, req)
+	return err
+}
+
+func (c *subscriptionAdminClient) CreateSnapshot(ctx context.Context, name, subscription string, labels map[string]string) (*pubsubpb.Snapshot, error) {
+	return c.client.CreateSnapshot(ctx, &pubsubpb.CreateSnapshotRequest{Name: name, Subscription: subscription, Labels: labels})
+}
+
+func (c *subscriptionAdminClient) DeleteSnapshot(ctx context.Context, name string) error {
+	return c.client.DeleteSnapshot(ctx, &pubsubpb.DeleteSnapshotRequest{Snapshot: name})
+}
+
+// topicsApi is the part of the Pub/Sub topic admin API the topic attacks use.
+type topicsApi interface {
+	GetTopic(ctx context.Context, name string) (*pubsubpb.Topic, error)
+	Publish(ctx context.Context, topic string, messages []*pubsubpb.PubsubMessage) error
+}
+
+type topicAdminClient struct {
+	client *pubsub.TopicAdminClient
+}
+
+func newTopicsApi(ctx context.Context, projectID string) (topicsApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := pubsub.NewTopicAdminClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &topicAdminClient{client: c}, func() { _ = c.Close() }, nil
+}
+
+func (c *topicAdminClient) GetTopic(ctx context.Context, name string) (*pubsubpb.Topic, error) {
+	return c.client.GetTopic(ctx, &pubsubpb.GetTopicRequest{Topic: name})
+}
+
+func (c *topicAdminClient) Publish(ctx context.Context, topic string, messages []*pubsubpb.PubsubMessage) error {
+	_, err := c.client.Publish(ctx, &pubsubpb.PublishRequest{Topic: topic, Messages: messages})
+	return err
+}
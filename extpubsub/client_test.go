@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/protobuf/proto"
+)
+
+const subscriptionName = "projects/proj-a/subscriptions/orders-sub"
+
+var validSubscriptionAttrs = map[string][]string{
+	attrProjectID:        {"proj-a"},
+	attrSubscriptionName: {"orders-sub"},
+}
+
+func subscriptionReq(cfg map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: validSubscriptionAttrs}),
+		Config: cfg,
+	})
+}
+
+// fakeSubscriptions serves subscriptions from a map and applies the masked fields of an update. seekErr fails every
+// seek.
+type fakeSubscriptions struct {
+	subscriptions map[string]*pubsubpb.Subscription
+	snapshots     map[string]*pubsubpb.Snapshot
+	updates       [][]string
+	seeks         []*pubsubpb.SeekRequest
+	seekErr       error
+}
+
+func newFakeSubscriptions() *fakeSubscriptions {
+	return &fakeSubscriptions{
+		subscriptions: map[string]*pubsubpb.Subscription{},
+		snapshots:     map[string]*pubsubpb.Snapshot{},
+	}
+}
+
+func (f *fakeSubscriptions) provider() func(context.Context, string) (subscriptionsApi, func(), error) {
+	return func(context.Context, string) (subscriptionsApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeSubscriptions) GetSubscription(_ context.Context, name string) (*pubsubpb.Subscription, error) {
+	if sub, ok := f.subscriptions[name]; ok {
+		return proto.Clone(sub).(*pubsubpb.Subscription), nil
+	}
+	return nil, fmt.Errorf("subscription %s not found", name)
+}
+
+func (f *fakeSubscriptions) UpdateSubscription(_ context.Context, subscription *pubsubpb.Subscription, paths ...string) (*pubsubpb.Subscription, error) {
+	stored, ok := f.subscriptions[subscription.Name]
+	if !ok {
+		return nil, fmt.Errorf("subscription %s not found", subscription.Name)
+	}
+	f.updates = append(f.updates, paths)
+	for _, p := range paths {
+		switch p {
+		case "push_config":
+			stored.PushConfig = proto.Clone(subscription.PushConfig).(*pubsubpb.PushConfig)
+		case "ack_deadline_seconds":
+			stored.AckDeadlineSeconds = subscription.AckDeadlineSeconds
+		default:
+			return nil, fmt.Errorf("unsupported update path %s", p)
+		}
+	}
+	return proto.Clone(stored).(*pubsubpb.Subscription), nil
+}
+
+func (f *fakeSubscriptions) Seek(_ context.Context, req *pubsubpb.SeekRequest) error {
+	if f.seekErr != nil {
+		return f.seekErr
+	}
+	f.seeks = append(f.seeks, req)
+	return nil
+}
+
+func (f *fakeSubscriptions) CreateSnapshot(_ context.Context, name, subscription string, labels map[string]string) (*pubsubpb.Snapshot, error) {
+	if _, ok := f.snapshots[name]; ok {
+		return nil, fmt.Errorf("snapshot %s already exists", name)
+	}
+	sub, ok := f.subscriptions[subscription]
+	if !ok {
+		return nil, fmt.Errorf("subscription %s not found", subscription)
+	}
+	f.snapshots[name] = &pubsubpb.Snapshot{Name: name, Topic: sub.Topic, Labels: labels}
+	return proto.Clone(f.snapshots[name]).(*pubsubpb.Snapshot), nil
+}
+
+func (f *fakeSubscriptions) DeleteSnapshot(_ context.Context, name string) error {
+	if _, ok := f.snapshots[name]; !ok {
+		return fmt.Errorf("snapshot %s not found", name)
+	}
+	delete(f.snapshots, name)
+	return nil
+}
+
+// fakeTopics serves a single topic and records published messages. It is safe for the flood publisher goroutine;
+// publishErr fails every publish.
+type fakeTopics struct {
+	mu         sync.Mutex
+	topic      *pubsubpb.Topic
+	published  []*pubsubpb.PubsubMessage
+	publishErr error
+}
+
+func (f *fakeTopics) provider() func(context.Context, string) (topicsApi, func(), error) {
+	return func(context.Context, string) (topicsApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeTopics) GetTopic(_ context.Context, name string) (*pubsubpb.Topic, error) {
+	if f.topic == nil || f.topic.Name != name {
+		return nil, fmt.Errorf("topic %s not found", name)
+	}
+	return proto.Clone(f.topic).(*pubsubpb.Topic), nil
+}
+
+func (f *fakeTopics) Publish(_ context.Context, topic string, messages []*pubsubpb.PubsubMessage) error {
+	if f.publishErr != nil {
+		return f.publishErr
+	}
+	if f.topic == nil || f.topic.Name != topic {
+		return fmt.Errorf("topic %s not found", topic)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.published = append(f.published, messages...)
+	return nil
+}
+
+func (f *fakeTopics) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.published)
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// unreachablePushEndpoint receives the push deliveries while a push subscription is paused. The .invalid TLD is
+// reserved and never resolves, so every delivery fails and Pub/Sub keeps the messages in the backlog.
+const unreachablePushEndpoint = "https://steadybit-paused.invalid/"
+
+// SubscriptionPauseState keeps the original subscription as proto.Marshal bytes (base64 in JSON), as push configs
+// carry oneof fields encoding/json cannot round-trip. The attack is reversible: Stop writes the original push config
+// and ack deadline back and, for pull subscriptions with replay enabled, seeks back to the snapshot taken at Start and
+// deletes it. Seeking to a snapshot rather than to a time keeps the backlog that was unacknowledged before the attack.
+type SubscriptionPauseState struct {
+	ProjectID            string
+	SubscriptionName     string
+	SubscriptionID       string
+	ExecutionID          string
+	DeliveryType         string
+	AckDeadlineSeconds   int32
+	ReplayOnStop         bool
+	OriginalSubscription []byte
+	StartedAt            time.Time
+	SnapshotName         string
+	SnapshotCreated      bool
+	Paused               bool
+}
+
+type subscriptionPauseAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (subscriptionsApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[SubscriptionPauseState] = (*subscriptionPauseAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[SubscriptionPauseState] = (*subscriptionPauseAttack)(nil)
+
+func NewSubscriptionPauseAction() action_kit_sdk.ActionWithStop[SubscriptionPauseState] {
+	return &subscriptionPauseAttack{clientProvider: newSubscriptionsApi}
+}
+
+func (a *subscriptionPauseAttack) NewEmptyState() SubscriptionPauseState {
+	return SubscriptionPauseState{}
+}
+
+func (a *subscriptionPauseAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          SubscriptionPauseActionId,
+		Label:       "Pause Pub/Sub subscription",
+		Description: "Disturbs consumption of a Pub/Sub subscription for the given duration. Push subscriptions deliver to an unreachable endpoint, so the backlog grows; pull subscriptions get a different ack deadline and can replay everything acknowledged during the attack once it ends. The original configuration is restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         TargetIDSubscription,
+			SelectionTemplates: extutil.Ptr(subscriptionSelectionTemplates),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Pub/Sub"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long consumption stays paused. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "ackDeadlineSeconds",
+				Label:        "Ack deadline (pull)",
+				Description:  extutil.Ptr("Ack deadline of a pull subscription during the attack, between 10 and 600 seconds. A short deadline makes slow consumers lose their leases and see redeliveries. Ignored for push subscriptions."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("10"),
+				MinValue:     extutil.Ptr(10),
+				MaxValue:     extutil.Ptr(600),
+				Order:        extutil.Ptr(2),
+			},
+			{
+				Name:         "replayOnStop",
+				Label:        "Replay on stop (pull)",
+				Description:  extutil.Ptr("Snapshot a pull subscription at the start of the attack and seek back to the snapshot on stop, so messages acknowledged meanwhile are delivered again. The snapshot is deleted afterwards. Ignored for push subscriptions."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("true"),
+				Order:        extutil.Ptr(3),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *subscriptionPauseAttack) Prepare(ctx context.Context, state *SubscriptionPauseState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.SubscriptionID, state.SubscriptionName, err = resolveSubscription(request)
+	if err != nil {
+		return nil, err
+	}
+	state.AckDeadlineSeconds = int32(extutil.ToInt64(request.Config["ackDeadlineSeconds"]))
+	state.ReplayOnStop = extutil.ToBool(request.Config["replayOnStop"])
+	state.ExecutionID = request.ExecutionId.String()
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	sub, err := client.GetSubscription(ctx, state.SubscriptionName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Pub/Sub subscription %s", state.SubscriptionID), err)
+	}
+	if sub.GetDetached() {
+		return nil, extension_kit.ToError(fmt.Sprintf("Pub/Sub subscription %s is detached from its topic and receives no messages", state.SubscriptionID), nil)
+	}
+	state.DeliveryType = deliveryType(sub)
+	switch state.DeliveryType {
+	case "push":
+	case "pull":
+		if state.AckDeadlineSeconds < 10 || state.AckDeadlineSeconds > 600 {
+			return nil, extension_kit.ToError(fmt.Sprintf("Ack deadline must be between 10 and 600 seconds, got %d", state.AckDeadlineSeconds), nil)
+		}
+		if state.ReplayOnStop {
+			state.SnapshotName = fmt.Sprintf("projects/%s/snapshots/steadybit-pause-%s", state.ProjectID, state.ExecutionID)
+		}
+	default:
+		return nil, extension_kit.ToError(fmt.Sprintf("Pub/Sub subscription %s exports to %s; only push and pull subscriptions can be paused", state.SubscriptionID, state.DeliveryType), nil)
+	}
+	state.OriginalSubscription, err = proto.Marshal(sub)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to serialize Pub/Sub subscription %s", state.SubscriptionID), err)
+	}
+	return nil, nil
+}
+
+func (a *subscriptionPauseAttack) Start(ctx context.Context, state *SubscriptionPauseState) (*action_kit_api.StartResult, error) {
+	original, err := state.original()
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to read original configuration of Pub/Sub subscription %s", state.SubscriptionID), err)
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+
+	update := &pubsubpb.Subscription{Name: state.SubscriptionName}
+	var message string
+	if state.DeliveryType == "push" {
+		update.PushConfig = proto.Clone(original.GetPushConfig()).(*pubsubpb.PushConfig)
+		update.PushConfig.PushEndpoint = unreachablePushEndpoint
+		_, err = client.UpdateSubscription(ctx, update, "push_config")
+		message = fmt.Sprintf("Pub/Sub subscription %s now pushes to %s (was %s)", state.SubscriptionID, unreachablePushEndpoint, original.GetPushConfig().GetPushEndpoint())
+	} else {
+		if state.SnapshotName != "" && !state.SnapshotCreated {
+			_, err := client.CreateSnapshot(ctx, state.SnapshotName, state.SubscriptionName, map[string]string{snapshotExecutionLabel: state.ExecutionID})
+			if status.Code(err) == codes.AlreadyExists {
+				log.Info().Msgf("Pub/Sub snapshot %s already exists — reusing it", state.SnapshotName)
+			} else if err != nil {
+				return nil, extension_kit.ToError(fmt.Sprintf("Failed to snapshot Pub/Sub subscription %s", state.SubscriptionID), err)
+			}
+			state.SnapshotCreated = true
+		}
+		update.AckDeadlineSeconds = state.AckDeadlineSeconds
+		_, err = client.UpdateSubscription(ctx, update, "ack_deadline_seconds")
+		message = fmt.Sprintf("Ack deadline of Pub/Sub subscription %s set to %ds (was %ds)", state.SubscriptionID, state.AckDeadlineSeconds, original.GetAckDeadlineSeconds())
+	}
+	state.StartedAt = time.Now()
+	if err != nil {
+		// Nothing was paused, so Stop will not run the replay; drop the snapshot right away.
+		if state.SnapshotCreated && client.DeleteSnapshot(ctx, state.SnapshotName) == nil {
+			state.SnapshotCreated = false
+		}
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to pause Pub/Sub subscription %s", state.SubscriptionID), err)
+	}
+	state.Paused = true
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: message,
+		}}),
+	}, nil
+}
+
+func (a *subscriptionPauseAttack) Stop(ctx context.Context, state *SubscriptionPauseState) (*action_kit_api.StopResult, error) {
+	if !state.Paused {
+		return nil, nil
+	}
+	messages, err := a.resume(ctx, state)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to resume Pub/Sub subscription %s", state.SubscriptionID)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to resume Pub/Sub subscription %s", state.SubscriptionID), err)
+	}
+	state.Paused = false
+	return &action_kit_api.StopResult{Messages: extutil.Ptr(messages)}, nil
+}
+
+func (a *subscriptionPauseAttack) resume(ctx context.Context, state *SubscriptionPauseState) ([]action_kit_api.Message, error) {
+	original, err := state.original()
+	if err != nil {
+		return nil, err
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+	current, err := client.GetSubscription(ctx, state.SubscriptionName)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []action_kit_api.Message{}
+	info := func(msg string) {
+		messages = append(messages, action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Info), Message: msg})
+	}
+	if state.DeliveryType == "push" {
+		if !proto.Equal(current.GetPushConfig(), original.GetPushConfig()) {
+			if _, err := client.UpdateSubscription(ctx, &pubsubpb.Subscription{Name: state.SubscriptionName, PushConfig: original.GetPushConfig()}, "push_config"); err != nil {
+				return nil, err
+			}
+		}
+		info(fmt.Sprintf("Pub/Sub subscription %s pushes to %s again", state.SubscriptionID, original.GetPushConfig().GetPushEndpoint()))
+		return messages, nil
+	}
+
+	if current.GetAckDeadlineSeconds() != original.GetAckDeadlineSeconds() {
+		if _, err := client.UpdateSubscription(ctx, &pubsubpb.Subscription{Name: state.SubscriptionName, AckDeadlineSeconds: original.GetAckDeadlineSeconds()}, "ack_deadline_seconds"); err != nil {
+			return nil, err
+		}
+	}
+	info(fmt.Sprintf("Ack deadline of Pub/Sub subscription %s restored to %ds", state.SubscriptionID, original.GetAckDeadlineSeconds()))
+	if !state.SnapshotCreated {
+		return messages, nil
+	}
+	if err := client.Seek(ctx, &pubsubpb.SeekRequest{
+		Subscription: state.SubscriptionName,
+		Target:       &pubsubpb.SeekRequest_Snapshot{Snapshot: state.SnapshotName},
+	}); err != nil {
+		return nil, fmt.Errorf("seek to snapshot %s, which is kept for a manual seek: %w", lastSegment(state.SnapshotName), err)
+	}
+	state.SnapshotCreated = false
+	info(fmt.Sprintf("Pub/Sub subscription %s replays the messages acknowledged since %s", state.SubscriptionID, state.StartedAt.Format(time.RFC3339)))
+	// The replay already happened; a snapshot left behind expires on its own within seven days.
+	if err := client.DeleteSnapshot(ctx, state.SnapshotName); err != nil && status.Code(err) != codes.NotFound {
+		log.Warn().Err(err).Msgf("Failed to delete Pub/Sub snapshot %s", state.SnapshotName)
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Warn),
+			Message: fmt.Sprintf("Failed to delete Pub/Sub snapshot %s: %s", lastSegment(state.SnapshotName), err),
+		})
+	}
+	return messages, nil
+}
+
+func (s *SubscriptionPauseState) original() (*pubsubpb.Subscription, error) {
+	sub := &pubsubpb.Subscription{}
+	if err := proto.Unmarshal(s.OriginalSubscription, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+var subscriptionSelectionTemplates = []action_kit_api.TargetSelectionTemplate{
+	{
+		Label:       "by subscription name",
+		Description: extutil.Ptr("Find Pub/Sub subscription by name"),
+		Query:       attrSubscriptionName + "=\"\"",
+	},
+}
+
+// resolveSubscription reads project and subscription from the target attributes and builds the resource name.
+func resolveSubscription(request action_kit_api.PrepareActionRequestBody) (projectID, subscriptionID, subscriptionName string, err error) {
+	projectID = mustHave(request.Target.Attributes, attrProjectID)
+	subscriptionID = mustHave(request.Target.Attributes, attrSubscriptionName)
+	if projectID == "" || subscriptionID == "" {
+		return "", "", "", extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.pubsub.subscription.name", nil)
+	}
+	return projectID, subscriptionID, fmt.Sprintf("projects/%s/subscriptions/%s", projectID, subscriptionID), nil
+}
+
+func mustHave(attrs map[string][]string, key string) string {
+	v, ok := attrs[key]
+	if !ok || len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
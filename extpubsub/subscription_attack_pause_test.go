@@ -0,0 +1,145 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushSubscription() *pubsubpb.Subscription {
+	return &pubsubpb.Subscription{
+		Name:               subscriptionName,
+		AckDeadlineSeconds: 30,
+		PushConfig: &pubsubpb.PushConfig{
+			PushEndpoint: "https://orders.example.com/push",
+			AuthenticationMethod: &pubsubpb.PushConfig_OidcToken_{OidcToken: &pubsubpb.PushConfig_OidcToken{
+				ServiceAccountEmail: "push@proj-a.iam.gserviceaccount.com",
+			}},
+		},
+	}
+}
+
+func pullSubscription() *pubsubpb.Subscription {
+	return &pubsubpb.Subscription{Name: subscriptionName, AckDeadlineSeconds: 60}
+}
+
+func TestSubscriptionPause_PushSwapsEndpointAndRestores(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = pushSubscription()
+	a := &subscriptionPauseAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 10, "replayOnStop": true}))
+	require.NoError(t, err)
+	assert.Equal(t, "push", state.DeliveryType)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	paused := api.subscriptions[subscriptionName].PushConfig
+	assert.Equal(t, unreachablePushEndpoint, paused.PushEndpoint)
+	assert.Equal(t, "push@proj-a.iam.gserviceaccount.com", paused.GetOidcToken().GetServiceAccountEmail())
+	assert.Equal(t, int32(30), api.subscriptions[subscriptionName].AckDeadlineSeconds)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, "https://orders.example.com/push", api.subscriptions[subscriptionName].PushConfig.PushEndpoint)
+	assert.Empty(t, api.seeks, "push subscriptions are never seeked")
+	assert.Len(t, api.updates, 2)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Len(t, api.updates, 2, "a second stop is a no-op")
+}
+
+func TestSubscriptionPause_PullChangesAckDeadlineAndReplays(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = pullSubscription()
+	a := &subscriptionPauseAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 10, "replayOnStop": true}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), api.subscriptions[subscriptionName].AckDeadlineSeconds)
+	require.Contains(t, api.snapshots, state.SnapshotName)
+	assert.Equal(t, state.ExecutionID, api.snapshots[state.SnapshotName].Labels[snapshotExecutionLabel])
+
+	result, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(60), api.subscriptions[subscriptionName].AckDeadlineSeconds)
+	require.Len(t, api.seeks, 1)
+	assert.Equal(t, state.SnapshotName, api.seeks[0].GetSnapshot(), "seeking to the snapshot keeps the backlog older than the attack")
+	assert.Empty(t, api.snapshots, "the snapshot is deleted after the seek")
+	require.NotNil(t, result.Messages)
+	assert.Len(t, *result.Messages, 2)
+}
+
+func TestSubscriptionPause_PullWithoutReplay(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = pullSubscription()
+	a := &subscriptionPauseAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 600, "replayOnStop": false}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Empty(t, api.seeks)
+	assert.Empty(t, api.snapshots)
+}
+
+func TestSubscriptionPause_StopReportsFailedSeek(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = pullSubscription()
+	api.seekErr = errors.New("permission denied")
+	a := &subscriptionPauseAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 10, "replayOnStop": true}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.Error(t, err)
+	assert.Equal(t, int32(60), api.subscriptions[subscriptionName].AckDeadlineSeconds, "the ack deadline is restored before seeking")
+	assert.Contains(t, api.snapshots, state.SnapshotName, "the snapshot is kept for a manual seek")
+}
+
+func TestSubscriptionPause_Prepare_Refusals(t *testing.T) {
+	api := newFakeSubscriptions()
+	a := &subscriptionPauseAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	api.subscriptions[subscriptionName] = &pubsubpb.Subscription{Name: subscriptionName, BigqueryConfig: &pubsubpb.BigQueryConfig{Table: "p.d.t"}}
+	_, err := a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 10}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only push and pull subscriptions")
+
+	api.subscriptions[subscriptionName] = &pubsubpb.Subscription{Name: subscriptionName, Detached: true}
+	_, err = a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 10}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "detached")
+
+	api.subscriptions[subscriptionName] = pullSubscription()
+	_, err = a.Prepare(context.Background(), &state, subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 5}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "between 10 and 600")
+
+	missing := subscriptionReq(map[string]interface{}{"ackDeadlineSeconds": 10})
+	missing.Target.Attributes = map[string][]string{attrProjectID: {"proj-a"}}
+	_, err = a.Prepare(context.Background(), &state, missing)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Target is missing one of")
+}
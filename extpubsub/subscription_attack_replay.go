@@ -0,0 +1,250 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	replayFromTime     = "time"
+	replayFromSnapshot = "snapshot"
+
+	// defaultMessageRetention applies when a subscription does not set message_retention_duration.
+	defaultMessageRetention = 7 * 24 * time.Hour
+	// snapshotExecutionLabel tags the snapshots the replay action creates, so leftovers can be traced to a run.
+	snapshotExecutionLabel = "steadybit-execution-id"
+)
+
+// SubscriptionReplayState tracks a replay. Seeking back in time is not reversible: redelivered messages stay
+// unacknowledged until the consumers process them. In snapshot mode, Stop seeks to the snapshot taken at Start and
+// deletes it.
+type SubscriptionReplayState struct {
+	ProjectID        string
+	SubscriptionName string
+	SubscriptionID   string
+	ExecutionID      string
+	ReplayFrom       string
+	Lookback         time.Duration
+	SeekTime         time.Time
+	SnapshotName     string
+	SnapshotCreated  bool
+}
+
+type subscriptionReplayAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (subscriptionsApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[SubscriptionReplayState] = (*subscriptionReplayAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[SubscriptionReplayState] = (*subscriptionReplayAttack)(nil)
+
+func NewSubscriptionReplayAction() action_kit_sdk.ActionWithStop[SubscriptionReplayState] {
+	return &subscriptionReplayAttack{clientProvider: newSubscriptionsApi}
+}
+
+func (a *subscriptionReplayAttack) NewEmptyState() SubscriptionReplayState {
+	return SubscriptionReplayState{}
+}
+
+func (a *subscriptionReplayAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          SubscriptionReplayActionId,
+		Label:       "Replay Pub/Sub messages",
+		Description: "Redelivers already processed messages of a Pub/Sub subscription to test consumer idempotency. Either seeks back by a fixed time right away, or snapshots the subscription at start and seeks back to the snapshot on stop, replaying everything consumed in between.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         TargetIDSubscription,
+			SelectionTemplates: extutil.Ptr(subscriptionSelectionTemplates),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Pub/Sub"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("In snapshot mode, the window whose messages are replayed on stop. In time mode, only how long the action stays active."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "replayFrom",
+				Label:        "Replay from",
+				Description:  extutil.Ptr("Seek back by a fixed time at start, or to a snapshot taken at start once the action stops."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: extutil.Ptr(replayFromTime),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Relative time", Value: replayFromTime},
+					action_kit_api.ExplicitParameterOption{Label: "Snapshot taken at start", Value: replayFromSnapshot},
+				}),
+			},
+			{
+				Name:         "lookback",
+				Label:        "Look back",
+				Description:  extutil.Ptr("How far back to seek in time mode, e.g. 15m replays the messages of the last 15 minutes. Must lie within the subscription's or topic's message retention."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("15m"),
+				Order:        extutil.Ptr(3),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *subscriptionReplayAttack) Prepare(ctx context.Context, state *SubscriptionReplayState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.SubscriptionID, state.SubscriptionName, err = resolveSubscription(request)
+	if err != nil {
+		return nil, err
+	}
+	state.ExecutionID = request.ExecutionId.String()
+	state.ReplayFrom = extutil.ToString(request.Config["replayFrom"])
+	state.Lookback = time.Duration(extutil.ToInt64(request.Config["lookback"])) * time.Millisecond
+	if state.ReplayFrom != replayFromTime && state.ReplayFrom != replayFromSnapshot {
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported replay mode %q; use %s or %s", state.ReplayFrom, replayFromTime, replayFromSnapshot), nil)
+	}
+	if state.ReplayFrom == replayFromTime && state.Lookback <= 0 {
+		return nil, extension_kit.ToError("Look back must be positive", nil)
+	}
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	sub, err := client.GetSubscription(ctx, state.SubscriptionName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Pub/Sub subscription %s", state.SubscriptionID), err)
+	}
+	if sub.GetDetached() {
+		return nil, extension_kit.ToError(fmt.Sprintf("Pub/Sub subscription %s is detached from its topic and cannot be seeked", state.SubscriptionID), nil)
+	}
+	if state.ReplayFrom == replayFromTime {
+		window := replayWindow(sub)
+		if window == 0 {
+			return nil, extension_kit.ToError(fmt.Sprintf("Pub/Sub subscription %s neither retains acknowledged messages nor has a topic with message retention, so seeking back in time replays nothing", state.SubscriptionID), nil)
+		}
+		if state.Lookback > window {
+			return nil, extension_kit.ToError(fmt.Sprintf("Pub/Sub subscription %s can only replay the last %s, not %s", state.SubscriptionID, window, state.Lookback), nil)
+		}
+	} else {
+		state.SnapshotName = fmt.Sprintf("projects/%s/snapshots/steadybit-replay-%s", state.ProjectID, state.ExecutionID)
+	}
+	return nil, nil
+}
+
+func (a *subscriptionReplayAttack) Start(ctx context.Context, state *SubscriptionReplayState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+
+	if state.ReplayFrom == replayFromSnapshot {
+		_, err := client.CreateSnapshot(ctx, state.SnapshotName, state.SubscriptionName, map[string]string{snapshotExecutionLabel: state.ExecutionID})
+		if status.Code(err) == codes.AlreadyExists {
+			log.Info().Msgf("Pub/Sub snapshot %s already exists — reusing it", state.SnapshotName)
+		} else if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to snapshot Pub/Sub subscription %s", state.SubscriptionID), err)
+		}
+		state.SnapshotCreated = true
+		return &action_kit_api.StartResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Snapshot %s of Pub/Sub subscription %s created; messages consumed from now on are replayed on stop", lastSegment(state.SnapshotName), state.SubscriptionID),
+			}}),
+		}, nil
+	}
+
+	state.SeekTime = time.Now().Add(-state.Lookback)
+	if err := client.Seek(ctx, &pubsubpb.SeekRequest{
+		Subscription: state.SubscriptionName,
+		Target:       &pubsubpb.SeekRequest_Time{Time: timestamppb.New(state.SeekTime)},
+	}); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to seek Pub/Sub subscription %s to %s", state.SubscriptionID, state.SeekTime.Format(time.RFC3339)), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Pub/Sub subscription %s replays messages published since %s", state.SubscriptionID, state.SeekTime.Format(time.RFC3339)),
+		}}),
+	}, nil
+}
+
+func (a *subscriptionReplayAttack) Stop(ctx context.Context, state *SubscriptionReplayState) (*action_kit_api.StopResult, error) {
+	if !state.SnapshotCreated {
+		return nil, nil
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	if err := client.Seek(ctx, &pubsubpb.SeekRequest{
+		Subscription: state.SubscriptionName,
+		Target:       &pubsubpb.SeekRequest_Snapshot{Snapshot: state.SnapshotName},
+	}); err != nil {
+		log.Error().Err(err).Msgf("Failed to seek Pub/Sub subscription %s to snapshot %s", state.SubscriptionID, state.SnapshotName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to seek Pub/Sub subscription %s to snapshot %s; the snapshot is kept for a manual seek", state.SubscriptionID, lastSegment(state.SnapshotName)), err)
+	}
+	state.SnapshotCreated = false
+	messages := []action_kit_api.Message{{
+		Level:   extutil.Ptr(action_kit_api.Info),
+		Message: fmt.Sprintf("Pub/Sub subscription %s replays the messages consumed since snapshot %s", state.SubscriptionID, lastSegment(state.SnapshotName)),
+	}}
+	// The replay already happened; a snapshot left behind expires on its own within seven days.
+	if err := client.DeleteSnapshot(ctx, state.SnapshotName); err != nil && status.Code(err) != codes.NotFound {
+		log.Warn().Err(err).Msgf("Failed to delete Pub/Sub snapshot %s", state.SnapshotName)
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Warn),
+			Message: fmt.Sprintf("Failed to delete Pub/Sub snapshot %s: %s", lastSegment(state.SnapshotName), err),
+		})
+	}
+	return &action_kit_api.StopResult{Messages: extutil.Ptr(messages)}, nil
+}
+
+// replayWindow returns how far back a seek to a time can redeliver acknowledged messages: the subscription's own
+// retention if it retains acknowledged messages, or the topic's retention, whichever is longer. Zero means acked
+// messages are gone.
+func replayWindow(sub *pubsubpb.Subscription) time.Duration {
+	var window time.Duration
+	if sub.GetRetainAckedMessages() {
+		window = defaultMessageRetention
+		if sub.GetMessageRetentionDuration() != nil {
+			window = sub.GetMessageRetentionDuration().AsDuration()
+		}
+	}
+	if sub.GetTopicMessageRetentionDuration() != nil {
+		window = max(window, sub.GetTopicMessageRetentionDuration().AsDuration())
+	}
+	return window
+}
+
+func lastSegment(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func retainingSubscription() *pubsubpb.Subscription {
+	return &pubsubpb.Subscription{
+		Name:                     subscriptionName,
+		Topic:                    "projects/proj-a/topics/orders",
+		RetainAckedMessages:      true,
+		MessageRetentionDuration: durationpb.New(time.Hour),
+	}
+}
+
+func replayReq(cfg map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	req := subscriptionReq(cfg)
+	req.ExecutionId = uuid.MustParse("7f0c2a5e-1b1e-4c8e-9d1a-2b3c4d5e6f70")
+	return req
+}
+
+func TestSubscriptionReplay_SeeksBackInTime(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = retainingSubscription()
+	a := &subscriptionReplayAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "time", "lookback": 15 * 60 * 1000}))
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, state.Lookback)
+
+	before := time.Now()
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.seeks, 1)
+	seekTime := api.seeks[0].GetTime().AsTime()
+	assert.WithinDuration(t, before.Add(-15*time.Minute), seekTime, time.Second)
+	assert.Empty(t, api.snapshots)
+
+	result, err := a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, api.seeks, 1)
+}
+
+func TestSubscriptionReplay_SeeksToSnapshotOnStop(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = &pubsubpb.Subscription{Name: subscriptionName, Topic: "projects/proj-a/topics/orders"}
+	a := &subscriptionReplayAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "snapshot"}))
+	require.NoError(t, err)
+	assert.Equal(t, "projects/proj-a/snapshots/steadybit-replay-7f0c2a5e-1b1e-4c8e-9d1a-2b3c4d5e6f70", state.SnapshotName)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	require.Contains(t, api.snapshots, state.SnapshotName)
+	assert.Equal(t, "7f0c2a5e-1b1e-4c8e-9d1a-2b3c4d5e6f70", api.snapshots[state.SnapshotName].Labels[snapshotExecutionLabel])
+	assert.Empty(t, api.seeks)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.seeks, 1)
+	assert.Equal(t, state.SnapshotName, api.seeks[0].GetSnapshot())
+	assert.Empty(t, api.snapshots, "the snapshot is deleted after the seek")
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Len(t, api.seeks, 1, "a second stop is a no-op")
+}
+
+func TestSubscriptionReplay_KeepsSnapshotWhenSeekFails(t *testing.T) {
+	api := newFakeSubscriptions()
+	api.subscriptions[subscriptionName] = retainingSubscription()
+	a := &subscriptionReplayAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "snapshot"}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	api.seekErr = errors.New("permission denied")
+
+	_, err = a.Stop(context.Background(), &state)
+	require.Error(t, err)
+	assert.Contains(t, api.snapshots, state.SnapshotName)
+}
+
+func TestSubscriptionReplay_Prepare_Refusals(t *testing.T) {
+	api := newFakeSubscriptions()
+	a := &subscriptionReplayAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	api.subscriptions[subscriptionName] = &pubsubpb.Subscription{Name: subscriptionName}
+	_, err := a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "time", "lookback": 60000}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "neither retains acknowledged messages")
+
+	api.subscriptions[subscriptionName] = retainingSubscription()
+	_, err = a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "time", "lookback": 2 * 60 * 60 * 1000}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can only replay the last 1h0m0s")
+
+	_, err = a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "offset"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported replay mode")
+
+	api.subscriptions[subscriptionName].Detached = true
+	_, err = a.Prepare(context.Background(), &state, replayReq(map[string]interface{}{"replayFrom": "snapshot"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "detached")
+}
+
+func TestReplayWindow(t *testing.T) {
+	assert.Equal(t, time.Duration(0), replayWindow(&pubsubpb.Subscription{}))
+	assert.Equal(t, 7*24*time.Hour, replayWindow(&pubsubpb.Subscription{RetainAckedMessages: true}))
+	assert.Equal(t, 3*24*time.Hour, replayWindow(&pubsubpb.Subscription{TopicMessageRetentionDuration: durationpb.New(3 * 24 * time.Hour)}))
+	assert.Equal(t, 3*24*time.Hour, replayWindow(&pubsubpb.Subscription{
+		RetainAckedMessages:           true,
+		MessageRetentionDuration:      durationpb.New(time.Hour),
+		TopicMessageRetentionDuration: durationpb.New(3 * 24 * time.Hour),
+	}))
+}
@@ -67,7 +67,7 @@ func (d *subscriptionDiscovery) DescribeTarget() discovery_kit_api.TargetDescrip
 
 func (d *subscriptionDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
 	return []discovery_kit_api.AttributeDescription{
-		{Attribute: "gcp.pubsub.subscription.name", Label: discovery_kit_api.PluralLabel{One: "Pub/Sub subscription name", Other: "Pub/Sub subscription names"}},
+		{Attribute: attrSubscriptionName, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub subscription name", Other: "Pub/Sub subscription names"}},
 		{Attribute: attrSubscriptionTopic, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub subscription topic", Other: "Pub/Sub subscription topics"}},
 		{Attribute: attrSubscriptionDeliveryType, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub subscription delivery type", Other: "Pub/Sub subscription delivery types"}},
 		{Attribute: attrSubscriptionAckDeadlineSeconds, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub subscription ack deadline", Other: "Pub/Sub subscription ack deadlines"}},
@@ -124,7 +124,7 @@ func toSubscriptionTarget(s *pubsubpb.Subscription, projectID string) discovery_
 
 	attributes := make(map[string][]string)
 	attributes[attrProjectID] = []string{projectID}
-	attributes["gcp.pubsub.subscription.name"] = []string{name}
+	attributes[attrSubscriptionName] = []string{name}
 	if s.Topic != "" {
 		attributes[attrSubscriptionTopic] = []string{s.Topic}
 	}
@@ -0,0 +1,316 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+const (
+	// floodExecutionAttribute is set on every flood message, so consumers can recognize and discard them.
+	floodExecutionAttribute = "steadybit-execution-id"
+
+	maxFloodRate         = 10_000
+	maxFloodPayloadBytes = 1 << 20
+	maxFloodOrderingKeys = 1_000
+	// Publish requests are capped at 1,000 messages and 10 MB; batches stay below both.
+	maxPublishBatchMessages = 1_000
+	maxPublishBatchBytes    = 9 << 20
+)
+
+var floodTickInterval = 100 * time.Millisecond
+
+// floodRuns holds the publishers started by this extension instance, keyed by execution ID. The counters live here
+// rather than in the action state because the publisher keeps running between Status calls.
+var floodRuns sync.Map
+
+type floodRun struct {
+	cancel    context.CancelFunc
+	done      chan struct{}
+	published atomic.Int64
+	failed    atomic.Int64
+	lastError atomic.Value
+}
+
+// TopicFloodState describes the synthetic load. The attack adds messages to the topic and cannot take them back;
+// Stop ends publishing.
+type TopicFloodState struct {
+	ProjectID         string
+	TopicName         string
+	TopicID           string
+	ExecutionID       string
+	MessagesPerSecond int
+	PayloadBytes      int
+	OrderingKeys      int
+	Attributes        map[string]string
+}
+
+type topicFloodAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (topicsApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[TopicFloodState] = (*topicFloodAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[TopicFloodState] = (*topicFloodAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[TopicFloodState] = (*topicFloodAttack)(nil)
+
+func NewTopicFloodAction() action_kit_sdk.ActionWithStop[TopicFloodState] {
+	return &topicFloodAttack{clientProvider: newTopicsApi}
+}
+
+func (a *topicFloodAttack) NewEmptyState() TopicFloodState {
+	return TopicFloodState{}
+}
+
+func (a *topicFloodAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          TopicFloodActionId,
+		Label:       "Flood Pub/Sub topic",
+		Description: fmt.Sprintf("Publishes synthetic messages into a Pub/Sub topic at a fixed rate for the given duration, to test how subscribers scale with the backlog. Every message carries the attribute %s so consumers can discard it.", floodExecutionAttribute),
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDTopic,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by topic name",
+					Description: extutil.Ptr("Find Pub/Sub topic by name"),
+					Query:       attrTopicName + "=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Pub/Sub"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long messages are published."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "messagesPerSecond",
+				Label:        "Messages per second",
+				Description:  extutil.Ptr(fmt.Sprintf("Publish rate, up to %d. The achieved rate is reported while the attack runs and may stay below the target if publishing is slower.", maxFloodRate)),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("100"),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(maxFloodRate),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "payloadBytes",
+				Label:        "Payload size (bytes)",
+				Description:  extutil.Ptr("Size of each message's data, up to 1 MiB."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("1024"),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(maxFloodPayloadBytes),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "orderingKeys",
+				Label:        "Ordering keys",
+				Description:  extutil.Ptr("Spread the messages round-robin over this many ordering keys (steadybit-0, steadybit-1, ...). 0 publishes without ordering keys."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("0"),
+				MinValue:     extutil.Ptr(0),
+				MaxValue:     extutil.Ptr(maxFloodOrderingKeys),
+				Order:        extutil.Ptr(4),
+				Advanced:     extutil.Ptr(true),
+			},
+			{
+				Name:        "attributes",
+				Label:       "Message attributes",
+				Description: extutil.Ptr("Additional attributes set on every message."),
+				Type:        action_kit_api.ActionParameterTypeKeyValue,
+				Order:       extutil.Ptr(5),
+				Advanced:    extutil.Ptr(true),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("5s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *topicFloodAttack) Prepare(ctx context.Context, state *TopicFloodState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, attrProjectID)
+	state.TopicID = mustHave(request.Target.Attributes, attrTopicName)
+	if state.ProjectID == "" || state.TopicID == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.pubsub.topic.name", nil)
+	}
+	state.TopicName = fmt.Sprintf("projects/%s/topics/%s", state.ProjectID, state.TopicID)
+	state.ExecutionID = request.ExecutionId.String()
+	state.MessagesPerSecond = int(extutil.ToInt64(request.Config["messagesPerSecond"]))
+	state.PayloadBytes = int(extutil.ToInt64(request.Config["payloadBytes"]))
+	state.OrderingKeys = int(extutil.ToInt64(request.Config["orderingKeys"]))
+	if state.MessagesPerSecond < 1 || state.MessagesPerSecond > maxFloodRate {
+		return nil, extension_kit.ToError(fmt.Sprintf("Messages per second must be between 1 and %d, got %d", maxFloodRate, state.MessagesPerSecond), nil)
+	}
+	if state.PayloadBytes < 1 || state.PayloadBytes > maxFloodPayloadBytes {
+		return nil, extension_kit.ToError(fmt.Sprintf("Payload size must be between 1 and %d bytes, got %d", maxFloodPayloadBytes, state.PayloadBytes), nil)
+	}
+	if state.OrderingKeys < 0 || state.OrderingKeys > maxFloodOrderingKeys {
+		return nil, extension_kit.ToError(fmt.Sprintf("Ordering keys must be between 0 and %d, got %d", maxFloodOrderingKeys, state.OrderingKeys), nil)
+	}
+	state.Attributes = map[string]string{}
+	if request.Config["attributes"] != nil {
+		var err error
+		if state.Attributes, err = extutil.ToKeyValue(request.Config, "attributes"); err != nil {
+			return nil, extension_kit.ToError("Failed to parse the 'attributes' parameter", err)
+		}
+	}
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	if _, err := client.GetTopic(ctx, state.TopicName); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Pub/Sub topic %s", state.TopicID), err)
+	}
+	return nil, nil
+}
+
+func (a *topicFloodAttack) Start(_ context.Context, state *TopicFloodState) (*action_kit_api.StartResult, error) {
+	// The publisher outlives this request, so neither it nor its client may depend on the request context.
+	ctx, cancel := context.WithCancel(context.Background())
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		cancel()
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Pub/Sub client for project %s", state.ProjectID), err)
+	}
+	run := &floodRun{cancel: cancel, done: make(chan struct{})}
+	if _, loaded := floodRuns.LoadOrStore(state.ExecutionID, run); loaded {
+		cancel()
+		closer()
+		return nil, extension_kit.ToError(fmt.Sprintf("A flood of Pub/Sub topic %s is already running for this execution", state.TopicID), nil)
+	}
+	go func() {
+		defer close(run.done)
+		defer closer()
+		run.publish(ctx, client, *state)
+	}()
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Publishing %d messages/s of %d bytes to Pub/Sub topic %s", state.MessagesPerSecond, state.PayloadBytes, state.TopicID),
+		}}),
+	}, nil
+}
+
+func (a *topicFloodAttack) Status(_ context.Context, state *TopicFloodState) (*action_kit_api.StatusResult, error) {
+	value, ok := floodRuns.Load(state.ExecutionID)
+	if !ok {
+		return nil, extension_kit.ToError(fmt.Sprintf("No flood of Pub/Sub topic %s is running in this extension instance", state.TopicID), nil)
+	}
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages:  extutil.Ptr(value.(*floodRun).report(state)),
+	}, nil
+}
+
+func (a *topicFloodAttack) Stop(_ context.Context, state *TopicFloodState) (*action_kit_api.StopResult, error) {
+	value, ok := floodRuns.LoadAndDelete(state.ExecutionID)
+	if !ok {
+		return nil, nil
+	}
+	run := value.(*floodRun)
+	run.cancel()
+	<-run.done
+	if run.published.Load() == 0 && run.failed.Load() > 0 {
+		log.Error().Msgf("Failed to publish any message to Pub/Sub topic %s: %s", state.TopicID, run.lastError.Load())
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to publish any of %d messages to Pub/Sub topic %s: %s", run.failed.Load(), state.TopicID, run.lastError.Load()), nil)
+	}
+	return &action_kit_api.StopResult{Messages: extutil.Ptr(run.report(state))}, nil
+}
+
+// publish sends batches until ctx is cancelled. Each tick it catches up to the number of messages due since the start,
+// so a slow publish is made up for in the following ticks instead of lowering the rate for good.
+func (r *floodRun) publish(ctx context.Context, client topicsApi, state TopicFloodState) {
+	payload := bytes.Repeat([]byte("steadybit"), state.PayloadBytes/9+1)[:state.PayloadBytes]
+	batchSize := min(maxPublishBatchMessages, max(1, maxPublishBatchBytes/state.PayloadBytes))
+	start := time.Now()
+	ticker := time.NewTicker(floodTickInterval)
+	defer ticker.Stop()
+	var sent int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		due := int64(float64(state.MessagesPerSecond)*time.Since(start).Seconds()) - sent
+		for due > 0 && ctx.Err() == nil {
+			n := min(due, int64(batchSize))
+			messages := make([]*pubsubpb.PubsubMessage, n)
+			for i := range messages {
+				messages[i] = floodMessage(state, payload, sent+int64(i))
+			}
+			err := client.Publish(ctx, state.TopicName, messages)
+			if err != nil && ctx.Err() != nil {
+				// Cancelled by Stop; the batch may or may not have been accepted.
+				return
+			}
+			sent += n
+			due -= n
+			if err != nil {
+				r.failed.Add(n)
+				r.lastError.Store(err.Error())
+				continue
+			}
+			r.published.Add(n)
+		}
+	}
+}
+
+func floodMessage(state TopicFloodState, payload []byte, seq int64) *pubsubpb.PubsubMessage {
+	attributes := maps.Clone(state.Attributes)
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	attributes[floodExecutionAttribute] = state.ExecutionID
+	message := &pubsubpb.PubsubMessage{Data: payload, Attributes: attributes}
+	if state.OrderingKeys > 0 {
+		message.OrderingKey = fmt.Sprintf("steadybit-%d", seq%int64(state.OrderingKeys))
+	}
+	return message
+}
+
+func (r *floodRun) report(state *TopicFloodState) []action_kit_api.Message {
+	messages := []action_kit_api.Message{{
+		Level:   extutil.Ptr(action_kit_api.Info),
+		Message: fmt.Sprintf("Published %d messages to Pub/Sub topic %s, %d failed", r.published.Load(), state.TopicID, r.failed.Load()),
+	}}
+	if lastError := r.lastError.Load(); lastError != nil {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Warn),
+			Message: fmt.Sprintf("Last publish error: %s", lastError),
+		})
+	}
+	return messages
+}
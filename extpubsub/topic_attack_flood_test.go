@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extpubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const topicName = "projects/proj-a/topics/orders"
+
+func floodReq(cfg map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	req := extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: map[string][]string{
+			attrProjectID: {"proj-a"},
+			attrTopicName: {"orders"},
+		}}),
+		Config: cfg,
+	})
+	req.ExecutionId = uuid.New()
+	return req
+}
+
+func useFastFloodTicks(t *testing.T) {
+	previous := floodTickInterval
+	floodTickInterval = 5 * time.Millisecond
+	t.Cleanup(func() { floodTickInterval = previous })
+}
+
+func TestTopicFlood_PublishesTaggedMessagesUntilStopped(t *testing.T) {
+	useFastFloodTicks(t)
+	api := &fakeTopics{topic: &pubsubpb.Topic{Name: topicName}}
+	a := &topicFloodAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, floodReq(map[string]interface{}{
+		"messagesPerSecond": 1000,
+		"payloadBytes":      16,
+		"orderingKeys":      3,
+		"attributes":        []interface{}{map[string]interface{}{"key": "source", "value": "chaos"}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, topicName, state.TopicName)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return api.count() >= 10 }, 5*time.Second, 5*time.Millisecond)
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.Contains(t, (*status.Messages)[0].Message, "Published")
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	stopped := api.count()
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	keys := map[string]bool{}
+	for _, m := range api.published {
+		assert.Len(t, m.Data, 16)
+		assert.Equal(t, state.ExecutionID, m.Attributes[floodExecutionAttribute])
+		assert.Equal(t, "chaos", m.Attributes["source"])
+		keys[m.OrderingKey] = true
+	}
+	assert.Equal(t, map[string]bool{"steadybit-0": true, "steadybit-1": true, "steadybit-2": true}, keys)
+	assert.Equal(t, stopped, len(api.published), "nothing is published after stop")
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err, "a second stop is a no-op")
+}
+
+func TestTopicFlood_StopFailsWhenNothingWasPublished(t *testing.T) {
+	useFastFloodTicks(t)
+	api := &fakeTopics{topic: &pubsubpb.Topic{Name: topicName}, publishErr: errors.New("permission denied")}
+	a := &topicFloodAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, floodReq(map[string]interface{}{"messagesPerSecond": 1000, "payloadBytes": 16}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		status, err := a.Status(context.Background(), &state)
+		return err == nil && len(*status.Messages) == 2
+	}, 5*time.Second, 5*time.Millisecond)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestTopicFlood_Prepare_Refusals(t *testing.T) {
+	api := &fakeTopics{topic: &pubsubpb.Topic{Name: topicName}}
+	a := &topicFloodAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, floodReq(map[string]interface{}{"messagesPerSecond": 0, "payloadBytes": 16}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Messages per second")
+
+	_, err = a.Prepare(context.Background(), &state, floodReq(map[string]interface{}{"messagesPerSecond": 10, "payloadBytes": maxFloodPayloadBytes + 1}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Payload size")
+
+	api.topic = &pubsubpb.Topic{Name: "projects/proj-a/topics/other"}
+	_, err = a.Prepare(context.Background(), &state, floodReq(map[string]interface{}{"messagesPerSecond": 10, "payloadBytes": 16}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to get Pub/Sub topic orders")
+}
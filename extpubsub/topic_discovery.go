@@ -66,7 +66,7 @@ func (d *topicDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
 
 func (d *topicDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
 	return []discovery_kit_api.AttributeDescription{
-		{Attribute: "gcp.pubsub.topic.name", Label: discovery_kit_api.PluralLabel{One: "Pub/Sub topic name", Other: "Pub/Sub topic names"}},
+		{Attribute: attrTopicName, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub topic name", Other: "Pub/Sub topic names"}},
 		{Attribute: attrTopicMessageRetentionDuration, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub topic message retention", Other: "Pub/Sub topic message retentions"}},
 		{Attribute: attrTopicKmsKeyName, Label: discovery_kit_api.PluralLabel{One: "Pub/Sub topic KMS key", Other: "Pub/Sub topic KMS keys"}},
 		{Attribute: "gcp.pubsub.topic.message-storage-policy.persistence-regions", Label: discovery_kit_api.PluralLabel{One: "Pub/Sub topic allowed region", Other: "Pub/Sub topic allowed regions"}},
@@ -115,7 +115,7 @@ func toTopicTarget(t *pubsubpb.Topic, projectID string) discovery_kit_api.Target
 
 	attributes := make(map[string][]string)
 	attributes[attrProjectID] = []string{projectID}
-	attributes["gcp.pubsub.topic.name"] = []string{name}
+	attributes[attrTopicName] = []string{name}
 	if t.MessageRetentionDuration != nil {
 		attributes[attrTopicMessageRetentionDuration] = []string{t.MessageRetentionDuration.AsDuration().String()}
 	}
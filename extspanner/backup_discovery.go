@@ -0,0 +1,152 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+type backupDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*backupDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*backupDiscovery)(nil)
+)
+
+func NewBackupDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&backupDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *backupDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDBackup,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *backupDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDBackup,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Spanner backup", Other: "Spanner backups"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrDatabaseName},
+				{Attribute: attrBackupState},
+				{Attribute: "gcp.spanner.backup.version-time"},
+				{Attribute: "gcp.spanner.backup.expire-time"},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *backupDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrBackupName, Label: discovery_kit_api.PluralLabel{One: "Spanner backup name", Other: "Spanner backup names"}},
+		{Attribute: attrBackupState, Label: discovery_kit_api.PluralLabel{One: "Spanner backup state", Other: "Spanner backup states"}},
+		{Attribute: "gcp.spanner.backup.create-time", Label: discovery_kit_api.PluralLabel{One: "Spanner backup creation time", Other: "Spanner backup creation times"}},
+		{Attribute: "gcp.spanner.backup.version-time", Label: discovery_kit_api.PluralLabel{One: "Spanner backup version time", Other: "Spanner backup version times"}},
+		{Attribute: "gcp.spanner.backup.expire-time", Label: discovery_kit_api.PluralLabel{One: "Spanner backup expiry time", Other: "Spanner backup expiry times"}},
+		{Attribute: "gcp.spanner.backup.size-bytes", Label: discovery_kit_api.PluralLabel{One: "Spanner backup size", Other: "Spanner backup sizes"}},
+		{Attribute: "gcp.spanner.backup.incremental", Label: discovery_kit_api.PluralLabel{One: "Spanner incremental backup", Other: "Spanner incremental backups"}},
+		{Attribute: "gcp.spanner.backup.backup-schedule", Label: discovery_kit_api.PluralLabel{One: "Spanner backup schedule", Other: "Spanner backup schedules"}},
+	}
+}
+
+func (d *backupDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		instances, err := instance.NewInstanceAdminClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Spanner instance admin client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = instances.Close() }()
+		databases, err := database.NewDatabaseAdminClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Spanner database admin client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = databases.Close() }()
+		return getAllBackups(ctx, instances, databases, access.ProjectID)
+	}, ctx, "spanner-backup")
+}
+
+func getAllBackups(ctx context.Context, instances *instance.InstanceAdminClient, databases *database.DatabaseAdminClient, projectID string) ([]discovery_kit_api.Target, error) {
+	instanceNames, err := listInstanceNames(ctx, instances, projectID)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]discovery_kit_api.Target, 0)
+	for _, instanceName := range instanceNames {
+		backups, err := listBackups(ctx, databases, instanceName)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range backups {
+			targets = append(targets, toBackupTarget(b, projectID))
+		}
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesSpannerDatabase), nil
+}
+
+func toBackupTarget(b *databasepb.Backup, projectID string) discovery_kit_api.Target {
+	// b.Name is "projects/<p>/instances/<instance>/backups/<name>"
+	instanceID := parentSegment(b.Name, "instances")
+	name := lastSegment(b.Name)
+
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrInstanceName] = []string{instanceID}
+	attributes[attrBackupName] = []string{name}
+	if b.Database != "" {
+		attributes[attrDatabaseName] = []string{lastSegment(b.Database)}
+	}
+	if b.State != databasepb.Backup_STATE_UNSPECIFIED {
+		attributes[attrBackupState] = []string{b.State.String()}
+	}
+	if b.CreateTime != nil {
+		attributes["gcp.spanner.backup.create-time"] = []string{b.CreateTime.AsTime().UTC().Format(time.RFC3339)}
+	}
+	if b.VersionTime != nil {
+		attributes["gcp.spanner.backup.version-time"] = []string{b.VersionTime.AsTime().UTC().Format(time.RFC3339)}
+	}
+	if b.ExpireTime != nil {
+		attributes["gcp.spanner.backup.expire-time"] = []string{b.ExpireTime.AsTime().UTC().Format(time.RFC3339)}
+	}
+	if b.SizeBytes > 0 {
+		attributes["gcp.spanner.backup.size-bytes"] = []string{strconv.FormatInt(b.SizeBytes, 10)}
+	}
+	attributes["gcp.spanner.backup.incremental"] = []string{strconv.FormatBool(b.IncrementalBackupChainId != "")}
+	for _, s := range b.BackupSchedules {
+		attributes["gcp.spanner.backup.backup-schedule"] = append(attributes["gcp.spanner.backup.backup-schedule"], lastSegment(s))
+	}
+
+	return discovery_kit_api.Target{
+		Id:         b.Name,
+		TargetType: TargetIDBackup,
+		Label:      fmt.Sprintf("%s/%s", instanceID, name),
+		Attributes: attributes,
+	}
+}
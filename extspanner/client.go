@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/steadybit/extension-gcp/utils"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// instancesApi is the part of the Spanner instance admin API the instance attacks use. UpdateInstance hands back the
+// name of its long-running operation so Status and Stop can follow an update started in an earlier call.
+type instancesApi interface {
+	GetInstance(ctx context.Context, name string) (*instancepb.Instance, error)
+	// UpdateInstance writes only the given fields of inst.
+	UpdateInstance(ctx context.Context, inst *instancepb.Instance, paths ...string) (string, error)
+	// PollUpdate reports whether the operation is done. An error with done=true is the operation's own failure;
+	// with done=false the poll itself failed.
+	PollUpdate(ctx context.Context, operation string) (bool, error)
+}
+
+type instanceAdminClient struct {
+	client *instance.InstanceAdminClient
+}
+
+func newInstancesApi(ctx context.Context, projectID string) (instancesApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := instance.NewInstanceAdminClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &instanceAdminClient{client: c}, func() { _ = c.Close() }, nil
+}
+
+func (c *instanceAdminClient) GetInstance(ctx context.Context, name string) (*instancepb.Instance, error) {
+	return c.client.GetInstance(ctx, &instancepb.GetInstanceRequest{Name: name})
+}
+
+func (c *instanceAdminClient) UpdateInstance(ctx context.Context, inst *instancepb.Instance, paths ...string) (string, error) {
+	op, err := c.client.UpdateInstance(ctx, &instancepb.UpdateInstanceRequest{
+		Instance:  inst,
+		FieldMask: &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *instanceAdminClient) PollUpdate(ctx context.Context, operation string) (bool, error) {
+	op := c.client.UpdateInstanceOperation(operation)
+	_, err := op.Poll(ctx)
+	return op.Done(), err
+}
+
+// databasesApi is the part of the Spanner database and instance admin APIs the database attacks use. The instance
+// config tells which regions may lead the database.
+type databasesApi interface {
+	GetDatabase(ctx context.Context, name string) (*databasepb.Database, error)
+	GetInstance(ctx context.Context, name string) (*instancepb.Instance, error)
+	GetInstanceConfig(ctx context.Context, name string) (*instancepb.InstanceConfig, error)
+	// UpdateDatabaseDdl runs the statements and hands back the name of their long-running operation.
+	UpdateDatabaseDdl(ctx context.Context, database string, statements ...string) (string, error)
+	// PollDdl reports whether the operation is done, like instancesApi.PollUpdate.
+	PollDdl(ctx context.Context, operation string) (bool, error)
+}
+
+type databaseAdminClient struct {
+	databases *database.DatabaseAdminClient
+	instances *instance.InstanceAdminClient
+}
+
+func newDatabasesApi(ctx context.Context, projectID string) (databasesApi, func(), error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	d, err := database.NewDatabaseAdminClient(ctx, access.ClientOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	i, err := instance.NewInstanceAdminClient(ctx, access.ClientOptions...)
+	if err != nil {
+		_ = d.Close()
+		return nil, nil, err
+	}
+	return &databaseAdminClient{databases: d, instances: i}, func() { _ = d.Close(); _ = i.Close() }, nil
+}
+
+func (c *databaseAdminClient) GetDatabase(ctx context.Context, name string) (*databasepb.Database, error) {
+	return c.databases.GetDatabase(ctx, &databasepb.GetDatabaseRequest{Name: name})
+}
+
+func (c *databaseAdminClient) GetInstance(ctx context.Context, name string) (*instancepb.Instance, error) {
+	return c.instances.GetInstance(ctx, &instancepb.GetInstanceRequest{Name: name})
+}
+
+func (c *databaseAdminClient) GetInstanceConfig(ctx context.Context, name string) (*instancepb.InstanceConfig, error) {
+	return c.instances.GetInstanceConfig(ctx, &instancepb.GetInstanceConfigRequest{Name: name})
+}
+
+func (c *databaseAdminClient) UpdateDatabaseDdl(ctx context.Context, database string, statements ...string) (string, error) {
+	op, err := c.databases.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{Database: database, Statements: statements})
+	if err != nil {
+		return "", err
+	}
+	return op.Name(), nil
+}
+
+func (c *databaseAdminClient) PollDdl(ctx context.Context, operation string) (bool, error) {
+	op := c.databases.UpdateDatabaseDdlOperation(operation)
+	err := op.Poll(ctx)
+	return op.Done(), err
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/protobuf/proto"
+)
+
+const instanceName = "projects/proj-a/instances/prod"
+
+func instanceReq(cfg map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: map[string][]string{
+			attrProjectID:    {"proj-a"},
+			attrInstanceName: {"prod"},
+		}}),
+		Config: cfg,
+	})
+}
+
+// fakeInstances serves instances from a map and applies the masked fields of an update immediately. Operations
+// finish on their first poll unless pendingPolls says otherwise; opErrors fails them once done.
+type fakeInstances struct {
+	instances    map[string]*instancepb.Instance
+	pendingPolls map[string]int
+	opErrors     map[string]error
+	updates      [][]string
+	nextOp       int
+}
+
+func newFakeInstances() *fakeInstances {
+	return &fakeInstances{
+		instances:    map[string]*instancepb.Instance{},
+		pendingPolls: map[string]int{},
+		opErrors:     map[string]error{},
+	}
+}
+
+func (f *fakeInstances) provider() func(context.Context, string) (instancesApi, func(), error) {
+	return func(context.Context, string) (instancesApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeInstances) GetInstance(_ context.Context, name string) (*instancepb.Instance, error) {
+	if inst, ok := f.instances[name]; ok {
+		return proto.Clone(inst).(*instancepb.Instance), nil
+	}
+	return nil, fmt.Errorf("instance %s not found", name)
+}
+
+func (f *fakeInstances) UpdateInstance(_ context.Context, inst *instancepb.Instance, paths ...string) (string, error) {
+	stored, ok := f.instances[inst.Name]
+	if !ok {
+		return "", fmt.Errorf("instance %s not found", inst.Name)
+	}
+	f.updates = append(f.updates, paths)
+	for _, p := range paths {
+		switch p {
+		case "processing_units":
+			stored.ProcessingUnits = inst.ProcessingUnits
+			stored.NodeCount = inst.ProcessingUnits / 1000
+		case "autoscaling_config":
+			stored.AutoscalingConfig = proto.Clone(inst.AutoscalingConfig).(*instancepb.AutoscalingConfig)
+		default:
+			return "", fmt.Errorf("unsupported update path %s", p)
+		}
+	}
+	f.nextOp++
+	return fmt.Sprintf("operations/op-%d", f.nextOp), nil
+}
+
+func (f *fakeInstances) PollUpdate(_ context.Context, operation string) (bool, error) {
+	if f.pendingPolls[operation] > 0 {
+		f.pendingPolls[operation]--
+		return false, nil
+	}
+	return true, f.opErrors[operation]
+}
+
+func withFastOperationPolling(t *testing.T) {
+	previous := utils.OperationPollInterval
+	utils.OperationPollInterval = time.Millisecond
+	t.Cleanup(func() { utils.OperationPollInterval = previous })
+}
+
+// fakeDatabases serves one instance with its config and applies default_leader DDL to the databases map. Operations
+// finish on their first poll unless pendingPolls says otherwise.
+type fakeDatabases struct {
+	databases      map[string]*databasepb.Database
+	instance       *instancepb.Instance
+	instanceConfig *instancepb.InstanceConfig
+	pendingPolls   map[string]int
+	statements     []string
+	nextOp         int
+}
+
+func newFakeDatabases() *fakeDatabases {
+	return &fakeDatabases{databases: map[string]*databasepb.Database{}, pendingPolls: map[string]int{}}
+}
+
+func (f *fakeDatabases) provider() func(context.Context, string) (databasesApi, func(), error) {
+	return func(context.Context, string) (databasesApi, func(), error) { return f, func() {}, nil }
+}
+
+func (f *fakeDatabases) GetDatabase(_ context.Context, name string) (*databasepb.Database, error) {
+	if db, ok := f.databases[name]; ok {
+		return proto.Clone(db).(*databasepb.Database), nil
+	}
+	return nil, fmt.Errorf("database %s not found", name)
+}
+
+func (f *fakeDatabases) GetInstance(_ context.Context, name string) (*instancepb.Instance, error) {
+	if f.instance == nil || f.instance.Name != name {
+		return nil, fmt.Errorf("instance %s not found", name)
+	}
+	return proto.Clone(f.instance).(*instancepb.Instance), nil
+}
+
+func (f *fakeDatabases) GetInstanceConfig(_ context.Context, name string) (*instancepb.InstanceConfig, error) {
+	if f.instanceConfig == nil || f.instanceConfig.Name != name {
+		return nil, fmt.Errorf("instance config %s not found", name)
+	}
+	return proto.Clone(f.instanceConfig).(*instancepb.InstanceConfig), nil
+}
+
+var defaultLeaderPattern = regexp.MustCompile(`default_leader(?: = '([a-z0-9-]+)'| = NULL)|RESET spanner\.default_leader`)
+
+func (f *fakeDatabases) UpdateDatabaseDdl(_ context.Context, database string, statements ...string) (string, error) {
+	db, ok := f.databases[database]
+	if !ok {
+		return "", fmt.Errorf("database %s not found", database)
+	}
+	for _, s := range statements {
+		m := defaultLeaderPattern.FindStringSubmatch(s)
+		if m == nil {
+			return "", fmt.Errorf("unsupported statement %s", s)
+		}
+		f.statements = append(f.statements, s)
+		db.DefaultLeader = m[1]
+	}
+	f.nextOp++
+	return fmt.Sprintf("operations/ddl-%d", f.nextOp), nil
+}
+
+func (f *fakeDatabases) PollDdl(_ context.Context, operation string) (bool, error) {
+	if f.pendingPolls[operation] > 0 {
+		f.pendingPolls[operation]--
+		return false, nil
+	}
+	return true, nil
+}
@@ -5,12 +5,24 @@
 package extspanner
 
 const (
-	TargetIDInstance = "com.steadybit.extension_gcp.spanner.instance"
-	targetIcon       = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMzMxLjIsMzEwLjZsLTU5LjUtMzQuOXYtODMuNGMuMS04LjgtNy0xNi0xNS45LTE2aDBjLTguOCwwLTE2LDcuMS0xNiwxNnY4NGMtLjEsMC01OS4xLDM0LjMtNTkuMSwzNC4zLTcuNiw0LjUtMTAuMiwxNC4yLTUuOCwyMS45LDMsNS4xLDguMyw4LDEzLjgsOHM1LjUtLjcsOC0yLjJsNTkuNC0zNC42LDU4LjcsMzQuNWMyLjUsMS41LDUuMywyLjIsOC4xLDIuMiw1LjUsMCwxMC44LTIuOCwxMy44LTcuOSw0LjUtNy42LDEuOS0xNy40LTUuNy0yMS45aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTI3MS45LDIxNS4xbC05MS44LTUyLjljLTQuOS0yLjktOC04LjEtOC0xMy45di03OC4zYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NjkuMWw1MS44LDI5LjksNTEuOC0yOS45di02OS4xYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NzguM2MwLDUuNy0zLDExLTgsMTMuOWwtNTkuOCwzNC41djE4LjRaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEyMS4zLDQ1OGMtNS41LDAtMTAuOS0yLjktMTMuOS04LTQuNC03LjYtMS44LTE3LjQsNS44LTIxLjlsNTkuNy0zNC42di01OS44Yy0uMSwwLTUyLTI5LjgtNTItMjkuOGwtNTkuNywzNC42Yy03LjYsNC40LTE3LjQsMS44LTIxLjktNS44LTQuNC03LjYtMS44LTE3LjQsNS44LTIxLjlsNjcuNy0zOS4zYzQuOS0yLjksMTEtMi45LDE2LDBsNTkuOSwzNC40LDE2LjEtOS4zdjEwNi4xYy4yLDUuNy0yLjksMTEtNy44LDEzLjlsLTY3LjcsMzkuM2MtMi41LDEuNS01LjMsMi4yLTgsMi4yaDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTM5MC43LDQ1OGMtMi43LDAtNS41LS43LTgtMi4ybC02Ny43LTM5LjNjLTUtMi45LTgtOC4yLTgtMTMuOXYtNjkuMWMuMSwwLTE2LjMtOS42LTE2LjMtOS42bDkyLjMtNTIuNGM1LTIuOSwxMS4xLTIuOCwxNiwwbDY3LjcsMzkuM2M3LjYsNC40LDEwLjIsMTQuMiw1LjgsMjEuOS00LjQsNy42LTE0LjIsMTAuMi0yMS45LDUuOGwtNTkuNy0zNC42LTUxLjksMjkuOHY1OS44Yy0uMSwwLDU5LjYsMzQuNiw1OS42LDM0LjYsNy42LDQuNCwxMC4yLDE0LjIsNS44LDIxLjktMyw1LjEtOC4zLDgtMTMuOSw4aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+Cjwvc3ZnPg=="
+	TargetIDInstance                  = "com.steadybit.extension_gcp.spanner.instance"
+	TargetIDDatabase                  = "com.steadybit.extension_gcp.spanner.database"
+	TargetIDBackup                    = "com.steadybit.extension_gcp.spanner.backup"
+	InstanceCapacityReductionActionId = "com.steadybit.extension_gcp.spanner.instance.capacity-reduction"
+	DatabaseLeaderRelocationActionId  = "com.steadybit.extension_gcp.spanner.database.leader-relocation"
+	targetIcon                        = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMzMxLjIsMzEwLjZsLTU5LjUtMzQuOXYtODMuNGMuMS04LjgtNy0xNi0xNS45LTE2aDBjLTguOCwwLTE2LDcuMS0xNiwxNnY4NGMtLjEsMC01OS4xLDM0LjMtNTkuMSwzNC4zLTcuNiw0LjUtMTAuMiwxNC4yLTUuOCwyMS45LDMsNS4xLDguMyw4LDEzLjgsOHM1LjUtLjcsOC0yLjJsNTkuNC0zNC42LDU4LjcsMzQuNWMyLjUsMS41LDUuMywyLjIsOC4xLDIuMiw1LjUsMCwxMC44LTIuOCwxMy44LTcuOSw0LjUtNy42LDEuOS0xNy40LTUuNy0yMS45aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTI3MS45LDIxNS4xbC05MS44LTUyLjljLTQuOS0yLjktOC04LjEtOC0xMy45di03OC4zYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NjkuMWw1MS44LDI5LjksNTEuOC0yOS45di02OS4xYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NzguM2MwLDUuNy0zLDExLTgsMTMuOWwtNTkuOCwzNC41djE4LjRaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEyMS4zLDQ1OGMtNS41LDAtMTAuOS0yLjktMTMuOS04LTQuNC03LjYtMS44LTE3LjQsNS44LTIxLjlsNTkuNy0zNC42di01OS44Yy0uMSwwLTUyLTI5LjgtNTItMjkuOGwtNTkuNywzNC42Yy03LjYsNC40LTE3LjQsMS44LTIxLjktNS44LTQuNC03LjYtMS44LTE3LjQsNS44LTIxLjlsNjcuNy0zOS4zYzQuOS0yLjksMTEtMi45LDE2LDBsNTkuOSwzNC40LDE2LjEtOS4zdjEwNi4xYy4yLDUuNy0yLjksMTEtNy44LDEzLjlsLTY3LjcsMzkuM2MtMi41LDEuNS01LjMsMi4yLTgsMi4yaDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTM5MC43LDQ1OGMtMi43LDAtNS41LS43LTgtMi4ybC02Ny43LTM5LjNjLTUtMi45LTgtOC4yLTgtMTMuOXYtNjkuMWMuMSwwLTE2LjMtOS42LTE2LjMtOS42bDkyLjMtNTIuNGM1LTIuOSwxMS4xLTIuOCwxNiwwbDY3LjcsMzkuM2M3LjYsNC40LDEwLjIsMTQuMiw1LjgsMjEuOS00LjQsNy42LTE0LjIsMTAuMi0yMS45LDUuOGwtNTkuNy0zNC42LTUxLjksMjkuOHY1OS44Yy0uMSwwLDU5LjYsMzQuNiw1OS42LDM0LjYsNy42LDQuNCwxMC4yLDE0LjIsNS44LDIxLjktMyw1LjEtOC4zLDgtMTMuOSw4aDBaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+Cjwvc3ZnPg=="
 
 	// Attribute names extracted per Sonar go:S1192.
+	attrInstanceName    = "gcp.spanner.instance.name"
 	attrConfig          = "gcp.spanner.instance.config"
 	attrEdition         = "gcp.spanner.instance.edition"
 	attrProcessingUnits = "gcp.spanner.instance.processing-units"
 	attrProjectID       = "gcp.project.id"
+
+	attrDatabaseName          = "gcp.spanner.database.name"
+	attrDatabaseDialect       = "gcp.spanner.database.dialect"
+	attrDatabaseState         = "gcp.spanner.database.state"
+	attrDatabaseDefaultLeader = "gcp.spanner.database.default-leader"
+	attrBackupName            = "gcp.spanner.backup.name"
+	attrBackupState           = "gcp.spanner.backup.state"
 )
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// LeaderRelocationState records the default leader found in Prepare. The attack is reversible: Stop writes the
+// original default_leader option back, or resets it when the database had none set explicitly.
+type LeaderRelocationState struct {
+	ProjectID       string
+	InstanceID      string
+	DatabaseID      string
+	DatabaseName    string
+	Dialect         string
+	Leader          string
+	OriginalLeader  string
+	EffectiveLeader string
+	UpdateOperation string
+	UpdateReported  bool
+}
+
+type leaderRelocationAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (databasesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[LeaderRelocationState] = (*leaderRelocationAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[LeaderRelocationState] = (*leaderRelocationAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[LeaderRelocationState] = (*leaderRelocationAttack)(nil)
+
+func NewDatabaseLeaderRelocationAction() action_kit_sdk.ActionWithStop[LeaderRelocationState] {
+	return &leaderRelocationAttack{clientProvider: newDatabasesApi}
+}
+
+func (a *leaderRelocationAttack) NewEmptyState() LeaderRelocationState {
+	return LeaderRelocationState{}
+}
+
+func (a *leaderRelocationAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          DatabaseLeaderRelocationActionId,
+		Label:       "Relocate Spanner leader",
+		Description: "Moves the default leader of a Spanner database in a multi-region instance config to another read-write region for the given duration, as happens during a regional incident. Writes then pay the round trip to the new leader region. The original leader is restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDDatabase,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by database name",
+					Description: extutil.Ptr("Find Spanner database by instance and database name"),
+					Query:       attrInstanceName + "=\"\" and " + attrDatabaseName + "=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Spanner"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the leader stays relocated. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "leader",
+				Label:       "New leader region",
+				Description: extutil.Ptr("Read-write region of the instance config that becomes the default leader, e.g. us-east4. Leave empty to pick the first leader option other than the current leader."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       extutil.Ptr(2),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("10s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *leaderRelocationAttack) Prepare(ctx context.Context, state *LeaderRelocationState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, attrProjectID)
+	state.InstanceID = mustHave(request.Target.Attributes, attrInstanceName)
+	state.DatabaseID = mustHave(request.Target.Attributes, attrDatabaseName)
+	if state.ProjectID == "" || state.InstanceID == "" || state.DatabaseID == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.spanner.instance.name, gcp.spanner.database.name", nil)
+	}
+	instanceName := fmt.Sprintf("projects/%s/instances/%s", state.ProjectID, state.InstanceID)
+	state.DatabaseName = fmt.Sprintf("%s/databases/%s", instanceName, state.DatabaseID)
+	state.Leader = strings.TrimSpace(extutil.ToString(request.Config["leader"]))
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Spanner client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	db, err := client.GetDatabase(ctx, state.DatabaseName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Spanner database %s", state.DatabaseID), err)
+	}
+	if db.State != databasepb.Database_READY {
+		return nil, extension_kit.ToError(fmt.Sprintf("Spanner database %s is %s, not READY", state.DatabaseID, db.State), nil)
+	}
+	inst, err := client.GetInstance(ctx, instanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Spanner instance %s", state.InstanceID), err)
+	}
+	instanceConfig, err := client.GetInstanceConfig(ctx, inst.Config)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Spanner instance config %s", lastSegment(inst.Config)), err)
+	}
+
+	state.Dialect = db.DatabaseDialect.String()
+	state.OriginalLeader = db.DefaultLeader
+	state.EffectiveLeader = db.DefaultLeader
+	if state.EffectiveLeader == "" {
+		for _, r := range instanceConfig.Replicas {
+			if r.DefaultLeaderLocation {
+				state.EffectiveLeader = r.Location
+			}
+		}
+	}
+	alternatives := slices.DeleteFunc(slices.Clone(instanceConfig.LeaderOptions), func(l string) bool { return l == state.EffectiveLeader })
+	if len(alternatives) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Spanner instance config %s offers no leader region other than %q; only multi-region configs can relocate the leader", lastSegment(inst.Config), state.EffectiveLeader), nil)
+	}
+	if state.Leader == "" {
+		state.Leader = alternatives[0]
+	} else if !slices.Contains(alternatives, state.Leader) {
+		return nil, extension_kit.ToError(fmt.Sprintf("Region %s is not an alternative leader of Spanner database %s; choose one of: %s", state.Leader, state.DatabaseID, strings.Join(alternatives, ", ")), nil)
+	}
+	return nil, nil
+}
+
+func (a *leaderRelocationAttack) Start(ctx context.Context, state *LeaderRelocationState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Spanner client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	state.UpdateOperation, err = client.UpdateDatabaseDdl(ctx, state.DatabaseName, defaultLeaderStatement(state.Dialect, state.DatabaseID, state.Leader))
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to relocate the leader of Spanner database %s", state.DatabaseID), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Moving the default leader of Spanner database %s from %s to %s", state.DatabaseID, state.EffectiveLeader, state.Leader),
+		}}),
+	}, nil
+}
+
+// Status reports once the default_leader change is applied. The new leader region then stays in effect until Stop
+// writes the original one back.
+func (a *leaderRelocationAttack) Status(ctx context.Context, state *LeaderRelocationState) (*action_kit_api.StatusResult, error) {
+	if state.UpdateReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Spanner client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollDdl(ctx, state.UpdateOperation)
+	if result, err := utils.OperationStatus(done, err, "Spanner", fmt.Sprintf("Leader relocation of Spanner database %s failed", state.DatabaseID)); result != nil || err != nil {
+		return result, err
+	}
+	state.UpdateReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Spanner database %s is led from %s", state.DatabaseID, state.Leader),
+		}}),
+	}, nil
+}
+
+func (a *leaderRelocationAttack) Stop(ctx context.Context, state *LeaderRelocationState) (*action_kit_api.StopResult, error) {
+	if state.UpdateOperation == "" {
+		return nil, nil
+	}
+	if err := a.restoreLeader(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore the leader of Spanner database %s", state.DatabaseID)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to move the leader of Spanner database %s back to %s", state.DatabaseID, state.EffectiveLeader), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Default leader of Spanner database %s restored to %s", state.DatabaseID, state.EffectiveLeader),
+		}}),
+	}, nil
+}
+
+// restoreLeader waits for the relocation first, as Spanner runs schema updates of a database one after another.
+func (a *leaderRelocationAttack) restoreLeader(ctx context.Context, state *LeaderRelocationState) error {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	if err := utils.WaitForOperation(ctx, client.PollDdl, state.UpdateOperation); err != nil {
+		log.Warn().Err(err).Msgf("Leader relocation of Spanner database %s did not complete cleanly", state.DatabaseID)
+	}
+	db, err := client.GetDatabase(ctx, state.DatabaseName)
+	if err != nil {
+		return err
+	}
+	if db.DefaultLeader == state.OriginalLeader {
+		return nil
+	}
+	op, err := client.UpdateDatabaseDdl(ctx, state.DatabaseName, defaultLeaderStatement(state.Dialect, state.DatabaseID, state.OriginalLeader))
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, client.PollDdl, op)
+}
+
+// defaultLeaderStatement builds the DDL setting the default_leader option in the database's dialect. An empty
+// leader resets the option, handing leadership back to the instance config's default.
+func defaultLeaderStatement(dialect, databaseID, leader string) string {
+	if dialect == databasepb.DatabaseDialect_POSTGRESQL.String() {
+		if leader == "" {
+			return fmt.Sprintf(`ALTER DATABASE "%s" RESET spanner.default_leader`, databaseID)
+		}
+		return fmt.Sprintf(`ALTER DATABASE "%s" SET spanner.default_leader = '%s'`, databaseID, leader)
+	}
+	if leader == "" {
+		return fmt.Sprintf("ALTER DATABASE `%s` SET OPTIONS (default_leader = NULL)", databaseID)
+	}
+	return fmt.Sprintf("ALTER DATABASE `%s` SET OPTIONS (default_leader = '%s')", databaseID, leader)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func multiRegionDatabases(defaultLeader string, dialect databasepb.DatabaseDialect) *fakeDatabases {
+	api := newFakeDatabases()
+	api.instance = &instancepb.Instance{Name: instanceName, Config: "projects/proj-a/instanceConfigs/nam6"}
+	api.instanceConfig = &instancepb.InstanceConfig{
+		Name:          "projects/proj-a/instanceConfigs/nam6",
+		LeaderOptions: []string{"us-central1", "us-east1"},
+		Replicas: []*instancepb.ReplicaInfo{
+			{Location: "us-central1", Type: instancepb.ReplicaInfo_READ_WRITE, DefaultLeaderLocation: true},
+			{Location: "us-east1", Type: instancepb.ReplicaInfo_READ_WRITE},
+			{Location: "us-west1", Type: instancepb.ReplicaInfo_READ_ONLY},
+		},
+	}
+	api.databases[databaseName] = &databasepb.Database{Name: databaseName, State: databasepb.Database_READY, DatabaseDialect: dialect, DefaultLeader: defaultLeader}
+	return api
+}
+
+func databaseReq(cfg map[string]interface{}) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Target: extutil.Ptr(action_kit_api.Target{Attributes: map[string][]string{
+			attrProjectID:    {"proj-a"},
+			attrInstanceName: {"prod"},
+			attrDatabaseName: {"orders"},
+		}}),
+		Config: cfg,
+	})
+}
+
+func TestLeaderRelocation_MovesAndResetsImplicitLeader(t *testing.T) {
+	withFastOperationPolling(t)
+	api := multiRegionDatabases("", databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+	a := &leaderRelocationAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, databaseReq(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Equal(t, "us-central1", state.EffectiveLeader)
+	assert.Equal(t, "us-east1", state.Leader)
+
+	api.pendingPolls["operations/ddl-1"] = 1
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, "us-east1", api.databases[databaseName].DefaultLeader)
+	assert.Equal(t, "ALTER DATABASE `orders` SET OPTIONS (default_leader = 'us-east1')", api.statements[0])
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.False(t, state.UpdateReported)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.statements, 2)
+	assert.Equal(t, "ALTER DATABASE `orders` SET OPTIONS (default_leader = NULL)", api.statements[1])
+	assert.Empty(t, api.databases[databaseName].DefaultLeader)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Len(t, api.statements, 2, "a second stop is a no-op")
+}
+
+func TestLeaderRelocation_PostgreSQLRestoresExplicitLeader(t *testing.T) {
+	withFastOperationPolling(t)
+	api := multiRegionDatabases("us-east1", databasepb.DatabaseDialect_POSTGRESQL)
+	a := &leaderRelocationAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, databaseReq(map[string]interface{}{"leader": "us-central1"}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, `ALTER DATABASE "orders" SET spanner.default_leader = 'us-central1'`, api.statements[0])
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, `ALTER DATABASE "orders" SET spanner.default_leader = 'us-east1'`, api.statements[1])
+	assert.Equal(t, "us-east1", api.databases[databaseName].DefaultLeader)
+}
+
+func TestLeaderRelocation_Prepare_Refusals(t *testing.T) {
+	api := multiRegionDatabases("", databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL)
+	a := &leaderRelocationAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, databaseReq(map[string]interface{}{"leader": "us-west1"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not an alternative leader")
+
+	_, err = a.Prepare(context.Background(), &state, databaseReq(map[string]interface{}{"leader": "us-central1"}))
+	require.Error(t, err, "the current leader is no relocation")
+
+	api.instanceConfig = &instancepb.InstanceConfig{
+		Name:          "projects/proj-a/instanceConfigs/nam6",
+		LeaderOptions: []string{"europe-west1"},
+		Replicas:      []*instancepb.ReplicaInfo{{Location: "europe-west1", Type: instancepb.ReplicaInfo_READ_WRITE, DefaultLeaderLocation: true}},
+	}
+	_, err = a.Prepare(context.Background(), &state, databaseReq(map[string]interface{}{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only multi-region configs")
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-gcp/utils"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+type databaseDiscovery struct{}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*databaseDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*databaseDiscovery)(nil)
+)
+
+func NewDatabaseDiscovery() discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&databaseDiscovery{},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *databaseDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDDatabase,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *databaseDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDDatabase,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Spanner database", Other: "Spanner databases"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrInstanceName},
+				{Attribute: attrDatabaseDialect},
+				{Attribute: "gcp.spanner.database.version-retention-period"},
+				{Attribute: "gcp.spanner.database.backup.ready-count"},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *databaseDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrDatabaseName, Label: discovery_kit_api.PluralLabel{One: "Spanner database name", Other: "Spanner database names"}},
+		{Attribute: attrDatabaseDialect, Label: discovery_kit_api.PluralLabel{One: "Spanner database dialect", Other: "Spanner database dialects"}},
+		{Attribute: attrDatabaseState, Label: discovery_kit_api.PluralLabel{One: "Spanner database state", Other: "Spanner database states"}},
+		{Attribute: "gcp.spanner.database.encryption.type", Label: discovery_kit_api.PluralLabel{One: "Spanner database encryption type", Other: "Spanner database encryption types"}},
+		{Attribute: "gcp.spanner.database.encryption.kms-key-name", Label: discovery_kit_api.PluralLabel{One: "Spanner database KMS key", Other: "Spanner database KMS keys"}},
+		{Attribute: "gcp.spanner.database.version-retention-period", Label: discovery_kit_api.PluralLabel{One: "Spanner database version retention period", Other: "Spanner database version retention periods"}},
+		{Attribute: "gcp.spanner.database.earliest-version-time", Label: discovery_kit_api.PluralLabel{One: "Spanner database earliest version time", Other: "Spanner database earliest version times"}},
+		{Attribute: attrDatabaseDefaultLeader, Label: discovery_kit_api.PluralLabel{One: "Spanner database default leader", Other: "Spanner database default leaders"}},
+		{Attribute: "gcp.spanner.database.drop-protection", Label: discovery_kit_api.PluralLabel{One: "Spanner database drop protection", Other: "Spanner database drop protection"}},
+		{Attribute: "gcp.spanner.database.backup.ready-count", Label: discovery_kit_api.PluralLabel{One: "Spanner database ready backup count", Other: "Spanner database ready backup counts"}},
+		{Attribute: "gcp.spanner.database.backup.latest-version-time", Label: discovery_kit_api.PluralLabel{One: "Spanner database latest backup version time", Other: "Spanner database latest backup version times"}},
+		{Attribute: "gcp.spanner.database.backup-schedule.name", Label: discovery_kit_api.PluralLabel{One: "Spanner database backup schedule", Other: "Spanner database backup schedules"}},
+		{Attribute: "gcp.spanner.database.backup-schedule.cron", Label: discovery_kit_api.PluralLabel{One: "Spanner database backup schedule cron", Other: "Spanner database backup schedule crons"}},
+	}
+}
+
+func (d *databaseDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
+		instances, err := instance.NewInstanceAdminClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Spanner instance admin client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = instances.Close() }()
+		databases, err := database.NewDatabaseAdminClient(ctx, access.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Spanner database admin client for project '%s': %w", access.ProjectID, err)
+		}
+		defer func() { _ = databases.Close() }()
+		return getAllDatabases(ctx, instances, databases, access.ProjectID)
+	}, ctx, "spanner-database")
+}
+
+// getAllDatabases walks the instances of the project. Backups are listed once per instance and matched to their
+// database, backup schedules are listed per database.
+func getAllDatabases(ctx context.Context, instances *instance.InstanceAdminClient, databases *database.DatabaseAdminClient, projectID string) ([]discovery_kit_api.Target, error) {
+	instanceNames, err := listInstanceNames(ctx, instances, projectID)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]discovery_kit_api.Target, 0)
+	for _, instanceName := range instanceNames {
+		backups, err := listBackups(ctx, databases, instanceName)
+		if err != nil {
+			return nil, err
+		}
+		backupsByDatabase := make(map[string][]*databasepb.Backup)
+		for _, b := range backups {
+			backupsByDatabase[b.Database] = append(backupsByDatabase[b.Database], b)
+		}
+		it := databases.ListDatabases(ctx, &databasepb.ListDatabasesRequest{Parent: instanceName})
+		for {
+			db, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				log.Warn().Err(err).Str("instance", instanceName).Msg("Failed to list Spanner databases")
+				return nil, err
+			}
+			schedules, err := listBackupSchedules(ctx, databases, db.Name)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, toDatabaseTarget(db, backupsByDatabase[db.Name], schedules, projectID))
+		}
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludesSpannerDatabase), nil
+}
+
+func listInstanceNames(ctx context.Context, client *instance.InstanceAdminClient, projectID string) ([]string, error) {
+	names := make([]string, 0)
+	it := client.ListInstances(ctx, &instancepb.ListInstancesRequest{Parent: fmt.Sprintf("projects/%s", projectID)})
+	for {
+		inst, err := it.Next()
+		if err == iterator.Done {
+			return names, nil
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("project", projectID).Msg("Failed to list Spanner instances")
+			return nil, err
+		}
+		names = append(names, inst.Name)
+	}
+}
+
+func listBackups(ctx context.Context, client *database.DatabaseAdminClient, instanceName string) ([]*databasepb.Backup, error) {
+	backups := make([]*databasepb.Backup, 0)
+	it := client.ListBackups(ctx, &databasepb.ListBackupsRequest{Parent: instanceName})
+	for {
+		b, err := it.Next()
+		if err == iterator.Done {
+			return backups, nil
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("instance", instanceName).Msg("Failed to list Spanner backups")
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+}
+
+func listBackupSchedules(ctx context.Context, client *database.DatabaseAdminClient, databaseName string) ([]*databasepb.BackupSchedule, error) {
+	schedules := make([]*databasepb.BackupSchedule, 0)
+	it := client.ListBackupSchedules(ctx, &databasepb.ListBackupSchedulesRequest{Parent: databaseName})
+	for {
+		s, err := it.Next()
+		if err == iterator.Done {
+			return schedules, nil
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("database", databaseName).Msg("Failed to list Spanner backup schedules")
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+}
+
+func toDatabaseTarget(db *databasepb.Database, backups []*databasepb.Backup, schedules []*databasepb.BackupSchedule, projectID string) discovery_kit_api.Target {
+	// db.Name is "projects/<p>/instances/<instance>/databases/<name>"
+	instanceID := parentSegment(db.Name, "instances")
+	name := lastSegment(db.Name)
+
+	// Project and instance name are the keys the instance-to-database enrichment joins on.
+	attributes := make(map[string][]string)
+	attributes[attrProjectID] = []string{projectID}
+	attributes[attrInstanceName] = []string{instanceID}
+	attributes[attrDatabaseName] = []string{name}
+	if db.DatabaseDialect != databasepb.DatabaseDialect_DATABASE_DIALECT_UNSPECIFIED {
+		attributes[attrDatabaseDialect] = []string{db.DatabaseDialect.String()}
+	}
+	if db.State != databasepb.Database_STATE_UNSPECIFIED {
+		attributes[attrDatabaseState] = []string{db.State.String()}
+	}
+	kmsKeys := db.GetEncryptionConfig().GetKmsKeyNames()
+	if key := db.GetEncryptionConfig().GetKmsKeyName(); key != "" {
+		kmsKeys = append([]string{key}, kmsKeys...)
+	}
+	if len(kmsKeys) > 0 {
+		attributes["gcp.spanner.database.encryption.type"] = []string{databasepb.EncryptionInfo_CUSTOMER_MANAGED_ENCRYPTION.String()}
+		attributes["gcp.spanner.database.encryption.kms-key-name"] = kmsKeys
+	} else {
+		attributes["gcp.spanner.database.encryption.type"] = []string{databasepb.EncryptionInfo_GOOGLE_DEFAULT_ENCRYPTION.String()}
+	}
+	if db.VersionRetentionPeriod != "" {
+		attributes["gcp.spanner.database.version-retention-period"] = []string{db.VersionRetentionPeriod}
+	}
+	if db.EarliestVersionTime != nil {
+		attributes["gcp.spanner.database.earliest-version-time"] = []string{db.EarliestVersionTime.AsTime().UTC().Format(time.RFC3339)}
+	}
+	if db.DefaultLeader != "" {
+		attributes[attrDatabaseDefaultLeader] = []string{db.DefaultLeader}
+	}
+	attributes["gcp.spanner.database.drop-protection"] = []string{strconv.FormatBool(db.EnableDropProtection)}
+
+	ready := 0
+	var latest time.Time
+	for _, b := range backups {
+		if b.State != databasepb.Backup_READY {
+			continue
+		}
+		ready++
+		if v := b.GetVersionTime().AsTime(); b.VersionTime != nil && v.After(latest) {
+			latest = v
+		}
+	}
+	attributes["gcp.spanner.database.backup.ready-count"] = []string{strconv.Itoa(ready)}
+	if !latest.IsZero() {
+		attributes["gcp.spanner.database.backup.latest-version-time"] = []string{latest.UTC().Format(time.RFC3339)}
+	}
+	for _, s := range schedules {
+		attributes["gcp.spanner.database.backup-schedule.name"] = append(attributes["gcp.spanner.database.backup-schedule.name"], lastSegment(s.Name))
+		if cron := s.GetSpec().GetCronSpec().GetText(); cron != "" {
+			attributes["gcp.spanner.database.backup-schedule.cron"] = append(attributes["gcp.spanner.database.backup-schedule.cron"], cron)
+		}
+	}
+
+	return discovery_kit_api.Target{
+		Id:         db.Name,
+		TargetType: TargetIDDatabase,
+		Label:      fmt.Sprintf("%s/%s", instanceID, name),
+		Attributes: attributes,
+	}
+}
+
+func lastSegment(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// parentSegment returns the ID following collection in a resource name, e.g. the instance of a database.
+func parentSegment(name, collection string) string {
+	parts := strings.Split(name, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == collection {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
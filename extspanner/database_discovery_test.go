@@ -0,0 +1,114 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const databaseName = "projects/proj-a/instances/prod/databases/orders"
+
+func TestToDatabaseTarget_Populated(t *testing.T) {
+	earliest := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC)
+	db := &databasepb.Database{
+		Name:                   databaseName,
+		State:                  databasepb.Database_READY,
+		DatabaseDialect:        databasepb.DatabaseDialect_GOOGLE_STANDARD_SQL,
+		EncryptionConfig:       &databasepb.EncryptionConfig{KmsKeyName: "projects/proj-a/locations/eur3/keyRings/r/cryptoKeys/k"},
+		VersionRetentionPeriod: "7d",
+		EarliestVersionTime:    timestamppb.New(earliest),
+		DefaultLeader:          "europe-west1",
+		EnableDropProtection:   true,
+	}
+	backups := []*databasepb.Backup{
+		{Name: "projects/proj-a/instances/prod/backups/b1", Database: databaseName, State: databasepb.Backup_READY, VersionTime: timestamppb.New(earliest.Add(time.Hour))},
+		{Name: "projects/proj-a/instances/prod/backups/b2", Database: databaseName, State: databasepb.Backup_READY, VersionTime: timestamppb.New(earliest.Add(3 * time.Hour))},
+		{Name: "projects/proj-a/instances/prod/backups/b3", Database: databaseName, State: databasepb.Backup_CREATING, VersionTime: timestamppb.New(earliest.Add(5 * time.Hour))},
+	}
+	schedules := []*databasepb.BackupSchedule{{
+		Name: databaseName + "/backupSchedules/daily",
+		Spec: &databasepb.BackupScheduleSpec{ScheduleSpec: &databasepb.BackupScheduleSpec_CronSpec{CronSpec: &databasepb.CrontabSpec{Text: "0 2 * * *"}}},
+	}}
+
+	target := toDatabaseTarget(db, backups, schedules, "proj-a")
+
+	assert.Equal(t, TargetIDDatabase, target.TargetType)
+	assert.Equal(t, databaseName, target.Id)
+	assert.Equal(t, "prod/orders", target.Label)
+	assert.Equal(t, []string{"proj-a"}, target.Attributes[attrProjectID])
+	assert.Equal(t, []string{"prod"}, target.Attributes[attrInstanceName])
+	assert.Equal(t, []string{"orders"}, target.Attributes[attrDatabaseName])
+	assert.Equal(t, []string{"GOOGLE_STANDARD_SQL"}, target.Attributes[attrDatabaseDialect])
+	assert.Equal(t, []string{"READY"}, target.Attributes[attrDatabaseState])
+	assert.Equal(t, []string{"CUSTOMER_MANAGED_ENCRYPTION"}, target.Attributes["gcp.spanner.database.encryption.type"])
+	assert.Equal(t, []string{"projects/proj-a/locations/eur3/keyRings/r/cryptoKeys/k"}, target.Attributes["gcp.spanner.database.encryption.kms-key-name"])
+	assert.Equal(t, []string{"7d"}, target.Attributes["gcp.spanner.database.version-retention-period"])
+	assert.Equal(t, []string{"2026-10-10T08:00:00Z"}, target.Attributes["gcp.spanner.database.earliest-version-time"])
+	assert.Equal(t, []string{"europe-west1"}, target.Attributes[attrDatabaseDefaultLeader])
+	assert.Equal(t, []string{"true"}, target.Attributes["gcp.spanner.database.drop-protection"])
+	assert.Equal(t, []string{"2"}, target.Attributes["gcp.spanner.database.backup.ready-count"])
+	assert.Equal(t, []string{"2026-10-10T11:00:00Z"}, target.Attributes["gcp.spanner.database.backup.latest-version-time"])
+	assert.Equal(t, []string{"daily"}, target.Attributes["gcp.spanner.database.backup-schedule.name"])
+	assert.Equal(t, []string{"0 2 * * *"}, target.Attributes["gcp.spanner.database.backup-schedule.cron"])
+}
+
+func TestToDatabaseTarget_Sparse(t *testing.T) {
+	target := toDatabaseTarget(&databasepb.Database{Name: databaseName}, nil, nil, "proj-a")
+
+	assert.Equal(t, []string{"GOOGLE_DEFAULT_ENCRYPTION"}, target.Attributes["gcp.spanner.database.encryption.type"])
+	assert.Equal(t, []string{"0"}, target.Attributes["gcp.spanner.database.backup.ready-count"])
+	assert.NotContains(t, target.Attributes, attrDatabaseDialect)
+	assert.NotContains(t, target.Attributes, attrDatabaseDefaultLeader)
+	assert.NotContains(t, target.Attributes, "gcp.spanner.database.earliest-version-time")
+	assert.NotContains(t, target.Attributes, "gcp.spanner.database.backup.latest-version-time")
+	assert.NotContains(t, target.Attributes, "gcp.spanner.database.backup-schedule.name")
+}
+
+func TestToBackupTarget(t *testing.T) {
+	b := &databasepb.Backup{
+		Name:                     "projects/proj-a/instances/prod/backups/daily-20261017",
+		Database:                 databaseName,
+		State:                    databasepb.Backup_READY,
+		CreateTime:               timestamppb.New(time.Date(2026, 10, 17, 2, 5, 0, 0, time.UTC)),
+		VersionTime:              timestamppb.New(time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)),
+		ExpireTime:               timestamppb.New(time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC)),
+		SizeBytes:                4096,
+		IncrementalBackupChainId: "chain-1",
+		BackupSchedules:          []string{databaseName + "/backupSchedules/daily"},
+	}
+
+	target := toBackupTarget(b, "proj-a")
+
+	assert.Equal(t, TargetIDBackup, target.TargetType)
+	assert.Equal(t, "prod/daily-20261017", target.Label)
+	assert.Equal(t, []string{"prod"}, target.Attributes[attrInstanceName])
+	assert.Equal(t, []string{"orders"}, target.Attributes[attrDatabaseName])
+	assert.Equal(t, []string{"daily-20261017"}, target.Attributes[attrBackupName])
+	assert.Equal(t, []string{"READY"}, target.Attributes[attrBackupState])
+	assert.Equal(t, []string{"2026-10-17T02:00:00Z"}, target.Attributes["gcp.spanner.backup.version-time"])
+	assert.Equal(t, []string{"2026-10-24T02:00:00Z"}, target.Attributes["gcp.spanner.backup.expire-time"])
+	assert.Equal(t, []string{"4096"}, target.Attributes["gcp.spanner.backup.size-bytes"])
+	assert.Equal(t, []string{"true"}, target.Attributes["gcp.spanner.backup.incremental"])
+	assert.Equal(t, []string{"daily"}, target.Attributes["gcp.spanner.backup.backup-schedule"])
+}
+
+func TestInstanceEnrichmentRules(t *testing.T) {
+	rules := (&instanceDiscovery{}).DescribeEnrichmentRules()
+
+	assert.Len(t, rules, 2)
+	assert.Equal(t, TargetIDDatabase, rules[0].Dest.Type)
+	assert.Equal(t, TargetIDBackup, rules[1].Dest.Type)
+	for _, rule := range rules {
+		assert.Equal(t, TargetIDInstance, rule.Src.Type)
+		assert.Equal(t, "${dest.gcp.spanner.instance.name}", rule.Src.Selector[attrInstanceName])
+		assert.Equal(t, "${src.gcp.spanner.instance.name}", rule.Dest.Selector[attrInstanceName])
+		assert.Equal(t, "gcp.spanner.instance.", rule.Attributes[0].Name)
+	}
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// CapacityReductionState records the capacity found in Prepare. The attack is reversible: Stop writes the original
+// processing units back or, for autoscaled instances, re-enables the original autoscaling config, which is kept as
+// proto.Marshal bytes.
+type CapacityReductionState struct {
+	ProjectID                 string
+	InstanceName              string
+	InstanceID                string
+	Percentage                int
+	ProcessingUnits           int32
+	OriginalProcessingUnits   int32
+	OriginalAutoscalingConfig []byte
+	UpdateOperation           string
+	UpdateReported            bool
+}
+
+type capacityReductionAttack struct {
+	clientProvider func(ctx context.Context, projectID string) (instancesApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[CapacityReductionState] = (*capacityReductionAttack)(nil)
+var _ action_kit_sdk.ActionWithStatus[CapacityReductionState] = (*capacityReductionAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[CapacityReductionState] = (*capacityReductionAttack)(nil)
+
+func NewInstanceCapacityReductionAction() action_kit_sdk.ActionWithStop[CapacityReductionState] {
+	return &capacityReductionAttack{clientProvider: newInstancesApi}
+}
+
+func (a *capacityReductionAttack) NewEmptyState() CapacityReductionState {
+	return CapacityReductionState{}
+}
+
+func (a *capacityReductionAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          InstanceCapacityReductionActionId,
+		Label:       "Reduce Spanner capacity",
+		Description: "Scales a Spanner instance down to a percentage of its current processing units for the given duration, to observe latency and CPU saturation under reduced compute. An autoscaling config is switched off during the attack. The original capacity and autoscaling config are restored on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType:         TargetIDInstance,
+			SelectionTemplates: extutil.Ptr(instanceSelectionTemplates),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Spanner"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the capacity stays reduced. Restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:         "percentage",
+				Label:        "Remaining capacity (%)",
+				Description:  extutil.Ptr("Processing units left during the attack, as a percentage of the current ones. Rounded down to a valid size (multiples of 100 below 1000, of 1000 above), but never below 100."),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: extutil.Ptr("50"),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(99),
+				Order:        extutil.Ptr(2),
+				Required:     extutil.Ptr(true),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("10s"),
+		}),
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+var instanceSelectionTemplates = []action_kit_api.TargetSelectionTemplate{
+	{
+		Label:       "by instance name",
+		Description: extutil.Ptr("Find Spanner instance by name"),
+		Query:       attrInstanceName + "=\"\"",
+	},
+}
+
+// resolveInstance reads the instance from the target attributes and builds its resource name.
+func resolveInstance(request action_kit_api.PrepareActionRequestBody) (projectID, instanceID, instanceName string, err error) {
+	projectID = mustHave(request.Target.Attributes, attrProjectID)
+	instanceID = mustHave(request.Target.Attributes, attrInstanceName)
+	if projectID == "" || instanceID == "" {
+		return "", "", "", extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.spanner.instance.name", nil)
+	}
+	return projectID, instanceID, fmt.Sprintf("projects/%s/instances/%s", projectID, instanceID), nil
+}
+
+func mustHave(attrs map[string][]string, key string) string {
+	v, ok := attrs[key]
+	if !ok || len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func (a *capacityReductionAttack) Prepare(ctx context.Context, state *CapacityReductionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var err error
+	state.ProjectID, state.InstanceID, state.InstanceName, err = resolveInstance(request)
+	if err != nil {
+		return nil, err
+	}
+	state.Percentage = int(extutil.ToInt64(request.Config["percentage"]))
+	if state.Percentage < 1 || state.Percentage > 99 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Remaining capacity must be between 1 and 99 percent, got %d", state.Percentage), nil)
+	}
+
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Spanner client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get Spanner instance %s", state.InstanceID), err)
+	}
+	if inst.InstanceType == instancepb.Instance_FREE_INSTANCE {
+		return nil, extension_kit.ToError(fmt.Sprintf("Spanner instance %s is a free trial instance, whose capacity cannot be changed", state.InstanceID), nil)
+	}
+	if inst.State != instancepb.Instance_READY {
+		return nil, extension_kit.ToError(fmt.Sprintf("Spanner instance %s is %s, not READY", state.InstanceID, inst.State), nil)
+	}
+	state.OriginalProcessingUnits = inst.ProcessingUnits
+	state.ProcessingUnits = reducedProcessingUnits(inst.ProcessingUnits, state.Percentage)
+	if state.ProcessingUnits >= state.OriginalProcessingUnits {
+		return nil, extension_kit.ToError(fmt.Sprintf("Spanner instance %s runs on %d processing units, which cannot be reduced to %d%%", state.InstanceID, inst.ProcessingUnits, state.Percentage), nil)
+	}
+	if inst.AutoscalingConfig != nil {
+		if state.OriginalAutoscalingConfig, err = proto.Marshal(inst.AutoscalingConfig); err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to record the autoscaling config of Spanner instance %s", state.InstanceID), err)
+		}
+	}
+	return nil, nil
+}
+
+func (a *capacityReductionAttack) Start(ctx context.Context, state *CapacityReductionState) (*action_kit_api.StartResult, error) {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Spanner client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	// Spanner only accepts a fixed capacity once the autoscaling config is cleared in the same update.
+	paths := []string{"processing_units"}
+	if state.OriginalAutoscalingConfig != nil {
+		paths = append(paths, "autoscaling_config")
+	}
+	state.UpdateOperation, err = client.UpdateInstance(ctx, &instancepb.Instance{Name: state.InstanceName, ProcessingUnits: state.ProcessingUnits}, paths...)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to reduce capacity of Spanner instance %s", state.InstanceID), err)
+	}
+	message := fmt.Sprintf("Scaling Spanner instance %s down to %d processing units (was %d)", state.InstanceID, state.ProcessingUnits, state.OriginalProcessingUnits)
+	if state.OriginalAutoscalingConfig != nil {
+		message += "; autoscaling is disabled until stop"
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: message,
+		}}),
+	}, nil
+}
+
+// Status reports once the instance runs on the reduced capacity. The reduction then stays in place until Stop.
+func (a *capacityReductionAttack) Status(ctx context.Context, state *CapacityReductionState) (*action_kit_api.StatusResult, error) {
+	if state.UpdateReported {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to initialize Spanner client for project %s", state.ProjectID), err)
+	}
+	defer closer()
+	done, err := client.PollUpdate(ctx, state.UpdateOperation)
+	if result, err := utils.OperationStatus(done, err, "Spanner", fmt.Sprintf("Capacity reduction of Spanner instance %s failed", state.InstanceID)); result != nil || err != nil {
+		return result, err
+	}
+	state.UpdateReported = true
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Spanner instance %s runs on %d processing units", state.InstanceID, state.ProcessingUnits),
+		}}),
+	}, nil
+}
+
+func (a *capacityReductionAttack) Stop(ctx context.Context, state *CapacityReductionState) (*action_kit_api.StopResult, error) {
+	if state.UpdateOperation == "" {
+		return nil, nil
+	}
+	original := fmt.Sprintf("%d processing units", state.OriginalProcessingUnits)
+	if state.OriginalAutoscalingConfig != nil {
+		original = "its autoscaling config"
+	}
+	if err := a.restoreCapacity(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restore capacity of Spanner instance %s", state.InstanceID)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore Spanner instance %s to %s", state.InstanceID, original), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Spanner instance %s restored to %s", state.InstanceID, original),
+		}}),
+	}, nil
+}
+
+// restoreCapacity waits for the reduction to settle, as Spanner rejects a second update while one is in progress.
+// Autoscaled instances only get their config back; the autoscaler then grows the capacity again on its own.
+func (a *capacityReductionAttack) restoreCapacity(ctx context.Context, state *CapacityReductionState) error {
+	client, closer, err := a.clientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	if err := utils.WaitForOperation(ctx, client.PollUpdate, state.UpdateOperation); err != nil {
+		log.Warn().Err(err).Msgf("Capacity reduction of Spanner instance %s did not complete cleanly", state.InstanceID)
+	}
+	inst, err := client.GetInstance(ctx, state.InstanceName)
+	if err != nil {
+		return err
+	}
+	update := &instancepb.Instance{Name: state.InstanceName}
+	var paths []string
+	if state.OriginalAutoscalingConfig != nil {
+		update.AutoscalingConfig = &instancepb.AutoscalingConfig{}
+		if err := proto.Unmarshal(state.OriginalAutoscalingConfig, update.AutoscalingConfig); err != nil {
+			return fmt.Errorf("decode original autoscaling config: %w", err)
+		}
+		if proto.Equal(inst.AutoscalingConfig, update.AutoscalingConfig) {
+			return nil
+		}
+		paths = []string{"autoscaling_config"}
+	} else {
+		if inst.ProcessingUnits == state.OriginalProcessingUnits {
+			return nil
+		}
+		update.ProcessingUnits = state.OriginalProcessingUnits
+		paths = []string{"processing_units"}
+	}
+	op, err := client.UpdateInstance(ctx, update, paths...)
+	if err != nil {
+		return err
+	}
+	return utils.WaitForOperation(ctx, client.PollUpdate, op)
+}
+
+// reducedProcessingUnits scales processingUnits to percentage and rounds down to a size Spanner accepts.
+func reducedProcessingUnits(processingUnits int32, percentage int) int32 {
+	target := int64(processingUnits) * int64(percentage) / 100
+	if target >= 1000 {
+		return int32(target / 1000 * 1000)
+	}
+	return int32(max(100, target/100*100))
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extspanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/spanner/admin/instance/apiv1/instancepb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func readyInstance(processingUnits int32) *instancepb.Instance {
+	return &instancepb.Instance{
+		Name:            instanceName,
+		State:           instancepb.Instance_READY,
+		InstanceType:    instancepb.Instance_PROVISIONED,
+		ProcessingUnits: processingUnits,
+	}
+}
+
+func TestCapacityReduction_ReducesAndRestores(t *testing.T) {
+	withFastOperationPolling(t)
+	api := newFakeInstances()
+	api.instances[instanceName] = readyInstance(3000)
+	a := &capacityReductionAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 50}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3000), state.OriginalProcessingUnits)
+	assert.Equal(t, int32(1000), state.ProcessingUnits)
+	assert.Nil(t, state.OriginalAutoscalingConfig)
+
+	api.pendingPolls["operations/op-1"] = 1
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1000), api.instances[instanceName].ProcessingUnits)
+	assert.Equal(t, []string{"processing_units"}, api.updates[0])
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.False(t, state.UpdateReported)
+	status, err = a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+	assert.True(t, state.UpdateReported)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3000), api.instances[instanceName].ProcessingUnits)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	assert.Len(t, api.updates, 2, "a second stop is a no-op")
+}
+
+func TestCapacityReduction_DisablesAndRestoresAutoscaling(t *testing.T) {
+	withFastOperationPolling(t)
+	autoscaling := &instancepb.AutoscalingConfig{
+		AutoscalingLimits: &instancepb.AutoscalingConfig_AutoscalingLimits{
+			MinLimit: &instancepb.AutoscalingConfig_AutoscalingLimits_MinProcessingUnits{MinProcessingUnits: 1000},
+			MaxLimit: &instancepb.AutoscalingConfig_AutoscalingLimits_MaxProcessingUnits{MaxProcessingUnits: 5000},
+		},
+		AutoscalingTargets: &instancepb.AutoscalingConfig_AutoscalingTargets{HighPriorityCpuUtilizationPercent: 65, StorageUtilizationPercent: 90},
+	}
+	inst := readyInstance(2000)
+	inst.AutoscalingConfig = proto.Clone(autoscaling).(*instancepb.AutoscalingConfig)
+	api := newFakeInstances()
+	api.instances[instanceName] = inst
+	a := &capacityReductionAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 25}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(500), state.ProcessingUnits)
+	assert.NotEmpty(t, state.OriginalAutoscalingConfig)
+
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"processing_units", "autoscaling_config"}, api.updates[0])
+	assert.Nil(t, api.instances[instanceName].AutoscalingConfig)
+	assert.Equal(t, int32(500), api.instances[instanceName].ProcessingUnits)
+
+	_, err = a.Stop(context.Background(), &state)
+	require.NoError(t, err)
+	require.Len(t, api.updates, 2)
+	assert.Equal(t, []string{"autoscaling_config"}, api.updates[1])
+	assert.True(t, proto.Equal(autoscaling, api.instances[instanceName].AutoscalingConfig))
+}
+
+func TestCapacityReduction_StatusReportsFailedUpdate(t *testing.T) {
+	api := newFakeInstances()
+	api.instances[instanceName] = readyInstance(1000)
+	api.opErrors["operations/op-1"] = errors.New("instance has too much storage for 300 processing units")
+	a := &capacityReductionAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	_, err := a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 30}))
+	require.NoError(t, err)
+	_, err = a.Start(context.Background(), &state)
+	require.NoError(t, err)
+
+	status, err := a.Status(context.Background(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Contains(t, *status.Error.Detail, "too much storage")
+}
+
+func TestCapacityReduction_Prepare_Refusals(t *testing.T) {
+	api := newFakeInstances()
+	a := &capacityReductionAttack{clientProvider: api.provider()}
+	state := a.NewEmptyState()
+
+	api.instances[instanceName] = readyInstance(100)
+	_, err := a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 50}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be reduced")
+
+	free := readyInstance(1000)
+	free.InstanceType = instancepb.Instance_FREE_INSTANCE
+	api.instances[instanceName] = free
+	_, err = a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 50}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "free trial")
+
+	creating := readyInstance(1000)
+	creating.State = instancepb.Instance_CREATING
+	api.instances[instanceName] = creating
+	_, err = a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 50}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not READY")
+
+	_, err = a.Prepare(context.Background(), &state, instanceReq(map[string]interface{}{"percentage": 100}))
+	require.Error(t, err)
+}
+
+func TestReducedProcessingUnits(t *testing.T) {
+	assert.Equal(t, int32(1000), reducedProcessingUnits(3000, 50))
+	assert.Equal(t, int32(2000), reducedProcessingUnits(3000, 67))
+	assert.Equal(t, int32(900), reducedProcessingUnits(1000, 99))
+	assert.Equal(t, int32(100), reducedProcessingUnits(1000, 1))
+	assert.Equal(t, int32(100), reducedProcessingUnits(100, 50))
+}
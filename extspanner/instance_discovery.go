@@ -27,8 +27,9 @@ import (
 type instanceDiscovery struct{}
 
 var (
-	_ discovery_kit_sdk.TargetDescriber    = (*instanceDiscovery)(nil)
-	_ discovery_kit_sdk.AttributeDescriber = (*instanceDiscovery)(nil)
+	_ discovery_kit_sdk.TargetDescriber          = (*instanceDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber       = (*instanceDiscovery)(nil)
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*instanceDiscovery)(nil)
 )
 
 func NewInstanceDiscovery() discovery_kit_sdk.TargetDiscovery {
@@ -67,7 +68,7 @@ func (d *instanceDiscovery) DescribeTarget() discovery_kit_api.TargetDescription
 
 func (d *instanceDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
 	return []discovery_kit_api.AttributeDescription{
-		{Attribute: "gcp.spanner.instance.name", Label: discovery_kit_api.PluralLabel{One: "Spanner instance name", Other: "Spanner instance names"}},
+		{Attribute: attrInstanceName, Label: discovery_kit_api.PluralLabel{One: "Spanner instance name", Other: "Spanner instance names"}},
 		{Attribute: "gcp.spanner.instance.display-name", Label: discovery_kit_api.PluralLabel{One: "Spanner instance display name", Other: "Spanner instance display names"}},
 		{Attribute: attrConfig, Label: discovery_kit_api.PluralLabel{One: "Spanner instance config", Other: "Spanner instance configs"}},
 		{Attribute: attrEdition, Label: discovery_kit_api.PluralLabel{One: "Spanner instance edition", Other: "Spanner instance editions"}},
@@ -80,6 +81,39 @@ func (d *instanceDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDe
 	}
 }
 
+// DescribeEnrichmentRules copies the instance attributes onto its databases and backups, so they can be selected by
+// the config, edition or capacity of the instance hosting them.
+func (d *instanceDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	return []discovery_kit_api.TargetEnrichmentRule{
+		instanceEnrichmentRule("com.steadybit.extension_gcp.spanner.instance-to-database", TargetIDDatabase),
+		instanceEnrichmentRule("com.steadybit.extension_gcp.spanner.instance-to-backup", TargetIDBackup),
+	}
+}
+
+func instanceEnrichmentRule(id, destType string) discovery_kit_api.TargetEnrichmentRule {
+	return discovery_kit_api.TargetEnrichmentRule{
+		Id:      id,
+		Version: extbuild.GetSemverVersionStringOrUnknown(),
+		Src: discovery_kit_api.SourceOrDestination{
+			Type: TargetIDInstance,
+			Selector: map[string]string{
+				attrProjectID:    "${dest.gcp.project.id}",
+				attrInstanceName: "${dest.gcp.spanner.instance.name}",
+			},
+		},
+		Dest: discovery_kit_api.SourceOrDestination{
+			Type: destType,
+			Selector: map[string]string{
+				attrProjectID:    "${src.gcp.project.id}",
+				attrInstanceName: "${src.gcp.spanner.instance.name}",
+			},
+		},
+		Attributes: []discovery_kit_api.Attribute{
+			{Matcher: discovery_kit_api.StartsWith, Name: "gcp.spanner.instance."},
+		},
+	}
+}
+
 func (d *instanceDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
 	return utils.ForEveryConfiguredGcpAccess(func(access *utils.GcpAccess, ctx context.Context) ([]discovery_kit_api.Target, error) {
 		client, err := instance.NewInstanceAdminClient(ctx, access.ClientOptions...)
@@ -122,7 +156,7 @@ func toInstanceTarget(inst *instancepb.Instance, projectID string) discovery_kit
 
 	attributes := make(map[string][]string)
 	attributes[attrProjectID] = []string{projectID}
-	attributes["gcp.spanner.instance.name"] = []string{name}
+	attributes[attrInstanceName] = []string{name}
 	if inst.DisplayName != "" {
 		attributes["gcp.spanner.instance.display-name"] = []string{inst.DisplayName}
 	}
@@ -16,3 +16,12 @@ func newInstancesClientForAccess(ctx context.Context, access *utils.GcpAccess) (
 	}
 	return client, nil
 }
+
+func newZoneOperationsClientForAccess(ctx context.Context, access *utils.GcpAccess) (*compute.ZoneOperationsClient, error) {
+	client, err := compute.NewZoneOperationsRESTClient(ctx, access.ClientOptions...)
+	if err != nil {
+		log.Error().Err(err).Str("project", access.ProjectID).Msg("Failed to create GCP zone operations client.")
+		return nil, err
+	}
+	return client, nil
+}
@@ -1,9 +1,11 @@
 package extvm
 
 const (
-	TargetIDVM                  = "com.steadybit.extension_gcp.vm"
-	VirtualMachineStateActionId = "com.steadybit.extension_gcp.vm.state"
-	targetIcon                  = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMzgwLjcsMzk2LjdoLTI0OS4zYy04LjgsMC0xNi03LjItMTYtMTZ2LTI0OS4zYzAtOC44LDcuMi0xNiwxNi0xNmgyNDkuM2M4LjgsMCwxNiw3LjIsMTYsMTZ2MjQ5LjNjMCw4LjgtNy4yLDE2LTE2LDE2Wk0xNDcuMywzNjQuN2gyMTcuM3YtMjE3LjNoLTIxNy4zdjIxNy4zWiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0xNDcuMywzNjQuN2gtMzJ2LTIzMy4zYzAtOC44LDcuMi0xNiwxNi0xNmgxNDYuMXYzMmgtMTMwLjF2MjE3LjNoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQzLDM2NC43aC02Mi4zYy04LjgsMC0xNi03LjItMTYtMTZzNy4yLTE2LDE2LTE2aDYyLjNjOC44LDAsMTYsNy4yLDE2LDE2cy03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTQ0MywyNzJoLTYyLjNjLTguOCwwLTE2LTcuMi0xNi0xNnM3LjItMTYsMTYtMTZoNjIuM2M4LjgsMCwxNiw3LjIsMTYsMTZzLTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQzLDE3OC41aC02Mi4zYy04LjgsMC0xNi03LjItMTYtMTZzNy4yLTE2LDE2LTE2aDYyLjNjOC44LDAsMTYsNy4yLDE2LDE2cy03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTM0OS41LDE0Ny4zYy04LjgsMC0xNi03LjItMTYtMTZ2LTYyLjNjMC04LjgsNy4yLTE2LDE2LTE2czE2LDcuMiwxNiwxNnY2Mi4zYzAsOC44LTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDE0Ny4zYy04LjgsMC0xNi03LjItMTYtMTZ2LTYyLjNjMC04LjgsNy4yLTE2LDE2LTE2czE2LDcuMiwxNiwxNnY2Mi4zYzAsOC44LTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMTYyLjUsMTQ3LjNjLTguOCwwLTE2LTcuMi0xNi0xNnYtNjIuM2MwLTguOCw3LjItMTYsMTYtMTZzMTYsNy4yLDE2LDE2djYyLjNjMCw4LjgtNy4yLDE2LTE2LDE2WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0zMTUsMzMxaC0xMThjLTguOCwwLTE2LTcuMi0xNi0xNnYtMTE4YzAtOC44LDcuMi0xNiwxNi0xNmgxMThjOC44LDAsMTYsNy4yLDE2LDE2djExOGMwLDguOC03LjIsMTYtMTYsMTZaTTIxMywyOTloODZ2LTg2aC04NnY4NloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMzk2LjcsMzMyLjdoLTMydi0xODUuM2gtMTI0LjZ2LTMyaDE0MC42YzguOCwwLDE2LDcuMiwxNiwxNnYyMDEuM1oiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMTYyLjUsNDU5Yy04LjgsMC0xNi03LjItMTYtMTZ2LTYyLjNjMC04LjgsNy4yLTE2LDE2LTE2czE2LDcuMiwxNiwxNnY2Mi4zYzAsOC44LTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDQ1OWMtOC44LDAtMTYtNy4yLTE2LTE2di02Mi4zYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NjIuM2MwLDguOC03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTM0OS41LDQ1OWMtOC44LDAtMTYtNy4yLTE2LTE2di02Mi4zYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NjIuM2MwLDguOC03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEzMS4zLDE3OC41aC02Mi4zYy04LjgsMC0xNi03LjItMTYtMTZzNy4yLTE2LDE2LTE2aDYyLjNjOC44LDAsMTYsNy4yLDE2LDE2cy03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEzMS4zLDI3MmgtNjIuM2MtOC44LDAtMTYtNy4yLTE2LTE2czcuMi0xNiwxNi0xNmg2Mi4zYzguOCwwLDE2LDcuMiwxNiwxNnMtNy4yLDE2LTE2LDE2WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0xMzEuMywzNjQuN2gtNjIuM2MtOC44LDAtMTYtNy4yLTE2LTE2czcuMi0xNiwxNi0xNmg2Mi4zYzguOCwwLDE2LDcuMiwxNiwxNnMtNy4yLDE2LTE2LDE2WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgo8L3N2Zz4="
+	TargetIDVM                      = "com.steadybit.extension_gcp.vm"
+	VirtualMachineStateActionId     = "com.steadybit.extension_gcp.vm.state"
+	VirtualMachineStopActionId      = "com.steadybit.extension_gcp.vm.stop-for-duration"
+	VirtualMachineBlackholeActionId = "com.steadybit.extension_gcp.vm.blackhole"
+	targetIcon                      = "data:image/svg+xml;base64,PHN2ZyB2aWV3Qm94PSIwIDAgNTEyIDUxMiIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KICA8cGF0aCBkPSJNMzgwLjcsMzk2LjdoLTI0OS4zYy04LjgsMC0xNi03LjItMTYtMTZ2LTI0OS4zYzAtOC44LDcuMi0xNiwxNi0xNmgyNDkuM2M4LjgsMCwxNiw3LjIsMTYsMTZ2MjQ5LjNjMCw4LjgtNy4yLDE2LTE2LDE2Wk0xNDcuMywzNjQuN2gyMTcuM3YtMjE3LjNoLTIxNy4zdjIxNy4zWiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0xNDcuMywzNjQuN2gtMzJ2LTIzMy4zYzAtOC44LDcuMi0xNiwxNi0xNmgxNDYuMXYzMmgtMTMwLjF2MjE3LjNoMFoiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQzLDM2NC43aC02Mi4zYy04LjgsMC0xNi03LjItMTYtMTZzNy4yLTE2LDE2LTE2aDYyLjNjOC44LDAsMTYsNy4yLDE2LDE2cy03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTQ0MywyNzJoLTYyLjNjLTguOCwwLTE2LTcuMi0xNi0xNnM3LjItMTYsMTYtMTZoNjIuM2M4LjgsMCwxNiw3LjIsMTYsMTZzLTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNNDQzLDE3OC41aC02Mi4zYy04LjgsMC0xNi03LjItMTYtMTZzNy4yLTE2LDE2LTE2aDYyLjNjOC44LDAsMTYsNy4yLDE2LDE2cy03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTM0OS41LDE0Ny4zYy04LjgsMC0xNi03LjItMTYtMTZ2LTYyLjNjMC04LjgsNy4yLTE2LDE2LTE2czE2LDcuMiwxNiwxNnY2Mi4zYzAsOC44LTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDE0Ny4zYy04LjgsMC0xNi03LjItMTYtMTZ2LTYyLjNjMC04LjgsNy4yLTE2LDE2LTE2czE2LDcuMiwxNiwxNnY2Mi4zYzAsOC44LTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMTYyLjUsMTQ3LjNjLTguOCwwLTE2LTcuMi0xNi0xNnYtNjIuM2MwLTguOCw3LjItMTYsMTYtMTZzMTYsNy4yLDE2LDE2djYyLjNjMCw4LjgtNy4yLDE2LTE2LDE2WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0zMTUsMzMxaC0xMThjLTguOCwwLTE2LTcuMi0xNi0xNnYtMTE4YzAtOC44LDcuMi0xNiwxNi0xNmgxMThjOC44LDAsMTYsNy4yLDE2LDE2djExOGMwLDguOC03LjIsMTYtMTYsMTZaTTIxMywyOTloODZ2LTg2aC04NnY4NloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMzk2LjcsMzMyLjdoLTMydi0xODUuM2gtMTI0LjZ2LTMyaDE0MC42YzguOCwwLDE2LDcuMiwxNiwxNnYyMDEuM1oiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMTYyLjUsNDU5Yy04LjgsMC0xNi03LjItMTYtMTZ2LTYyLjNjMC04LjgsNy4yLTE2LDE2LTE2czE2LDcuMiwxNiwxNnY2Mi4zYzAsOC44LTcuMiwxNi0xNiwxNloiIGZpbGw9ImN1cnJlbnRDb2xvciIgLz4KICA8cGF0aCBkPSJNMjU2LDQ1OWMtOC44LDAtMTYtNy4yLTE2LTE2di02Mi4zYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NjIuM2MwLDguOC03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTM0OS41LDQ1OWMtOC44LDAtMTYtNy4yLTE2LTE2di02Mi4zYzAtOC44LDcuMi0xNiwxNi0xNnMxNiw3LjIsMTYsMTZ2NjIuM2MwLDguOC03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEzMS4zLDE3OC41aC02Mi4zYy04LjgsMC0xNi03LjItMTYtMTZzNy4yLTE2LDE2LTE2aDYyLjNjOC44LDAsMTYsNy4yLDE2LDE2cy03LjIsMTYtMTYsMTZaIiBmaWxsPSJjdXJyZW50Q29sb3IiIC8+CiAgPHBhdGggZD0iTTEzMS4zLDI3MmgtNjIuM2MtOC44LDAtMTYtNy4yLTE2LTE2czcuMi0xNiwxNi0xNmg2Mi4zYzguOCwwLDE2LDcuMiwxNiwxNnMtNy4yLDE2LTE2LDE2WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgogIDxwYXRoIGQ9Ik0xMzEuMywzNjQuN2gtNjIuM2MtOC44LDAtMTYtNy4yLTE2LTE2czcuMi0xNiwxNi0xNmg2Mi4zYzguOCwwLDE2LDcuMiwxNiwxNnMtNy4yLDE2LTE2LDE2WiIgZmlsbD0iY3VycmVudENvbG9yIiAvPgo8L3N2Zz4="
 
 	// Attribute names extracted per Sonar go:S1192.
 	attrVmID        = "gcp-vm.id"
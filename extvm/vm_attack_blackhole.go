@@ -0,0 +1,424 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extvm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	blackholeTagPrefix = "steadybit-blackhole-"
+	// Deny rules sit one step below the allow rules: at equal priority a deny wins, so the exceptions need priority 0.
+	blackholeDenyPriority  = int32(1)
+	blackholeAllowPriority = int32(0)
+	setTagsMaxAttempts     = 3
+)
+
+// Google's load balancer and health check probe ranges, see
+// https://cloud.google.com/load-balancing/docs/health-check-concepts#ip-ranges
+var healthCheckSourceRanges = []string{"35.191.0.0/16", "130.211.0.0/22"}
+
+// VirtualMachineBlackholeState holds everything Stop needs to undo the isolation: the network tag added to the
+// instance and the networks the firewall rules were created in. Rule names are derived from the tag, so Start and
+// Stop agree on them without storing the rules themselves. Only IPv4 traffic is blocked.
+type VirtualMachineBlackholeState struct {
+	ProjectId         string
+	VmName            string
+	Zone              string
+	NetworkTag        string
+	Networks          []string
+	SshSourceRanges   []string
+	AllowHealthChecks bool
+}
+
+type instanceTagsApi interface {
+	Get(ctx context.Context, req *computepb.GetInstanceRequest, opts ...gax.CallOption) (*computepb.Instance, error)
+	SetTags(ctx context.Context, req *computepb.SetTagsInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Close() error
+}
+
+type firewallsApi interface {
+	Insert(ctx context.Context, req *computepb.InsertFirewallRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Delete(ctx context.Context, req *computepb.DeleteFirewallRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Close() error
+}
+
+type virtualMachineBlackholeAction struct {
+	instancesClientProvider func(ctx context.Context, projectID string) (instanceTagsApi, error)
+	firewallsClientProvider func(ctx context.Context, projectID string) (firewallsApi, error)
+}
+
+var _ action_kit_sdk.Action[VirtualMachineBlackholeState] = (*virtualMachineBlackholeAction)(nil)
+var _ action_kit_sdk.ActionWithStop[VirtualMachineBlackholeState] = (*virtualMachineBlackholeAction)(nil)
+
+func NewVirtualMachineBlackholeAction() action_kit_sdk.ActionWithStop[VirtualMachineBlackholeState] {
+	return &virtualMachineBlackholeAction{
+		instancesClientProvider: func(ctx context.Context, projectID string) (instanceTagsApi, error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, err
+			}
+			return newInstancesClientForAccess(ctx, access)
+		},
+		firewallsClientProvider: func(ctx context.Context, projectID string) (firewallsApi, error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, err
+			}
+			return compute.NewFirewallsRESTClient(ctx, access.ClientOptions...)
+		},
+	}
+}
+
+func (e *virtualMachineBlackholeAction) NewEmptyState() VirtualMachineBlackholeState {
+	return VirtualMachineBlackholeState{}
+}
+
+func (e *virtualMachineBlackholeAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          VirtualMachineBlackholeActionId,
+		Label:       "Blackhole Virtual Machine",
+		Description: "Isolates Google Cloud virtual machines from the network with a deny-all VPC firewall rule bound to a temporary network tag. Tag and rules are removed on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(targetIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: TargetIDVM,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "vm-name",
+					Description: new("Find gcp virtual machine by name"),
+					Query:       "gcp-vm.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("GCP"),
+		Category:    new("Virtual Machines"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("How long the virtual machine stays isolated. Network access is restored on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("60s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "sshSourceRanges",
+				Label:       "Keep SSH open from",
+				Description: new("Comma-separated CIDR ranges still allowed to reach TCP port 22, e.g. 35.235.240.0/20 for IAP. Empty blocks SSH as well."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(2),
+				Required:    new(false),
+				Advanced:    new(true),
+			},
+			{
+				Name:         "allowHealthChecks",
+				Label:        "Keep health checks open",
+				Description:  new("Still allow ingress from Google's health check ranges, so load balancers keep routing to the virtual machine and only its outbound traffic and clients are cut."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("false"),
+				Order:        new(3),
+				Required:     new(false),
+				Advanced:     new(true),
+			},
+		},
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (e *virtualMachineBlackholeAction) Prepare(ctx context.Context, state *VirtualMachineBlackholeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	vmName := request.Target.Attributes["gcp-vm.name"]
+	zone := request.Target.Attributes[attrZone]
+	projectId := request.Target.Attributes[attrProjectID]
+	if len(vmName) == 0 || len(zone) == 0 || len(projectId) == 0 {
+		return nil, extension_kit.ToError("Target is missing one of: gcp-vm.name, gcp.zone, gcp.project.id", nil)
+	}
+	state.VmName = vmName[0]
+	state.Zone = zone[0]
+	state.ProjectId = projectId[0]
+	state.NetworkTag = blackholeTag(request.ExecutionId.String(), state.ProjectId, state.Zone, state.VmName)
+	state.SshSourceRanges = splitRanges(extutil.ToString(request.Config["sshSourceRanges"]))
+	state.AllowHealthChecks = extutil.ToBool(request.Config["allowHealthChecks"])
+
+	client, err := e.instancesClientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to initialize gcp client", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get vm '%s'", state.VmName), err)
+	}
+	state.Networks = nil
+	for _, nic := range instance.GetNetworkInterfaces() {
+		if network := nic.GetNetwork(); network != "" && !slices.Contains(state.Networks, network) {
+			state.Networks = append(state.Networks, network)
+		}
+	}
+	if len(state.Networks) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("vm '%s' has no network interfaces to isolate", state.VmName), nil)
+	}
+
+	rules := blackholeFirewallRules(state)
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Firewall.GetName())
+	}
+	return &action_kit_api.PrepareResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("Will tag vm '%s' with '%s' and create firewall rules %s", state.VmName, state.NetworkTag, strings.Join(names, ", ")),
+		}}),
+	}, nil
+}
+
+func (e *virtualMachineBlackholeAction) Start(ctx context.Context, state *VirtualMachineBlackholeState) (*action_kit_api.StartResult, error) {
+	// Rules first: a tag without its rules is harmless, so a failure between the two steps never half-isolates.
+	if err := e.createFirewallRules(ctx, state); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to create blackhole firewall rules for vm '%s'", state.VmName), err)
+	}
+	if err := e.updateTags(ctx, state, true); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to add network tag '%s' to vm '%s'", state.NetworkTag, state.VmName), err)
+	}
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("vm '%s' is isolated via network tag '%s' until stop", state.VmName, state.NetworkTag),
+		}}),
+	}, nil
+}
+
+func (e *virtualMachineBlackholeAction) Stop(ctx context.Context, state *VirtualMachineBlackholeState) (*action_kit_api.StopResult, error) {
+	// Both steps run even if one fails: deleting the rules alone already restores connectivity.
+	err := errors.Join(e.updateTags(ctx, state, false), e.deleteFirewallRules(ctx, state))
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to remove blackhole from vm '%s'", state.VmName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to remove blackhole from vm '%s'", state.VmName), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("Removed network tag '%s' and its firewall rules from vm '%s'", state.NetworkTag, state.VmName),
+		}}),
+	}, nil
+}
+
+// createFirewallRules inserts every rule and waits for it to be active. A rule that already exists (Start retried,
+// or a leftover from an aborted run with the same tag) is reused.
+func (e *virtualMachineBlackholeAction) createFirewallRules(ctx context.Context, state *VirtualMachineBlackholeState) error {
+	client, err := e.firewallsClientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	for _, rule := range blackholeFirewallRules(state) {
+		op, err := client.Insert(ctx, &computepb.InsertFirewallRequest{Project: rule.Project, FirewallResource: rule.Firewall})
+		if isHTTPStatus(err, http.StatusConflict) {
+			log.Info().Msgf("Firewall rule '%s' already exists — reusing it", rule.Firewall.GetName())
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("insert firewall rule '%s': %w", rule.Firewall.GetName(), err)
+		}
+		if err := utils.WaitForComputeOperation(ctx, op); err != nil {
+			return fmt.Errorf("insert firewall rule '%s': %w", rule.Firewall.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// deleteFirewallRules is idempotent: rules that are already gone are skipped.
+func (e *virtualMachineBlackholeAction) deleteFirewallRules(ctx context.Context, state *VirtualMachineBlackholeState) error {
+	client, err := e.firewallsClientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	var errs []error
+	for _, rule := range blackholeFirewallRules(state) {
+		op, err := client.Delete(ctx, &computepb.DeleteFirewallRequest{Project: rule.Project, Firewall: rule.Firewall.GetName()})
+		if isHTTPStatus(err, http.StatusNotFound) {
+			continue
+		}
+		if err == nil {
+			err = utils.WaitForComputeOperation(ctx, op)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete firewall rule '%s': %w", rule.Firewall.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateTags adds or removes the blackhole tag. SetTags is guarded by the tags fingerprint, so a concurrent edit
+// makes it fail with 412; the instance is then re-read and the update retried.
+func (e *virtualMachineBlackholeAction) updateTags(ctx context.Context, state *VirtualMachineBlackholeState, add bool) error {
+	client, err := e.instancesClientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	for attempt := 1; ; attempt++ {
+		instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName})
+		if !add && isHTTPStatus(err, http.StatusNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get vm: %w", err)
+		}
+
+		items := instance.GetTags().GetItems()
+		present := slices.Contains(items, state.NetworkTag)
+		if present == add {
+			return nil
+		}
+		if add {
+			items = append(slices.Clone(items), state.NetworkTag)
+		} else {
+			items = slices.DeleteFunc(slices.Clone(items), func(tag string) bool { return tag == state.NetworkTag })
+		}
+
+		op, err := client.SetTags(ctx, &computepb.SetTagsInstanceRequest{
+			Project:  state.ProjectId,
+			Zone:     state.Zone,
+			Instance: state.VmName,
+			TagsResource: &computepb.Tags{
+				Items:       items,
+				Fingerprint: instance.GetTags().Fingerprint,
+			},
+		})
+		if isHTTPStatus(err, http.StatusPreconditionFailed) && attempt < setTagsMaxAttempts {
+			log.Debug().Msgf("Tags of vm '%s' changed concurrently — retrying", state.VmName)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("set tags: %w", err)
+		}
+		return utils.WaitForComputeOperation(ctx, op)
+	}
+}
+
+type blackholeFirewallRule struct {
+	Project  string
+	Firewall *computepb.Firewall
+}
+
+// blackholeFirewallRules derives the rule set from the state. Rules are created in the project owning each network,
+// which differs from the instance project for Shared VPC.
+func blackholeFirewallRules(state *VirtualMachineBlackholeState) []blackholeFirewallRule {
+	description := new(fmt.Sprintf("Created by Steadybit to blackhole vm '%s'. Safe to delete once the experiment has ended.", state.VmName))
+	var rules []blackholeFirewallRule
+	for i, network := range state.Networks {
+		project := projectOfNetwork(network, state.ProjectId)
+		prefix := fmt.Sprintf("%s-%d", state.NetworkTag, i)
+		rules = append(rules,
+			blackholeFirewallRule{project, &computepb.Firewall{
+				Name:         new(prefix + "-deny-in"),
+				Description:  description,
+				Network:      new(network),
+				Direction:    new(computepb.Firewall_INGRESS.String()),
+				Priority:     new(blackholeDenyPriority),
+				SourceRanges: []string{"0.0.0.0/0"},
+				TargetTags:   []string{state.NetworkTag},
+				Denied:       []*computepb.Denied{{IPProtocol: new("all")}},
+			}},
+			blackholeFirewallRule{project, &computepb.Firewall{
+				Name:              new(prefix + "-deny-out"),
+				Description:       description,
+				Network:           new(network),
+				Direction:         new(computepb.Firewall_EGRESS.String()),
+				Priority:          new(blackholeDenyPriority),
+				DestinationRanges: []string{"0.0.0.0/0"},
+				TargetTags:        []string{state.NetworkTag},
+				Denied:            []*computepb.Denied{{IPProtocol: new("all")}},
+			}},
+		)
+		if len(state.SshSourceRanges) > 0 {
+			rules = append(rules, blackholeFirewallRule{project, &computepb.Firewall{
+				Name:         new(prefix + "-allow-ssh"),
+				Description:  description,
+				Network:      new(network),
+				Direction:    new(computepb.Firewall_INGRESS.String()),
+				Priority:     new(blackholeAllowPriority),
+				SourceRanges: state.SshSourceRanges,
+				TargetTags:   []string{state.NetworkTag},
+				Allowed:      []*computepb.Allowed{{IPProtocol: new("tcp"), Ports: []string{"22"}}},
+			}})
+		}
+		if state.AllowHealthChecks {
+			rules = append(rules, blackholeFirewallRule{project, &computepb.Firewall{
+				Name:         new(prefix + "-allow-hc"),
+				Description:  description,
+				Network:      new(network),
+				Direction:    new(computepb.Firewall_INGRESS.String()),
+				Priority:     new(blackholeAllowPriority),
+				SourceRanges: healthCheckSourceRanges,
+				TargetTags:   []string{state.NetworkTag},
+				Allowed:      []*computepb.Allowed{{IPProtocol: new("tcp")}},
+			}})
+		}
+	}
+	return rules
+}
+
+// blackholeTag is unique per target and execution, so parallel targets never share (and prematurely delete) rules,
+// while a retried Start within the same execution finds its own rules again.
+func blackholeTag(executionID, projectID, zone, vmName string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{executionID, projectID, zone, vmName}, "/")))
+	return blackholeTagPrefix + hex.EncodeToString(sum[:])[:12]
+}
+
+// projectOfNetwork extracts the project from a network URL such as
+// https://www.googleapis.com/compute/v1/projects/host-project/global/networks/default.
+func projectOfNetwork(network, fallback string) string {
+	parts := strings.Split(network, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "projects" {
+			return parts[i+1]
+		}
+	}
+	return fallback
+}
+
+func splitRanges(value string) []string {
+	var ranges []string
+	for _, r := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(r); trimmed != "" {
+			ranges = append(ranges, trimmed)
+		}
+	}
+	return ranges
+}
+
+func isHTTPStatus(err error, code int) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
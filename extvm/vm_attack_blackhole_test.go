@@ -0,0 +1,259 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extvm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+type firewallsApiMock struct {
+	mock.Mock
+}
+
+func (m *firewallsApiMock) Insert(ctx context.Context, req *computepb.InsertFirewallRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	args := m.Called(ctx, req)
+	return nil, args.Error(1)
+}
+
+func (m *firewallsApiMock) Delete(ctx context.Context, req *computepb.DeleteFirewallRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	args := m.Called(ctx, req)
+	return nil, args.Error(1)
+}
+
+func (m *firewallsApiMock) Close() error {
+	return nil
+}
+
+func newBlackholeTestAction(api *gcpClientApiMock, firewalls *firewallsApiMock) *virtualMachineBlackholeAction {
+	return &virtualMachineBlackholeAction{
+		instancesClientProvider: func(ctx context.Context, projectID string) (instanceTagsApi, error) {
+			return api, nil
+		},
+		firewallsClientProvider: func(ctx context.Context, projectID string) (firewallsApi, error) {
+			return firewalls, nil
+		},
+	}
+}
+
+func blackholeState() *VirtualMachineBlackholeState {
+	return &VirtualMachineBlackholeState{
+		ProjectId:  "42",
+		VmName:     "my-vm",
+		Zone:       "us-central1-a",
+		NetworkTag: "steadybit-blackhole-abc",
+		Networks:   []string{"https://www.googleapis.com/compute/v1/projects/42/global/networks/default"},
+	}
+}
+
+func TestVirtualMachineBlackhole_Describe(t *testing.T) {
+	a := &virtualMachineBlackholeAction{}
+	desc := a.Describe()
+	assert.Equal(t, VirtualMachineBlackholeActionId, desc.Id)
+	assert.Equal(t, TargetIDVM, desc.TargetSelection.TargetType)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, VirtualMachineBlackholeState{}, a.NewEmptyState())
+}
+
+func TestVirtualMachineBlackhole_NewAction(t *testing.T) {
+	assert.NotNil(t, NewVirtualMachineBlackholeAction())
+}
+
+func TestVirtualMachineBlackhole_Prepare_MissingRequiredAttr(t *testing.T) {
+	for _, drop := range []string{"gcp-vm.name", "gcp.zone", "gcp.project.id"} {
+		attrs := map[string][]string{}
+		for k, v := range validStopAttrs {
+			if k != drop {
+				attrs[k] = v
+			}
+		}
+		a := &virtualMachineBlackholeAction{}
+		state := VirtualMachineBlackholeState{}
+		_, err := a.Prepare(context.Background(), &state, stopPrepareReq(attrs, map[string]any{}))
+		require.Error(t, err, "dropping %s should fail Prepare", drop)
+		assert.Contains(t, err.Error(), "missing")
+	}
+}
+
+func TestVirtualMachineBlackhole_Prepare_CollectsNetworksAndOptions(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{NetworkInterfaces: []*computepb.NetworkInterface{
+		{Network: new("https://www.googleapis.com/compute/v1/projects/host/global/networks/shared")},
+		{Network: new("https://www.googleapis.com/compute/v1/projects/host/global/networks/shared")},
+		{Network: new("https://www.googleapis.com/compute/v1/projects/42/global/networks/default")},
+	}}, nil)
+	a := newBlackholeTestAction(api, nil)
+	state := VirtualMachineBlackholeState{}
+
+	res, err := a.Prepare(context.Background(), &state, stopPrepareReq(validStopAttrs, map[string]any{
+		"sshSourceRanges":   "35.235.240.0/20, 10.0.0.0/8",
+		"allowHealthChecks": true,
+	}))
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Len(t, state.Networks, 2)
+	assert.Equal(t, []string{"35.235.240.0/20", "10.0.0.0/8"}, state.SshSourceRanges)
+	assert.True(t, state.AllowHealthChecks)
+	assert.Regexp(t, `^steadybit-blackhole-[0-9a-f]{12}$`, state.NetworkTag)
+}
+
+func TestVirtualMachineBlackhole_Prepare_RefusesVmWithoutNetwork(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{}, nil)
+	a := newBlackholeTestAction(api, nil)
+	state := VirtualMachineBlackholeState{}
+
+	_, err := a.Prepare(context.Background(), &state, stopPrepareReq(validStopAttrs, map[string]any{}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no network interfaces")
+}
+
+func TestBlackholeFirewallRules(t *testing.T) {
+	state := blackholeState()
+	state.Networks = append(state.Networks, "https://www.googleapis.com/compute/v1/projects/host/global/networks/shared")
+	state.SshSourceRanges = []string{"35.235.240.0/20"}
+	state.AllowHealthChecks = true
+
+	rules := blackholeFirewallRules(state)
+
+	require.Len(t, rules, 8)
+	assert.Equal(t, "42", rules[0].Project)
+	assert.Equal(t, "steadybit-blackhole-abc-0-deny-in", rules[0].Firewall.GetName())
+	assert.Equal(t, "INGRESS", rules[0].Firewall.GetDirection())
+	assert.Equal(t, int32(1), rules[0].Firewall.GetPriority())
+	assert.Equal(t, []string{"steadybit-blackhole-abc"}, rules[0].Firewall.GetTargetTags())
+	assert.Equal(t, "EGRESS", rules[1].Firewall.GetDirection())
+	assert.Equal(t, []string{"0.0.0.0/0"}, rules[1].Firewall.GetDestinationRanges())
+	assert.Equal(t, "steadybit-blackhole-abc-0-allow-ssh", rules[2].Firewall.GetName())
+	assert.Equal(t, int32(0), rules[2].Firewall.GetPriority())
+	assert.Equal(t, []string{"22"}, rules[2].Firewall.GetAllowed()[0].GetPorts())
+	assert.Equal(t, "steadybit-blackhole-abc-0-allow-hc", rules[3].Firewall.GetName())
+	assert.Equal(t, "host", rules[4].Project)
+	assert.Equal(t, "steadybit-blackhole-abc-1-deny-in", rules[4].Firewall.GetName())
+}
+
+func TestBlackholeTag_IsStablePerTarget(t *testing.T) {
+	a := blackholeTag("exec", "42", "us-central1-a", "vm-a")
+	assert.Equal(t, a, blackholeTag("exec", "42", "us-central1-a", "vm-a"))
+	assert.NotEqual(t, a, blackholeTag("exec", "42", "us-central1-a", "vm-b"))
+	assert.LessOrEqual(t, len(a+"-0-allow-ssh"), 63)
+}
+
+func TestVirtualMachineBlackhole_StartCreatesRulesThenAddsTag(t *testing.T) {
+	firewalls := new(firewallsApiMock)
+	firewalls.On("Insert", mock.Anything, mock.MatchedBy(func(req *computepb.InsertFirewallRequest) bool {
+		return req.FirewallResource.GetName() == "steadybit-blackhole-abc-0-deny-in"
+	})).Return(nil, nil)
+	// An existing rule (e.g. Start retried) is reused.
+	firewalls.On("Insert", mock.Anything, mock.MatchedBy(func(req *computepb.InsertFirewallRequest) bool {
+		return req.FirewallResource.GetName() == "steadybit-blackhole-abc-0-deny-out"
+	})).Return(nil, &googleapi.Error{Code: http.StatusConflict})
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Tags: &computepb.Tags{Items: []string{"http-server"}, Fingerprint: new("fp-1")}}, nil)
+	api.On("SetTags", mock.Anything, mock.MatchedBy(func(req *computepb.SetTagsInstanceRequest) bool {
+		return assert.ObjectsAreEqual([]string{"http-server", "steadybit-blackhole-abc"}, req.TagsResource.GetItems()) &&
+			req.TagsResource.GetFingerprint() == "fp-1"
+	})).Return(nil, nil)
+	a := newBlackholeTestAction(api, firewalls)
+
+	res, err := a.Start(context.Background(), blackholeState())
+
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+	firewalls.AssertExpectations(t)
+	api.AssertExpectations(t)
+}
+
+func TestVirtualMachineBlackhole_StartRetriesOnFingerprintConflict(t *testing.T) {
+	firewalls := new(firewallsApiMock)
+	firewalls.On("Insert", mock.Anything, mock.Anything).Return(nil, nil)
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Tags: &computepb.Tags{Fingerprint: new("fp-1")}}, nil).Once()
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Tags: &computepb.Tags{Fingerprint: new("fp-2")}}, nil).Once()
+	api.On("SetTags", mock.Anything, mock.MatchedBy(func(req *computepb.SetTagsInstanceRequest) bool {
+		return req.TagsResource.GetFingerprint() == "fp-1"
+	})).Return(nil, &googleapi.Error{Code: http.StatusPreconditionFailed}).Once()
+	api.On("SetTags", mock.Anything, mock.MatchedBy(func(req *computepb.SetTagsInstanceRequest) bool {
+		return req.TagsResource.GetFingerprint() == "fp-2"
+	})).Return(nil, nil).Once()
+	a := newBlackholeTestAction(api, firewalls)
+
+	_, err := a.Start(context.Background(), blackholeState())
+
+	require.NoError(t, err)
+	api.AssertExpectations(t)
+}
+
+func TestVirtualMachineBlackhole_StopRemovesTagAndRules(t *testing.T) {
+	firewalls := new(firewallsApiMock)
+	firewalls.On("Delete", mock.Anything, mock.MatchedBy(func(req *computepb.DeleteFirewallRequest) bool {
+		return req.Firewall == "steadybit-blackhole-abc-0-deny-in"
+	})).Return(nil, nil)
+	// Already deleted rules are skipped.
+	firewalls.On("Delete", mock.Anything, mock.MatchedBy(func(req *computepb.DeleteFirewallRequest) bool {
+		return req.Firewall == "steadybit-blackhole-abc-0-deny-out"
+	})).Return(nil, &googleapi.Error{Code: http.StatusNotFound})
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Tags: &computepb.Tags{Items: []string{"http-server", "steadybit-blackhole-abc"}, Fingerprint: new("fp-1")}}, nil)
+	api.On("SetTags", mock.Anything, mock.MatchedBy(func(req *computepb.SetTagsInstanceRequest) bool {
+		return assert.ObjectsAreEqual([]string{"http-server"}, req.TagsResource.GetItems())
+	})).Return(nil, nil)
+	a := newBlackholeTestAction(api, firewalls)
+
+	res, err := a.Stop(context.Background(), blackholeState())
+
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+	firewalls.AssertExpectations(t)
+	api.AssertExpectations(t)
+}
+
+func TestVirtualMachineBlackhole_StopDeletesRulesEvenIfTagRemovalFails(t *testing.T) {
+	firewalls := new(firewallsApiMock)
+	firewalls.On("Delete", mock.Anything, mock.Anything).Return(nil, nil)
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(nil, &googleapi.Error{Code: http.StatusForbidden})
+	a := newBlackholeTestAction(api, firewalls)
+
+	_, err := a.Stop(context.Background(), blackholeState())
+
+	require.Error(t, err)
+	firewalls.AssertNumberOfCalls(t, "Delete", 2)
+}
+
+func TestVirtualMachineBlackhole_StopToleratesDeletedVm(t *testing.T) {
+	firewalls := new(firewallsApiMock)
+	firewalls.On("Delete", mock.Anything, mock.Anything).Return(nil, nil)
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(nil, &googleapi.Error{Code: http.StatusNotFound})
+	a := newBlackholeTestAction(api, firewalls)
+
+	_, err := a.Stop(context.Background(), blackholeState())
+
+	require.NoError(t, err)
+	api.AssertNotCalled(t, "SetTags", mock.Anything, mock.Anything)
+}
+
+func TestVirtualMachineBlackhole_DescribeHasOptionalExceptions(t *testing.T) {
+	desc := (&virtualMachineBlackholeAction{}).Describe()
+	names := make([]string, 0, len(desc.Parameters))
+	for _, p := range desc.Parameters {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"duration", "sshSourceRanges", "allowHealthChecks"}, names)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+}
@@ -7,28 +7,43 @@ package extvm
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	compute "cloud.google.com/go/compute/apiv1"
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
 	"github.com/steadybit/extension-gcp/utils"
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
 )
 
 type virtualMachineStateAction struct {
-	clientProvider func(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error)
+	clientProvider           func(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error)
+	operationsClientProvider func(ctx context.Context, projectID string) (zoneOperationsApi, error)
+	snapshotsClientProvider  func(ctx context.Context, projectID string) (snapshotsApi, error)
 }
 
 var _ action_kit_sdk.Action[VirtualMachineStateChangeState] = (*virtualMachineStateAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[VirtualMachineStateChangeState] = (*virtualMachineStateAction)(nil)
 
+// VirtualMachineStateChangeState carries the zonal operation returned by the state change so that Status can
+// follow it until DONE. ObservedStatuses is the ordered list of distinct instance statuses seen so far, seeded
+// with the discovered status of the target. Snapshots lists the safety-net snapshots taken in Prepare, if any; they
+// are never deleted by the extension.
 type VirtualMachineStateChangeState struct {
-	ProjectId string
-	VmName    string
-	Zone      string
-	Action    string
+	ProjectId        string
+	VmName           string
+	Zone             string
+	Action           string
+	OperationName    string
+	ObservedStatuses []string
+	Snapshots        []string
 }
 
 type virtualMachineStateChangeApi interface {
@@ -37,10 +52,23 @@ type virtualMachineStateChangeApi interface {
 	Reset(ctx context.Context, req *computepb.ResetInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
 	Suspend(ctx context.Context, req *computepb.SuspendInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
 	Start(ctx context.Context, req *computepb.StartInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Resume(ctx context.Context, req *computepb.ResumeInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Get(ctx context.Context, req *computepb.GetInstanceRequest, opts ...gax.CallOption) (*computepb.Instance, error)
+	Close() error
 }
 
-func NewVirtualMachineStateAction() action_kit_sdk.Action[VirtualMachineStateChangeState] {
-	return &virtualMachineStateAction{defaultClientProvider}
+type zoneOperationsApi interface {
+	Get(ctx context.Context, req *computepb.GetZoneOperationRequest, opts ...gax.CallOption) (*computepb.Operation, error)
+	Wait(ctx context.Context, req *computepb.WaitZoneOperationRequest, opts ...gax.CallOption) (*computepb.Operation, error)
+	Close() error
+}
+
+func NewVirtualMachineStateAction() action_kit_sdk.ActionWithStatus[VirtualMachineStateChangeState] {
+	return &virtualMachineStateAction{
+		clientProvider:           defaultClientProvider,
+		operationsClientProvider: defaultOperationsClientProvider,
+		snapshotsClientProvider:  defaultSnapshotsClientProvider,
+	}
 }
 
 func (e *virtualMachineStateAction) NewEmptyState() VirtualMachineStateChangeState {
@@ -71,7 +99,7 @@ func (e *virtualMachineStateAction) Describe() action_kit_api.ActionDescription
 		}),
 		Technology:  new("GCP"),
 		Category:    new("Virtual Machines"),
-		TimeControl: action_kit_api.TimeControlInstantaneous,
+		TimeControl: action_kit_api.TimeControlInternal,
 		Kind:        action_kit_api.Attack,
 		Parameters: []action_kit_api.ActionParameter{
 			{
@@ -103,11 +131,33 @@ func (e *virtualMachineStateAction) Describe() action_kit_api.ActionDescription
 					},
 				}),
 			},
+			{
+				Name:         "snapshotBeforeAttack",
+				Label:        "Snapshot disks before attack",
+				Description:  new("Snapshot every attached persistent disk and wait until the snapshots are ready before the attack starts. Always done before 'delete' when the extension is configured with STEADYBIT_EXTENSION_SNAPSHOT_BEFORE_ATTACK."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("false"),
+				Required:     new(false),
+				Advanced:     new(true),
+			},
+			{
+				Name:         "snapshotRetentionDays",
+				Label:        "Snapshot retention (days)",
+				Description:  new("Labels the snapshots with a steadybit-delete-after date this many days ahead. The extension never deletes snapshots; removing expired ones is up to your own cleanup job. 0 adds no such label."),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: new("0"),
+				Required:     new(false),
+				Advanced:     new(true),
+				MinValue:     new(0),
+			},
 		},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("2s"),
+		}),
 	}
 }
 
-func (e *virtualMachineStateAction) Prepare(_ context.Context, state *VirtualMachineStateChangeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+func (e *virtualMachineStateAction) Prepare(ctx context.Context, state *VirtualMachineStateChangeState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
 	vmName := request.Target.Attributes["gcp-vm.name"]
 	if len(vmName) == 0 {
 		return nil, extension_kit.ToError("Target is missing the 'gcp-vm.name' attribute.", nil)
@@ -132,7 +182,64 @@ func (e *virtualMachineStateAction) Prepare(_ context.Context, state *VirtualMac
 	state.VmName = vmName[0]
 	state.ProjectId = projectId[0]
 	state.Action = action.(string)
-	return nil, nil
+	state.ObservedStatuses = nil
+	if status := request.Target.Attributes[attrVmStatus]; len(status) > 0 && status[0] != "" {
+		state.ObservedStatuses = []string{status[0]}
+	}
+
+	state.Snapshots = nil
+	if !snapshotRequested(state.Action, request.Config) {
+		return nil, nil
+	}
+	names, err := e.snapshotDisks(ctx, snapshotRequest{
+		ProjectId:     state.ProjectId,
+		Zone:          state.Zone,
+		VmName:        state.VmName,
+		ExecutionId:   request.ExecutionId.String(),
+		RetentionDays: extutil.ToInt(request.Config["snapshotRetentionDays"]),
+		Now:           time.Now(),
+	})
+	state.Snapshots = names
+	if err != nil {
+		if len(names) > 0 {
+			err = fmt.Errorf("%w (already created: %s)", err, strings.Join(names, ", "))
+		}
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to snapshot disks of vm '%s'; the attack was not started", state.VmName), err)
+	}
+	return &action_kit_api.PrepareResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("Snapshotted %d disk(s) of vm '%s': %s", len(names), state.VmName, strings.Join(names, ", ")),
+		}}),
+	}, nil
+}
+
+// snapshotRequested is true when the experiment opted in, or when the extension enforces snapshots and the action
+// deletes the instance together with its auto-delete disks.
+func snapshotRequested(action string, cfg map[string]any) bool {
+	if extutil.ToBool(cfg["snapshotBeforeAttack"]) {
+		return true
+	}
+	return config.Config.SnapshotBeforeAttack && action == "delete"
+}
+
+func (e *virtualMachineStateAction) snapshotDisks(ctx context.Context, req snapshotRequest) ([]string, error) {
+	client, err := e.clientProvider(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: req.ProjectId, Zone: req.Zone, Instance: req.VmName})
+	if err != nil {
+		return nil, fmt.Errorf("get vm: %w", err)
+	}
+
+	snapshots, err := e.snapshotsClientProvider(ctx, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = snapshots.Close() }()
+	return snapshotAttachedDisks(ctx, snapshots, instance, req)
 }
 
 func (e *virtualMachineStateAction) Start(ctx context.Context, state *VirtualMachineStateChangeState) (*action_kit_api.StartResult, error) {
@@ -140,33 +247,35 @@ func (e *virtualMachineStateAction) Start(ctx context.Context, state *VirtualMac
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to initialize gcp client", err)
 	}
+	defer func() { _ = client.Close() }()
 
+	var op *compute.Operation
 	if state.Action == "reset" {
-		_, err = client.Reset(ctx, &computepb.ResetInstanceRequest{
+		op, err = client.Reset(ctx, &computepb.ResetInstanceRequest{
 			Zone:     state.Zone,
 			Project:  state.ProjectId,
 			Instance: state.VmName,
 		})
 	} else if state.Action == "stop" {
-		_, err = client.Stop(ctx, &computepb.StopInstanceRequest{
+		op, err = client.Stop(ctx, &computepb.StopInstanceRequest{
 			Zone:     state.Zone,
 			Project:  state.ProjectId,
 			Instance: state.VmName,
 		})
 	} else if state.Action == "delete" {
-		_, err = client.Delete(ctx, &computepb.DeleteInstanceRequest{
+		op, err = client.Delete(ctx, &computepb.DeleteInstanceRequest{
 			Zone:     state.Zone,
 			Project:  state.ProjectId,
 			Instance: state.VmName,
 		})
 	} else if state.Action == "suspend" {
-		_, err = client.Suspend(ctx, &computepb.SuspendInstanceRequest{
+		op, err = client.Suspend(ctx, &computepb.SuspendInstanceRequest{
 			Zone:     state.Zone,
 			Project:  state.ProjectId,
 			Instance: state.VmName,
 		})
 	} else if state.Action == "start" {
-		_, err = client.Start(ctx, &computepb.StartInstanceRequest{
+		op, err = client.Start(ctx, &computepb.StartInstanceRequest{
 			Zone:     state.Zone,
 			Project:  state.ProjectId,
 			Instance: state.VmName,
@@ -179,7 +288,96 @@ func (e *virtualMachineStateAction) Start(ctx context.Context, state *VirtualMac
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to execute state change attack '%s' on vm '%s'", state.Action, state.VmName), err)
 	}
 
-	return nil, nil
+	// A nil operation only happens with stubbed clients; Status then has nothing to wait for.
+	if op != nil {
+		state.OperationName = op.Name()
+	}
+
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("Requested '%s' of vm '%s' (operation '%s').", state.Action, state.VmName, state.OperationName),
+		}}),
+	}, nil
+}
+
+func (e *virtualMachineStateAction) Status(ctx context.Context, state *VirtualMachineStateChangeState) (*action_kit_api.StatusResult, error) {
+	var messages []action_kit_api.Message
+	if msg := e.observeInstanceStatus(ctx, state); msg != nil {
+		messages = append(messages, *msg)
+	}
+
+	if state.OperationName == "" {
+		return &action_kit_api.StatusResult{Completed: true, Messages: &messages}, nil
+	}
+
+	ops, err := e.operationsClientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to initialize gcp client", err)
+	}
+	defer func() { _ = ops.Close() }()
+
+	op, err := ops.Get(ctx, &computepb.GetZoneOperationRequest{
+		Project:   state.ProjectId,
+		Zone:      state.Zone,
+		Operation: state.OperationName,
+	})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get operation '%s' for vm '%s'", state.OperationName, state.VmName), err)
+	}
+
+	if op.GetStatus() != computepb.Operation_DONE {
+		return &action_kit_api.StatusResult{Completed: false, Messages: &messages}, nil
+	}
+
+	if detail := utils.OperationErrorDetail(op); detail != "" {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Messages:  &messages,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("State change attack '%s' on vm '%s' failed", state.Action, state.VmName),
+				Detail: new(detail),
+				Status: new(action_kit_api.Failed),
+			},
+		}, nil
+	}
+
+	messages = append(messages, action_kit_api.Message{
+		Level:   new(action_kit_api.Info),
+		Message: fmt.Sprintf("Operation '%s' for '%s' of vm '%s' is done.", state.OperationName, state.Action, state.VmName),
+	})
+	return &action_kit_api.StatusResult{Completed: true, Messages: &messages}, nil
+}
+
+// observeInstanceStatus reads the current instance status and returns a progress message when it differs from the
+// last observed one. Lookup failures are only logged: a deleted instance is expected to disappear mid-operation.
+func (e *virtualMachineStateAction) observeInstanceStatus(ctx context.Context, state *VirtualMachineStateChangeState) *action_kit_api.Message {
+	client, err := e.clientProvider(ctx, state.ProjectId)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Failed to initialize gcp client to observe vm '%s'", state.VmName)
+		return nil
+	}
+	defer func() { _ = client.Close() }()
+
+	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{
+		Project:  state.ProjectId,
+		Zone:     state.Zone,
+		Instance: state.VmName,
+	})
+	if err != nil {
+		log.Debug().Err(err).Msgf("Failed to read status of vm '%s'", state.VmName)
+		return nil
+	}
+
+	status := instance.GetStatus()
+	if status == "" || (len(state.ObservedStatuses) > 0 && state.ObservedStatuses[len(state.ObservedStatuses)-1] == status) {
+		return nil
+	}
+	state.ObservedStatuses = append(state.ObservedStatuses, status)
+	return &action_kit_api.Message{
+		Level:   new(action_kit_api.Info),
+		Message: fmt.Sprintf("vm '%s' status: %s", state.VmName, strings.Join(state.ObservedStatuses, " → ")),
+	}
 }
 
 func defaultClientProvider(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error) {
@@ -189,3 +387,11 @@ func defaultClientProvider(ctx context.Context, projectID string) (virtualMachin
 	}
 	return newInstancesClientForAccess(ctx, access)
 }
+
+func defaultOperationsClientProvider(ctx context.Context, projectID string) (zoneOperationsApi, error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return newZoneOperationsClientForAccess(ctx, access)
+}
@@ -160,6 +160,52 @@ func (m *gcpClientApiMock) Start(ctx context.Context, req *computepb.StartInstan
 	return nil, args.Error(1)
 }
 
+func (m *gcpClientApiMock) Resume(ctx context.Context, req *computepb.ResumeInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	args := m.Called(ctx, req)
+	return nil, args.Error(1)
+}
+
+func (m *gcpClientApiMock) Get(ctx context.Context, req *computepb.GetInstanceRequest, _ ...gax.CallOption) (*computepb.Instance, error) {
+	args := m.Called(ctx, req)
+	if instance := args.Get(0); instance != nil {
+		return instance.(*computepb.Instance), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *gcpClientApiMock) SetTags(ctx context.Context, req *computepb.SetTagsInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	args := m.Called(ctx, req)
+	return nil, args.Error(1)
+}
+
+func (m *gcpClientApiMock) Close() error {
+	return nil
+}
+
+type zoneOperationsApiMock struct {
+	mock.Mock
+}
+
+func (m *zoneOperationsApiMock) Get(ctx context.Context, req *computepb.GetZoneOperationRequest, _ ...gax.CallOption) (*computepb.Operation, error) {
+	args := m.Called(ctx, req)
+	if op := args.Get(0); op != nil {
+		return op.(*computepb.Operation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *zoneOperationsApiMock) Wait(ctx context.Context, req *computepb.WaitZoneOperationRequest, _ ...gax.CallOption) (*computepb.Operation, error) {
+	args := m.Called(ctx, req)
+	if op := args.Get(0); op != nil {
+		return op.(*computepb.Operation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *zoneOperationsApiMock) Close() error {
+	return nil
+}
+
 func TestGcpVirtualMachineStateAction_Suspend(t *testing.T) {
 	// Given
 	api := new(gcpClientApiMock)
@@ -184,7 +230,7 @@ func TestGcpVirtualMachineStateAction_Suspend(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Nil(t, result)
+	assert.NotNil(t, result)
 
 	api.AssertExpectations(t)
 }
@@ -213,7 +259,7 @@ func TestGcpVirtualMachineStateAction_Delete(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Nil(t, result)
+	assert.NotNil(t, result)
 
 	api.AssertExpectations(t)
 }
@@ -242,7 +288,7 @@ func TestGcpVirtualMachineStateAction_Stop(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Nil(t, result)
+	assert.NotNil(t, result)
 
 	api.AssertExpectations(t)
 }
@@ -271,7 +317,7 @@ func TestGcpVirtualMachineStateAction_Reset(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Nil(t, result)
+	assert.NotNil(t, result)
 
 	api.AssertExpectations(t)
 }
@@ -300,7 +346,7 @@ func TestGcpVirtualMachineStateAction_Start(t *testing.T) {
 
 	// Then
 	assert.NoError(t, err)
-	assert.Nil(t, result)
+	assert.NotNil(t, result)
 
 	api.AssertExpectations(t)
 }
@@ -332,3 +378,107 @@ func TestStartVirtualMachineStateChangeForwardsError(t *testing.T) {
 
 	api.AssertExpectations(t)
 }
+
+func newStatusTestAction(api *gcpClientApiMock, ops *zoneOperationsApiMock) virtualMachineStateAction {
+	return virtualMachineStateAction{
+		clientProvider: func(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error) {
+			return api, nil
+		},
+		operationsClientProvider: func(ctx context.Context, projectID string) (zoneOperationsApi, error) {
+			return ops, nil
+		},
+	}
+}
+
+func TestGcpVirtualMachineStateAction_StatusReportsTransitionsUntilDone(t *testing.T) {
+	// Given
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("STOPPING")}, nil).Once()
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("TERMINATED")}, nil).Once()
+	ops := new(zoneOperationsApiMock)
+	ops.On("Get", mock.Anything, mock.MatchedBy(func(req *computepb.GetZoneOperationRequest) bool {
+		require.Equal(t, "42", req.Project)
+		require.Equal(t, "us-central1-a", req.Zone)
+		require.Equal(t, "operation-1", req.Operation)
+		return true
+	})).Return(&computepb.Operation{Status: new(computepb.Operation_RUNNING)}, nil).Once()
+	ops.On("Get", mock.Anything, mock.Anything).Return(&computepb.Operation{Status: new(computepb.Operation_DONE)}, nil).Once()
+	action := newStatusTestAction(api, ops)
+	state := &VirtualMachineStateChangeState{
+		ProjectId:        "42",
+		VmName:           "my-vm",
+		Zone:             "us-central1-a",
+		Action:           "stop",
+		OperationName:    "operation-1",
+		ObservedStatuses: []string{"RUNNING"},
+	}
+
+	// When
+	first, err := action.Status(context.Background(), state)
+	require.NoError(t, err)
+	second, err := action.Status(context.Background(), state)
+	require.NoError(t, err)
+
+	// Then
+	assert.False(t, first.Completed)
+	require.Len(t, *first.Messages, 1)
+	assert.Equal(t, "vm 'my-vm' status: RUNNING → STOPPING", (*first.Messages)[0].Message)
+	assert.True(t, second.Completed)
+	assert.Nil(t, second.Error)
+	assert.Equal(t, "vm 'my-vm' status: RUNNING → STOPPING → TERMINATED", (*second.Messages)[0].Message)
+	assert.Equal(t, []string{"RUNNING", "STOPPING", "TERMINATED"}, state.ObservedStatuses)
+	api.AssertExpectations(t)
+	ops.AssertExpectations(t)
+}
+
+func TestGcpVirtualMachineStateAction_StatusReportsOperationError(t *testing.T) {
+	// Given
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+	ops := new(zoneOperationsApiMock)
+	ops.On("Get", mock.Anything, mock.Anything).Return(&computepb.Operation{
+		Status: new(computepb.Operation_DONE),
+		Error: &computepb.Error{Errors: []*computepb.Errors{
+			{Code: new("RESOURCE_IN_USE"), Message: new("instance is busy")},
+		}},
+	}, nil)
+	action := newStatusTestAction(api, ops)
+
+	// When
+	result, err := action.Status(context.Background(), &VirtualMachineStateChangeState{
+		ProjectId:     "42",
+		VmName:        "my-vm",
+		Zone:          "us-central1-a",
+		Action:        "stop",
+		OperationName: "operation-1",
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "RESOURCE_IN_USE: instance is busy", *result.Error.Detail)
+	assert.Equal(t, action_kit_api.Failed, *result.Error.Status)
+}
+
+func TestGcpVirtualMachineStateAction_StatusForwardsPollError(t *testing.T) {
+	// Given
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("RUNNING")}, nil)
+	ops := new(zoneOperationsApiMock)
+	ops.On("Get", mock.Anything, mock.Anything).Return(nil, errors.New("expected"))
+	action := newStatusTestAction(api, ops)
+
+	// When
+	result, err := action.Status(context.Background(), &VirtualMachineStateChangeState{
+		ProjectId:     "42",
+		VmName:        "my-vm",
+		Zone:          "us-central1-a",
+		Action:        "stop",
+		OperationName: "operation-1",
+	})
+
+	// Then
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+)
+
+// VirtualMachineStopState is the time-controlled counterpart of VirtualMachineStateChangeState. OriginalStatus is
+// read live in Prepare and decides what Stop does: a VM that was RUNNING is started (after stop) or resumed
+// (after suspend). OperationName is the stop/suspend operation, which Stop waits on before restoring because
+// Compute rejects a start while the instance is still STOPPING or SUSPENDING.
+type VirtualMachineStopState struct {
+	ProjectId      string
+	VmName         string
+	Zone           string
+	Action         string
+	OriginalStatus string
+	OperationName  string
+}
+
+type virtualMachineStopAction struct {
+	clientProvider           func(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error)
+	operationsClientProvider func(ctx context.Context, projectID string) (zoneOperationsApi, error)
+}
+
+var _ action_kit_sdk.Action[VirtualMachineStopState] = (*virtualMachineStopAction)(nil)
+var _ action_kit_sdk.ActionWithStop[VirtualMachineStopState] = (*virtualMachineStopAction)(nil)
+
+func NewVirtualMachineStopAction() action_kit_sdk.ActionWithStop[VirtualMachineStopState] {
+	return &virtualMachineStopAction{
+		clientProvider:           defaultClientProvider,
+		operationsClientProvider: defaultOperationsClientProvider,
+	}
+}
+
+func (e *virtualMachineStopAction) NewEmptyState() VirtualMachineStopState {
+	return VirtualMachineStopState{}
+}
+
+func (e *virtualMachineStopAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          VirtualMachineStopActionId,
+		Label:       "Stop Virtual Machine",
+		Description: "Stops or suspends Google Cloud virtual machines for the given duration. Started or resumed again on stop.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(targetIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: TargetIDVM,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "vm-name",
+					Description: new("Find gcp virtual machine by name"),
+					Query:       "gcp-vm.name=\"\"",
+				},
+				{
+					Label:       "cluster name",
+					Description: new("Find gcp virtual machine by cluster name"),
+					Query:       "gcp-kubernetes-engine.cluster.name=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("GCP"),
+		Category:    new("Virtual Machines"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("How long the virtual machine stays stopped or suspended. Started or resumed on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("60s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:         "action",
+				Label:        "Action",
+				Description:  new("Stop shuts the guest down; suspend preserves its memory and resumes where it left off."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new("stop"),
+				Order:        new(2),
+				Required:     new(true),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Stop",
+						Value: "stop",
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Suspend",
+						Value: "suspend",
+					},
+				}),
+			},
+		},
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (e *virtualMachineStopAction) Prepare(ctx context.Context, state *VirtualMachineStopState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	vmName := request.Target.Attributes["gcp-vm.name"]
+	zone := request.Target.Attributes[attrZone]
+	projectId := request.Target.Attributes[attrProjectID]
+	if len(vmName) == 0 || len(zone) == 0 || len(projectId) == 0 {
+		return nil, extension_kit.ToError("Target is missing one of: gcp-vm.name, gcp.zone, gcp.project.id", nil)
+	}
+
+	action, _ := request.Config["action"].(string)
+	if action == "" {
+		action = "stop"
+	}
+	if action != "stop" && action != "suspend" {
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported action '%s'; expected stop or suspend", action), nil)
+	}
+
+	state.VmName = vmName[0]
+	state.Zone = zone[0]
+	state.ProjectId = projectId[0]
+	state.Action = action
+
+	client, err := e.clientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to initialize gcp client", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName})
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get vm '%s'", state.VmName), err)
+	}
+	state.OriginalStatus = instance.GetStatus()
+	if state.OriginalStatus != computepb.Instance_RUNNING.String() {
+		return nil, extension_kit.ToError(fmt.Sprintf("vm '%s' is %s, not RUNNING — nothing to %s and nothing to restore", state.VmName, state.OriginalStatus, state.Action), nil)
+	}
+
+	return &action_kit_api.PrepareResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("Will %s vm '%s' (currently %s) and restore it on stop", state.Action, state.VmName, state.OriginalStatus),
+		}}),
+	}, nil
+}
+
+func (e *virtualMachineStopAction) Start(ctx context.Context, state *VirtualMachineStopState) (*action_kit_api.StartResult, error) {
+	client, err := e.clientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to initialize gcp client", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if state.Action == "suspend" {
+		op, err := client.Suspend(ctx, &computepb.SuspendInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName})
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to suspend vm '%s'", state.VmName), err)
+		}
+		if op != nil {
+			state.OperationName = op.Name()
+		}
+	} else {
+		op, err := client.Stop(ctx, &computepb.StopInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName})
+		if err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to stop vm '%s'", state.VmName), err)
+		}
+		if op != nil {
+			state.OperationName = op.Name()
+		}
+	}
+
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: fmt.Sprintf("Requested %s of vm '%s'; it is restored to %s on stop", state.Action, state.VmName, state.OriginalStatus),
+		}}),
+	}, nil
+}
+
+func (e *virtualMachineStopAction) Stop(ctx context.Context, state *VirtualMachineStopState) (*action_kit_api.StopResult, error) {
+	message, err := e.restore(ctx, state)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to restore vm '%s'", state.VmName)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restore vm '%s' to %s", state.VmName, state.OriginalStatus), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: new([]action_kit_api.Message{{
+			Level:   new(action_kit_api.Info),
+			Message: message,
+		}}),
+	}, nil
+}
+
+// restore waits for the stop/suspend operation to settle, then starts or resumes the instance depending on where it
+// ended up. Idempotent: an instance that is already RUNNING (Stop retried, or Start never got through) is left alone.
+func (e *virtualMachineStopAction) restore(ctx context.Context, state *VirtualMachineStopState) (string, error) {
+	if state.OperationName != "" {
+		ops, err := e.operationsClientProvider(ctx, state.ProjectId)
+		if err != nil {
+			return "", err
+		}
+		err = utils.WaitForZoneOperation(ctx, ops, state.ProjectId, state.Zone, state.OperationName)
+		_ = ops.Close()
+		// A failed stop/suspend leaves the instance RUNNING, which the status check below treats as nothing to restore.
+		var failed *utils.OperationFailedError
+		if errors.As(err, &failed) {
+			log.Warn().Msgf("Operation '%s' on vm '%s' failed: %s", state.OperationName, state.VmName, failed.Detail)
+		} else if err != nil {
+			return "", fmt.Errorf("wait for operation '%s': %w", state.OperationName, err)
+		}
+	}
+
+	client, err := e.clientProvider(ctx, state.ProjectId)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = client.Close() }()
+
+	instance, err := client.Get(ctx, &computepb.GetInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName})
+	if err != nil {
+		return "", fmt.Errorf("get vm: %w", err)
+	}
+
+	switch status := instance.GetStatus(); status {
+	case computepb.Instance_RUNNING.String():
+		return fmt.Sprintf("vm '%s' is already RUNNING — nothing to restore", state.VmName), nil
+	case computepb.Instance_TERMINATED.String():
+		if _, err := client.Start(ctx, &computepb.StartInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName}); err != nil {
+			return "", fmt.Errorf("start vm: %w", err)
+		}
+		return fmt.Sprintf("Requested start of vm '%s'", state.VmName), nil
+	case computepb.Instance_SUSPENDED.String():
+		if _, err := client.Resume(ctx, &computepb.ResumeInstanceRequest{Project: state.ProjectId, Zone: state.Zone, Instance: state.VmName}); err != nil {
+			return "", fmt.Errorf("resume vm: %w", err)
+		}
+		return fmt.Sprintf("Requested resume of vm '%s'", state.VmName), nil
+	default:
+		return "", fmt.Errorf("vm is %s; expected TERMINATED or SUSPENDED", status)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extvm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var validStopAttrs = map[string][]string{
+	"gcp-vm.name":    {"my-vm"},
+	"gcp.zone":       {"us-central1-a"},
+	"gcp.project.id": {"42"},
+}
+
+func stopPrepareReq(attrs map[string][]string, config map[string]any) action_kit_api.PrepareActionRequestBody {
+	return extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Config: config,
+		Target: new(action_kit_api.Target{Attributes: attrs}),
+	})
+}
+
+func newStopTestAction(api *gcpClientApiMock, ops *zoneOperationsApiMock) *virtualMachineStopAction {
+	return &virtualMachineStopAction{
+		clientProvider: func(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error) {
+			return api, nil
+		},
+		operationsClientProvider: func(ctx context.Context, projectID string) (zoneOperationsApi, error) {
+			return ops, nil
+		},
+	}
+}
+
+func TestVirtualMachineStop_Describe(t *testing.T) {
+	a := &virtualMachineStopAction{}
+	desc := a.Describe()
+	assert.Equal(t, VirtualMachineStopActionId, desc.Id)
+	assert.Equal(t, TargetIDVM, desc.TargetSelection.TargetType)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, VirtualMachineStopState{}, a.NewEmptyState())
+}
+
+func TestVirtualMachineStop_NewAction(t *testing.T) {
+	assert.NotNil(t, NewVirtualMachineStopAction())
+}
+
+func TestVirtualMachineStop_Prepare_MissingRequiredAttr(t *testing.T) {
+	for _, drop := range []string{"gcp-vm.name", "gcp.zone", "gcp.project.id"} {
+		attrs := map[string][]string{}
+		for k, v := range validStopAttrs {
+			if k != drop {
+				attrs[k] = v
+			}
+		}
+		a := &virtualMachineStopAction{}
+		state := VirtualMachineStopState{}
+		_, err := a.Prepare(context.Background(), &state, stopPrepareReq(attrs, map[string]any{"action": "stop"}))
+		require.Error(t, err, "dropping %s should fail Prepare", drop)
+		assert.Contains(t, err.Error(), "missing")
+	}
+}
+
+func TestVirtualMachineStop_Prepare_RejectsUnsupportedAction(t *testing.T) {
+	a := &virtualMachineStopAction{}
+	state := VirtualMachineStopState{}
+	_, err := a.Prepare(context.Background(), &state, stopPrepareReq(validStopAttrs, map[string]any{"action": "delete"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported action")
+}
+
+func TestVirtualMachineStop_Prepare_RecordsOriginalStatus(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.MatchedBy(func(req *computepb.GetInstanceRequest) bool {
+		return req.Project == "42" && req.Zone == "us-central1-a" && req.Instance == "my-vm"
+	})).Return(&computepb.Instance{Status: new("RUNNING")}, nil)
+	a := newStopTestAction(api, nil)
+	state := VirtualMachineStopState{}
+
+	res, err := a.Prepare(context.Background(), &state, stopPrepareReq(validStopAttrs, map[string]any{"action": "suspend", "duration": 60000}))
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, "RUNNING", state.OriginalStatus)
+	assert.Equal(t, "suspend", state.Action)
+	assert.Equal(t, "my-vm", state.VmName)
+}
+
+func TestVirtualMachineStop_Prepare_RefusesVmThatIsNotRunning(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("TERMINATED")}, nil)
+	a := newStopTestAction(api, nil)
+	state := VirtualMachineStopState{}
+
+	_, err := a.Prepare(context.Background(), &state, stopPrepareReq(validStopAttrs, map[string]any{"action": "stop"}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not RUNNING")
+}
+
+func TestVirtualMachineStop_StartSuspends(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Suspend", mock.Anything, mock.MatchedBy(func(req *computepb.SuspendInstanceRequest) bool {
+		return req.Project == "42" && req.Zone == "us-central1-a" && req.Instance == "my-vm"
+	})).Return(nil, nil)
+	a := newStopTestAction(api, nil)
+
+	res, err := a.Start(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "suspend", OriginalStatus: "RUNNING"})
+
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+	api.AssertExpectations(t)
+}
+
+func TestVirtualMachineStop_StartForwardsError(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Stop", mock.Anything, mock.Anything).Return(nil, errors.New("expected"))
+	a := newStopTestAction(api, nil)
+
+	res, err := a.Start(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "stop"})
+
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestVirtualMachineStop_StopWaitsForOperationThenStarts(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("TERMINATED")}, nil)
+	api.On("Start", mock.Anything, mock.MatchedBy(func(req *computepb.StartInstanceRequest) bool {
+		return req.Project == "42" && req.Zone == "us-central1-a" && req.Instance == "my-vm"
+	})).Return(nil, nil)
+	ops := new(zoneOperationsApiMock)
+	ops.On("Wait", mock.Anything, mock.MatchedBy(func(req *computepb.WaitZoneOperationRequest) bool {
+		return req.Operation == "operation-1"
+	})).Return(&computepb.Operation{Status: new(computepb.Operation_RUNNING)}, nil).Once()
+	ops.On("Wait", mock.Anything, mock.Anything).Return(&computepb.Operation{Status: new(computepb.Operation_DONE)}, nil).Once()
+	a := newStopTestAction(api, ops)
+
+	res, err := a.Stop(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "stop", OriginalStatus: "RUNNING", OperationName: "operation-1"})
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Contains(t, (*res.Messages)[0].Message, "start")
+	api.AssertExpectations(t)
+	ops.AssertExpectations(t)
+}
+
+func TestVirtualMachineStop_StopTreatsFailedOperationAsNothingToRestore(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("RUNNING")}, nil)
+	ops := new(zoneOperationsApiMock)
+	ops.On("Wait", mock.Anything, mock.Anything).Return(&computepb.Operation{
+		Status: new(computepb.Operation_DONE),
+		Error:  &computepb.Error{Errors: []*computepb.Errors{{Code: new("RESOURCE_NOT_READY"), Message: new("busy")}}},
+	}, nil).Once()
+	a := newStopTestAction(api, ops)
+
+	res, err := a.Stop(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "stop", OriginalStatus: "RUNNING", OperationName: "operation-1"})
+
+	require.NoError(t, err)
+	assert.Contains(t, (*res.Messages)[0].Message, "nothing to restore")
+	api.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+	ops.AssertExpectations(t)
+}
+
+func TestVirtualMachineStop_StopResumesSuspendedVm(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("SUSPENDED")}, nil)
+	api.On("Resume", mock.Anything, mock.Anything).Return(nil, nil)
+	a := newStopTestAction(api, nil)
+
+	_, err := a.Stop(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "suspend", OriginalStatus: "RUNNING"})
+
+	require.NoError(t, err)
+	api.AssertExpectations(t)
+}
+
+func TestVirtualMachineStop_StopIsNoOpWhenAlreadyRunning(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("RUNNING")}, nil)
+	a := newStopTestAction(api, nil)
+
+	res, err := a.Stop(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "stop", OriginalStatus: "RUNNING"})
+
+	require.NoError(t, err)
+	assert.Contains(t, (*res.Messages)[0].Message, "already RUNNING")
+	api.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+}
+
+func TestVirtualMachineStop_StopFailsOnUnexpectedStatus(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(&computepb.Instance{Status: new("STOPPING")}, nil)
+	a := newStopTestAction(api, nil)
+
+	_, err := a.Stop(context.Background(), &VirtualMachineStopState{ProjectId: "42", Zone: "us-central1-a", VmName: "my-vm", Action: "stop", OriginalStatus: "RUNNING"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to restore")
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extvm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-gcp/utils"
+)
+
+const (
+	snapshotNamePrefix = "steadybit-"
+	// Labels put on every safety-net snapshot. The extension never deletes snapshots; the delete-after date is only a
+	// marker for the operator's own cleanup, which can list snapshots by the execution label.
+	snapshotExecutionLabel   = "steadybit-execution-id"
+	snapshotDeleteAfterLabel = "steadybit-delete-after"
+)
+
+// snapshotPollInterval is how often the snapshot status is re-read while waiting for READY.
+var snapshotPollInterval = 5 * time.Second
+
+var invalidSnapshotNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+type snapshotsApi interface {
+	Insert(ctx context.Context, req *computepb.InsertSnapshotRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Get(ctx context.Context, req *computepb.GetSnapshotRequest, opts ...gax.CallOption) (*computepb.Snapshot, error)
+	Close() error
+}
+
+func defaultSnapshotsClientProvider(ctx context.Context, projectID string) (snapshotsApi, error) {
+	access, err := utils.GetGcpAccess(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return compute.NewSnapshotsRESTClient(ctx, access.ClientOptions...)
+}
+
+// snapshotRequest describes one safety-net run: which instance's disks to snapshot and how to label the result.
+type snapshotRequest struct {
+	ProjectId     string
+	Zone          string
+	VmName        string
+	ExecutionId   string
+	RetentionDays int
+	Now           time.Time
+}
+
+// snapshotAttachedDisks snapshots every persistent disk attached to the instance and blocks until all snapshots are
+// READY. Local SSDs have no source disk and are skipped. Snapshot names are derived from the execution and disk, so a
+// retried Prepare finds the snapshots it already created instead of failing on a name conflict. The names created so
+// far are returned even when an error occurs, so they can be reported.
+func snapshotAttachedDisks(ctx context.Context, client snapshotsApi, instance *computepb.Instance, req snapshotRequest) ([]string, error) {
+	labels := map[string]string{snapshotExecutionLabel: req.ExecutionId}
+	if req.RetentionDays > 0 {
+		labels[snapshotDeleteAfterLabel] = req.Now.AddDate(0, 0, req.RetentionDays).UTC().Format("2006-01-02")
+	}
+
+	var names []string
+	for _, disk := range instance.GetDisks() {
+		source := disk.GetSource()
+		if source == "" {
+			continue
+		}
+		name := snapshotName(req.ExecutionId, source)
+		op, err := client.Insert(ctx, &computepb.InsertSnapshotRequest{
+			Project: req.ProjectId,
+			SnapshotResource: &computepb.Snapshot{
+				Name:        &name,
+				SourceDisk:  &source,
+				Labels:      labels,
+				Description: new(fmt.Sprintf("Taken by Steadybit before attacking vm '%s'", req.VmName)),
+			},
+		})
+		if isHTTPStatus(err, http.StatusConflict) {
+			log.Info().Msgf("Snapshot '%s' already exists — reusing it", name)
+		} else if err != nil {
+			return names, fmt.Errorf("create snapshot '%s' of disk '%s': %w", name, lastSegment(source), err)
+		} else if err := utils.WaitForComputeOperation(ctx, op); err != nil {
+			return names, fmt.Errorf("create snapshot '%s' of disk '%s': %w", name, lastSegment(source), err)
+		}
+		names = append(names, name)
+	}
+
+	for _, name := range names {
+		if err := waitForSnapshotReady(ctx, client, req.ProjectId, name); err != nil {
+			return names, err
+		}
+	}
+	return names, nil
+}
+
+func waitForSnapshotReady(ctx context.Context, client snapshotsApi, projectId, name string) error {
+	for {
+		snapshot, err := client.Get(ctx, &computepb.GetSnapshotRequest{Project: projectId, Snapshot: name})
+		if err != nil {
+			return fmt.Errorf("get snapshot '%s': %w", name, err)
+		}
+		switch snapshot.GetStatus() {
+		case computepb.Snapshot_READY.String():
+			return nil
+		case computepb.Snapshot_FAILED.String(), computepb.Snapshot_DELETING.String():
+			return fmt.Errorf("snapshot '%s' is %s", name, snapshot.GetStatus())
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("snapshot '%s' not ready (last status %s): %w", name, snapshot.GetStatus(), ctx.Err())
+		case <-time.After(snapshotPollInterval):
+		}
+	}
+}
+
+// snapshotName builds a valid resource name (lowercase, max 63 characters) from the disk name and a hash of the
+// execution and disk URL.
+func snapshotName(executionId, diskSource string) string {
+	sum := sha256.Sum256([]byte(executionId + "/" + diskSource))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	disk := strings.Trim(invalidSnapshotNameChars.ReplaceAllString(strings.ToLower(lastSegment(diskSource)), "-"), "-")
+	if maxLen := 63 - len(snapshotNamePrefix) - len(suffix) - 1; len(disk) > maxLen {
+		disk = strings.TrimRight(disk[:maxLen], "-")
+	}
+	if disk == "" {
+		return snapshotNamePrefix + suffix
+	}
+	return snapshotNamePrefix + disk + "-" + suffix
+}
+
+func lastSegment(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extvm
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	bootDiskURL = "https://www.googleapis.com/compute/v1/projects/42/zones/us-central1-a/disks/my-vm"
+	dataDiskURL = "https://www.googleapis.com/compute/v1/projects/42/zones/us-central1-a/disks/my-data"
+)
+
+type snapshotsApiMock struct {
+	mock.Mock
+}
+
+func (m *snapshotsApiMock) Insert(ctx context.Context, req *computepb.InsertSnapshotRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	args := m.Called(ctx, req)
+	return nil, args.Error(1)
+}
+
+func (m *snapshotsApiMock) Get(ctx context.Context, req *computepb.GetSnapshotRequest, _ ...gax.CallOption) (*computepb.Snapshot, error) {
+	args := m.Called(ctx, req)
+	if snapshot := args.Get(0); snapshot != nil {
+		return snapshot.(*computepb.Snapshot), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *snapshotsApiMock) Close() error {
+	return nil
+}
+
+func instanceWithDisks() *computepb.Instance {
+	return &computepb.Instance{
+		Name: new("my-vm"),
+		Disks: []*computepb.AttachedDisk{
+			{Source: new(bootDiskURL), Boot: new(true)},
+			{Type: new("SCRATCH")},
+			{Source: new(dataDiskURL)},
+		},
+	}
+}
+
+func withFastSnapshotPolling(t *testing.T) {
+	previous := snapshotPollInterval
+	snapshotPollInterval = time.Millisecond
+	t.Cleanup(func() { snapshotPollInterval = previous })
+}
+
+func TestSnapshotName(t *testing.T) {
+	valid := regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+	name := snapshotName("exec-1", dataDiskURL)
+	assert.Regexp(t, valid, name)
+	assert.True(t, strings.HasPrefix(name, "steadybit-my-data-"))
+	assert.Equal(t, name, snapshotName("exec-1", dataDiskURL))
+	assert.NotEqual(t, name, snapshotName("exec-2", dataDiskURL))
+
+	long := snapshotName("exec-1", "projects/42/zones/z/disks/"+strings.Repeat("a", 70)+"-disk")
+	assert.Regexp(t, valid, long)
+	assert.LessOrEqual(t, len(long), 63)
+}
+
+func TestSnapshotAttachedDisks_CreatesLabelledSnapshotsAndWaitsForReady(t *testing.T) {
+	withFastSnapshotPolling(t)
+	client := new(snapshotsApiMock)
+	var inserted []*computepb.InsertSnapshotRequest
+	client.On("Insert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		inserted = append(inserted, args.Get(1).(*computepb.InsertSnapshotRequest))
+	}).Return(nil, nil)
+	client.On("Get", mock.Anything, mock.Anything).Return(&computepb.Snapshot{Status: new("CREATING")}, nil).Once()
+	client.On("Get", mock.Anything, mock.Anything).Return(&computepb.Snapshot{Status: new("READY")}, nil)
+
+	names, err := snapshotAttachedDisks(context.Background(), client, instanceWithDisks(), snapshotRequest{
+		ProjectId:     "42",
+		VmName:        "my-vm",
+		ExecutionId:   "exec-1",
+		RetentionDays: 7,
+		Now:           time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{snapshotName("exec-1", bootDiskURL), snapshotName("exec-1", dataDiskURL)}, names)
+	require.Len(t, inserted, 2)
+	assert.Equal(t, "42", inserted[1].Project)
+	assert.Equal(t, dataDiskURL, inserted[1].SnapshotResource.GetSourceDisk())
+	assert.Equal(t, map[string]string{
+		"steadybit-execution-id": "exec-1",
+		"steadybit-delete-after": "2026-10-24",
+	}, inserted[1].SnapshotResource.GetLabels())
+	client.AssertNumberOfCalls(t, "Get", 3)
+}
+
+func TestSnapshotAttachedDisks_ReusesExistingSnapshot(t *testing.T) {
+	client := new(snapshotsApiMock)
+	client.On("Insert", mock.Anything, mock.Anything).Return(nil, &googleapi.Error{Code: http.StatusConflict})
+	client.On("Get", mock.Anything, mock.Anything).Return(&computepb.Snapshot{Status: new("READY")}, nil)
+
+	names, err := snapshotAttachedDisks(context.Background(), client, instanceWithDisks(), snapshotRequest{ProjectId: "42", ExecutionId: "exec-1"})
+
+	require.NoError(t, err)
+	assert.Len(t, names, 2)
+}
+
+func TestSnapshotAttachedDisks_ReportsFailedSnapshot(t *testing.T) {
+	client := new(snapshotsApiMock)
+	client.On("Insert", mock.Anything, mock.Anything).Return(nil, nil)
+	client.On("Get", mock.Anything, mock.Anything).Return(&computepb.Snapshot{Status: new("FAILED")}, nil)
+
+	names, err := snapshotAttachedDisks(context.Background(), client, instanceWithDisks(), snapshotRequest{ProjectId: "42", ExecutionId: "exec-1"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is FAILED")
+	assert.Len(t, names, 2)
+}
+
+func TestSnapshotRequested(t *testing.T) {
+	previous := config.Config.SnapshotBeforeAttack
+	t.Cleanup(func() { config.Config.SnapshotBeforeAttack = previous })
+
+	config.Config.SnapshotBeforeAttack = false
+	assert.False(t, snapshotRequested("delete", map[string]any{}))
+	assert.True(t, snapshotRequested("stop", map[string]any{"snapshotBeforeAttack": true}))
+
+	config.Config.SnapshotBeforeAttack = true
+	assert.True(t, snapshotRequested("delete", map[string]any{}))
+	assert.False(t, snapshotRequested("reset", map[string]any{}))
+}
+
+func TestGcpVirtualMachineStateAction_PrepareSnapshotsDisks(t *testing.T) {
+	api := new(gcpClientApiMock)
+	api.On("Get", mock.Anything, mock.Anything).Return(instanceWithDisks(), nil)
+	snapshots := new(snapshotsApiMock)
+	snapshots.On("Insert", mock.Anything, mock.Anything).Return(nil, nil)
+	snapshots.On("Get", mock.Anything, mock.Anything).Return(&computepb.Snapshot{Status: new("READY")}, nil)
+	action := virtualMachineStateAction{
+		clientProvider: func(ctx context.Context, projectID string) (virtualMachineStateChangeApi, error) {
+			return api, nil
+		},
+		snapshotsClientProvider: func(ctx context.Context, projectID string) (snapshotsApi, error) {
+			return snapshots, nil
+		},
+	}
+	// The request carries no execution id, so the zero UUID is used for naming.
+	executionId := "00000000-0000-0000-0000-000000000000"
+	state := action.NewEmptyState()
+
+	result, err := action.Prepare(context.Background(), &state, extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"action": "delete", "snapshotBeforeAttack": true},
+		Target: new(action_kit_api.Target{Attributes: map[string][]string{
+			"gcp-vm.name":    {"my-vm"},
+			"gcp.project.id": {"42"},
+			"gcp.zone":       {"us-central1-a"},
+		}}),
+	}))
+
+	require.NoError(t, err)
+	expected := []string{snapshotName(executionId, bootDiskURL), snapshotName(executionId, dataDiskURL)}
+	assert.Equal(t, expected, state.Snapshots)
+	require.NotNil(t, result)
+	assert.Contains(t, (*result.Messages)[0].Message, strings.Join(expected, ", "))
+}
@@ -0,0 +1,584 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extzone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-gcp/utils"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"google.golang.org/api/iterator"
+)
+
+// ZoneOutageState is the blast radius computed in Prepare plus what Start actually did. Only the standalone VMs are
+// restored on Stop — via StopOperations, which maps each VM whose stop was accepted to its zonal operation so Stop
+// can wait for TERMINATED before starting it again. Recreated MIG instances heal through their MIG and need no
+// restore.
+type ZoneOutageState struct {
+	ProjectID      string
+	Zone           string
+	Region         string
+	DryRun         bool
+	Vms            []string
+	Migs           []ZoneOutageMig
+	StopOperations map[string]string
+}
+
+// ZoneOutageMig lists the RUNNING instances of one MIG that live in the attacked zone. Scope is "zonal" or
+// "regional", Location the MIG's zone or region.
+type ZoneOutageMig struct {
+	Scope     string
+	Location  string
+	Name      string
+	Instances []string
+}
+
+type zoneInstancesApi interface {
+	List(ctx context.Context, req *computepb.ListInstancesRequest, opts ...gax.CallOption) *compute.InstanceIterator
+	Stop(ctx context.Context, req *computepb.StopInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+	Start(ctx context.Context, req *computepb.StartInstanceRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type zoneOperationsApi interface {
+	Wait(ctx context.Context, req *computepb.WaitZoneOperationRequest, opts ...gax.CallOption) (*computepb.Operation, error)
+}
+
+type zonalMigApi interface {
+	List(ctx context.Context, req *computepb.ListInstanceGroupManagersRequest, opts ...gax.CallOption) *compute.InstanceGroupManagerIterator
+	ListManagedInstances(ctx context.Context, req *computepb.ListManagedInstancesInstanceGroupManagersRequest, opts ...gax.CallOption) *compute.ManagedInstanceIterator
+	RecreateInstances(ctx context.Context, req *computepb.RecreateInstancesInstanceGroupManagerRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type regionalMigApi interface {
+	List(ctx context.Context, req *computepb.ListRegionInstanceGroupManagersRequest, opts ...gax.CallOption) *compute.InstanceGroupManagerIterator
+	ListManagedInstances(ctx context.Context, req *computepb.ListManagedInstancesRegionInstanceGroupManagersRequest, opts ...gax.CallOption) *compute.ManagedInstanceIterator
+	RecreateInstances(ctx context.Context, req *computepb.RecreateInstancesRegionInstanceGroupManagerRequest, opts ...gax.CallOption) (*compute.Operation, error)
+}
+
+type zoneOutageAttack struct {
+	instancesClientProvider   func(ctx context.Context, projectID string) (zoneInstancesApi, func(), error)
+	operationsClientProvider  func(ctx context.Context, projectID string) (zoneOperationsApi, func(), error)
+	zonalMigClientProvider    func(ctx context.Context, projectID string) (zonalMigApi, func(), error)
+	regionalMigClientProvider func(ctx context.Context, projectID string) (regionalMigApi, func(), error)
+}
+
+var _ action_kit_sdk.Action[ZoneOutageState] = (*zoneOutageAttack)(nil)
+var _ action_kit_sdk.ActionWithStop[ZoneOutageState] = (*zoneOutageAttack)(nil)
+
+func NewZoneOutageAction() action_kit_sdk.ActionWithStop[ZoneOutageState] {
+	return &zoneOutageAttack{
+		instancesClientProvider: func(ctx context.Context, projectID string) (zoneInstancesApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewInstancesRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+		operationsClientProvider: func(ctx context.Context, projectID string) (zoneOperationsApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewZoneOperationsRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+		zonalMigClientProvider: func(ctx context.Context, projectID string) (zonalMigApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewInstanceGroupManagersRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+		regionalMigClientProvider: func(ctx context.Context, projectID string) (regionalMigApi, func(), error) {
+			access, err := utils.GetGcpAccess(projectID)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, err := compute.NewRegionInstanceGroupManagersRESTClient(ctx, access.ClientOptions...)
+			if err != nil {
+				return nil, nil, err
+			}
+			return c, func() { _ = c.Close() }, nil
+		},
+	}
+}
+
+func (a *zoneOutageAttack) NewEmptyState() ZoneOutageState {
+	return ZoneOutageState{}
+}
+
+func (a *zoneOutageAttack) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ZoneOutageActionId,
+		Label:       "Simulate zone outage",
+		Description: "Stops every RUNNING standalone VM in a zone (optionally filtered by label) and recreates all MIG instances located in it. Stopped VMs are started again on stop; MIG instances heal through their MIG.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: extutil.Ptr(action_kit_api.TargetSelection{
+			TargetType: TargetIDZone,
+			SelectionTemplates: extutil.Ptr([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label:       "by zone",
+					Description: extutil.Ptr("Find zone by name"),
+					Query:       "gcp.zone=\"\"",
+				},
+			}),
+		}),
+		Technology:  extutil.Ptr("GCP"),
+		Category:    extutil.Ptr("Compute Engine"),
+		TimeControl: action_kit_api.TimeControlExternal,
+		Kind:        action_kit_api.Attack,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long the VMs stay stopped. Started again on stop."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("120s"),
+				Order:        extutil.Ptr(1),
+				Required:     extutil.Ptr(true),
+			},
+			{
+				Name:        "vmLabels",
+				Label:       "VM labels",
+				Description: extutil.Ptr("Only stop standalone VMs carrying all of these labels. Empty stops every RUNNING standalone VM in the zone. Does not apply to MIG instances."),
+				Type:        action_kit_api.ActionParameterTypeKeyValue,
+				Order:       extutil.Ptr(2),
+				Required:    extutil.Ptr(false),
+			},
+			{
+				Name:         "includeMigs",
+				Label:        "Recreate MIG instances",
+				Description:  extutil.Ptr("Also recreate the RUNNING instances of zonal and regional MIGs that are located in the zone."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("true"),
+				Order:        extutil.Ptr(3),
+				Required:     extutil.Ptr(false),
+			},
+			{
+				Name:         "dryRun",
+				Label:        "Dry run",
+				Description:  extutil.Ptr("Only report the blast radius computed in Prepare; Start changes nothing."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("false"),
+				Order:        extutil.Ptr(4),
+				Required:     extutil.Ptr(false),
+			},
+		},
+		Stop: extutil.Ptr(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *zoneOutageAttack) Prepare(ctx context.Context, state *ZoneOutageState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ProjectID = mustHave(request.Target.Attributes, attrProjectID)
+	state.Zone = mustHave(request.Target.Attributes, attrZone)
+	if state.ProjectID == "" || state.Zone == "" {
+		return nil, extension_kit.ToError("Target is missing one of: gcp.project.id, gcp.zone", nil)
+	}
+	state.Region = mustHave(request.Target.Attributes, attrRegion)
+	if state.Region == "" {
+		state.Region = regionOfZone(state.Zone)
+	}
+	state.DryRun = extutil.ToBool(request.Config["dryRun"])
+
+	labels := map[string]string{}
+	if request.Config["vmLabels"] != nil {
+		var err error
+		if labels, err = extutil.ToKeyValue(request.Config, "vmLabels"); err != nil {
+			return nil, extension_kit.ToError("Failed to parse the 'vmLabels' parameter", err)
+		}
+	}
+
+	instances, err := a.listInstances(ctx, state)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to list VMs in zone %s", state.Zone), err)
+	}
+	var managed int
+	state.Vms, managed = selectStandaloneVms(instances, labels)
+
+	state.Migs = nil
+	if request.Config["includeMigs"] == nil || extutil.ToBool(request.Config["includeMigs"]) {
+		if state.Migs, err = a.listMigInstancesInZone(ctx, state); err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to list MIG instances in zone %s", state.Zone), err)
+		}
+	}
+
+	if len(state.Vms) == 0 && len(state.Migs) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Nothing to take down in zone %s: no matching RUNNING VMs or MIG instances", state.Zone), nil)
+	}
+
+	messages := []action_kit_api.Message{{
+		Level:   extutil.Ptr(action_kit_api.Info),
+		Message: blastRadius(state),
+	}}
+	if managed > 0 {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Debug),
+			Message: fmt.Sprintf("Skipped %d MIG-managed VM(s) when selecting standalone VMs; they are handled via their MIG", managed),
+		})
+	}
+	return &action_kit_api.PrepareResult{Messages: &messages}, nil
+}
+
+func (a *zoneOutageAttack) listInstances(ctx context.Context, state *ZoneOutageState) ([]*computepb.Instance, error) {
+	client, closer, err := a.instancesClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+	result := make([]*computepb.Instance, 0)
+	it := client.List(ctx, &computepb.ListInstancesRequest{Project: state.ProjectID, Zone: state.Zone})
+	for {
+		inst, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, inst)
+	}
+	return result, nil
+}
+
+// listMigInstancesInZone covers both zonal MIGs in the zone and regional MIGs of the zone's region; for the latter
+// only the instances placed in the zone are taken.
+func (a *zoneOutageAttack) listMigInstancesInZone(ctx context.Context, state *ZoneOutageState) ([]ZoneOutageMig, error) {
+	result := make([]ZoneOutageMig, 0)
+
+	zonal, closeZonal, err := a.zonalMigClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer closeZonal()
+	zit := zonal.List(ctx, &computepb.ListInstanceGroupManagersRequest{Project: state.ProjectID, Zone: state.Zone})
+	for {
+		mig, err := zit.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		managed, err := collectManagedInstances(zonal.ListManagedInstances(ctx, &computepb.ListManagedInstancesInstanceGroupManagersRequest{
+			Project:              state.ProjectID,
+			Zone:                 state.Zone,
+			InstanceGroupManager: mig.GetName(),
+		}))
+		if err != nil {
+			return nil, err
+		}
+		if instances := runningInstancesInZone(managed, state.Zone); len(instances) > 0 {
+			result = append(result, ZoneOutageMig{Scope: "zonal", Location: state.Zone, Name: mig.GetName(), Instances: instances})
+		}
+	}
+
+	regional, closeRegional, err := a.regionalMigClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRegional()
+	rit := regional.List(ctx, &computepb.ListRegionInstanceGroupManagersRequest{Project: state.ProjectID, Region: state.Region})
+	for {
+		mig, err := rit.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		managed, err := collectManagedInstances(regional.ListManagedInstances(ctx, &computepb.ListManagedInstancesRegionInstanceGroupManagersRequest{
+			Project:              state.ProjectID,
+			Region:               state.Region,
+			InstanceGroupManager: mig.GetName(),
+		}))
+		if err != nil {
+			return nil, err
+		}
+		if instances := runningInstancesInZone(managed, state.Zone); len(instances) > 0 {
+			result = append(result, ZoneOutageMig{Scope: "regional", Location: state.Region, Name: mig.GetName(), Instances: instances})
+		}
+	}
+	return result, nil
+}
+
+func collectManagedInstances(it *compute.ManagedInstanceIterator) ([]*computepb.ManagedInstance, error) {
+	result := make([]*computepb.ManagedInstance, 0)
+	for {
+		mi, err := it.Next()
+		if err == iterator.Done {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, mi)
+	}
+}
+
+func (a *zoneOutageAttack) Start(ctx context.Context, state *ZoneOutageState) (*action_kit_api.StartResult, error) {
+	if state.DryRun {
+		return &action_kit_api.StartResult{
+			Messages: extutil.Ptr([]action_kit_api.Message{{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: "Dry run — nothing was changed. " + blastRadius(state),
+			}}),
+		}, nil
+	}
+
+	// Every VM and MIG is attempted even if one fails, so the outage is as complete as possible; the accepted stops
+	// are recorded in StopOperations before returning, which keeps Stop able to restore them.
+	var errs []error
+	stopped, err := a.stopVms(ctx, state)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	recreated, err := a.recreateMigInstances(ctx, state)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Zone outage in %s was only partially applied (%d VM(s) stopped, %d MIG instance(s) recreated)", state.Zone, stopped, recreated), errors.Join(errs...))
+	}
+	return &action_kit_api.StartResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Zone outage in %s: stop requested for %d VM(s), recreation requested for %d MIG instance(s)", state.Zone, stopped, recreated),
+		}}),
+	}, nil
+}
+
+func (a *zoneOutageAttack) stopVms(ctx context.Context, state *ZoneOutageState) (int, error) {
+	if len(state.Vms) == 0 {
+		return 0, nil
+	}
+	client, closer, err := a.instancesClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	defer closer()
+
+	if state.StopOperations == nil {
+		state.StopOperations = make(map[string]string, len(state.Vms))
+	}
+	var errs []error
+	for _, vm := range state.Vms {
+		op, err := client.Stop(ctx, &computepb.StopInstanceRequest{Project: state.ProjectID, Zone: state.Zone, Instance: vm})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stop vm '%s': %w", vm, err))
+			continue
+		}
+		state.StopOperations[vm] = ""
+		if op != nil {
+			state.StopOperations[vm] = op.Name()
+		}
+	}
+	return len(state.StopOperations), errors.Join(errs...)
+}
+
+func (a *zoneOutageAttack) recreateMigInstances(ctx context.Context, state *ZoneOutageState) (int, error) {
+	var errs []error
+	recreated := 0
+	for _, mig := range state.Migs {
+		var err error
+		switch mig.Scope {
+		case "zonal":
+			err = a.recreateZonal(ctx, state.ProjectID, mig)
+		case "regional":
+			err = a.recreateRegional(ctx, state.ProjectID, mig)
+		default:
+			err = fmt.Errorf("unsupported MIG scope %q", mig.Scope)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recreate instances of MIG %s/%s: %w", mig.Location, mig.Name, err))
+			continue
+		}
+		recreated += len(mig.Instances)
+	}
+	return recreated, errors.Join(errs...)
+}
+
+func (a *zoneOutageAttack) recreateZonal(ctx context.Context, projectID string, mig ZoneOutageMig) error {
+	client, closer, err := a.zonalMigClientProvider(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	_, err = client.RecreateInstances(ctx, &computepb.RecreateInstancesInstanceGroupManagerRequest{
+		Project:              projectID,
+		Zone:                 mig.Location,
+		InstanceGroupManager: mig.Name,
+		InstanceGroupManagersRecreateInstancesRequestResource: &computepb.InstanceGroupManagersRecreateInstancesRequest{
+			Instances: mig.Instances,
+		},
+	})
+	return err
+}
+
+func (a *zoneOutageAttack) recreateRegional(ctx context.Context, projectID string, mig ZoneOutageMig) error {
+	client, closer, err := a.regionalMigClientProvider(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	_, err = client.RecreateInstances(ctx, &computepb.RecreateInstancesRegionInstanceGroupManagerRequest{
+		Project:              projectID,
+		Region:               mig.Location,
+		InstanceGroupManager: mig.Name,
+		RegionInstanceGroupManagersRecreateRequestResource: &computepb.RegionInstanceGroupManagersRecreateRequest{
+			Instances: mig.Instances,
+		},
+	})
+	return err
+}
+
+func (a *zoneOutageAttack) Stop(ctx context.Context, state *ZoneOutageState) (*action_kit_api.StopResult, error) {
+	if state.DryRun || len(state.StopOperations) == 0 {
+		return nil, nil
+	}
+	if err := a.restartVms(ctx, state); err != nil {
+		log.Error().Err(err).Msgf("Failed to restart VMs stopped by the zone outage in %s", state.Zone)
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to restart VMs stopped by the zone outage in %s", state.Zone), err)
+	}
+	return &action_kit_api.StopResult{
+		Messages: extutil.Ptr([]action_kit_api.Message{{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Start requested for %d VM(s) in zone %s", len(state.StopOperations), state.Zone),
+		}}),
+	}, nil
+}
+
+// restartVms waits for each stop operation before issuing the start, because Compute rejects starting an instance that
+// is still STOPPING. Starting a VM that is already RUNNING succeeds, which keeps a retried Stop harmless.
+func (a *zoneOutageAttack) restartVms(ctx context.Context, state *ZoneOutageState) error {
+	client, closer, err := a.instancesClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closer()
+	ops, closeOps, err := a.operationsClientProvider(ctx, state.ProjectID)
+	if err != nil {
+		return err
+	}
+	defer closeOps()
+
+	vms := make([]string, 0, len(state.StopOperations))
+	for vm := range state.StopOperations {
+		vms = append(vms, vm)
+	}
+	sort.Strings(vms)
+
+	var errs []error
+	for _, vm := range vms {
+		if opName := state.StopOperations[vm]; opName != "" {
+			if err := utils.WaitForZoneOperation(ctx, ops, state.ProjectID, state.Zone, opName); err != nil {
+				errs = append(errs, fmt.Errorf("wait for stop of vm '%s': %w", vm, err))
+				continue
+			}
+		}
+		if _, err := client.Start(ctx, &computepb.StartInstanceRequest{Project: state.ProjectID, Zone: state.Zone, Instance: vm}); err != nil {
+			errs = append(errs, fmt.Errorf("start vm '%s': %w", vm, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// selectStandaloneVms returns the sorted names of RUNNING VMs carrying all given labels. MIG-managed VMs are
+// skipped and counted: a MIG restarts instances stopped behind its back, so they are recreated via the MIG instead.
+func selectStandaloneVms(instances []*computepb.Instance, labels map[string]string) ([]string, int) {
+	vms := make([]string, 0)
+	managed := 0
+	for _, inst := range instances {
+		if inst.GetStatus() != computepb.Instance_RUNNING.String() || !hasLabels(inst, labels) {
+			continue
+		}
+		if isMigManaged(inst) {
+			managed++
+			continue
+		}
+		vms = append(vms, inst.GetName())
+	}
+	sort.Strings(vms)
+	return vms, managed
+}
+
+func hasLabels(inst *computepb.Instance, labels map[string]string) bool {
+	for k, v := range labels {
+		if actual, ok := inst.GetLabels()[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+// isMigManaged relies on the "created-by" metadata entry Compute sets on every instance a MIG creates.
+func isMigManaged(inst *computepb.Instance) bool {
+	for _, item := range inst.GetMetadata().GetItems() {
+		if item.GetKey() == "created-by" && strings.Contains(item.GetValue(), "/instanceGroupManagers/") {
+			return true
+		}
+	}
+	return false
+}
+
+// runningInstancesInZone filters managed instance URLs (…/zones/<zone>/instances/<name>) down to RUNNING ones in zone.
+func runningInstancesInZone(managed []*computepb.ManagedInstance, zone string) []string {
+	result := make([]string, 0)
+	for _, mi := range managed {
+		if mi.GetInstanceStatus() == "RUNNING" && strings.Contains(mi.GetInstance(), "/zones/"+zone+"/") {
+			result = append(result, mi.GetInstance())
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func blastRadius(state *ZoneOutageState) string {
+	migInstances := 0
+	migNames := make([]string, 0, len(state.Migs))
+	for _, mig := range state.Migs {
+		migInstances += len(mig.Instances)
+		migNames = append(migNames, fmt.Sprintf("%s (%d)", mig.Name, len(mig.Instances)))
+	}
+	msg := fmt.Sprintf("Zone outage in %s affects %d standalone VM(s) and %d MIG instance(s) across %d MIG(s).", state.Zone, len(state.Vms), migInstances, len(state.Migs))
+	if len(state.Vms) > 0 {
+		msg += " VMs to stop: " + strings.Join(state.Vms, ", ") + "."
+	}
+	if len(migNames) > 0 {
+		msg += " MIGs to recreate instances in: " + strings.Join(migNames, ", ") + "."
+	}
+	return msg
+}
+
+func mustHave(attrs map[string][]string, key string) string {
+	v, ok := attrs[key]
+	if !ok || len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extzone
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInstances struct {
+	stopErr  map[string]error
+	stopped  []string
+	started  []string
+	startErr error
+}
+
+func (f *fakeInstances) List(context.Context, *computepb.ListInstancesRequest, ...gax.CallOption) *compute.InstanceIterator {
+	return nil
+}
+
+func (f *fakeInstances) Stop(_ context.Context, req *computepb.StopInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	if err := f.stopErr[req.Instance]; err != nil {
+		return nil, err
+	}
+	f.stopped = append(f.stopped, req.Instance)
+	return nil, nil
+}
+
+func (f *fakeInstances) Start(_ context.Context, req *computepb.StartInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	f.started = append(f.started, req.Instance)
+	return nil, f.startErr
+}
+
+type fakeOperations struct {
+	waited []string
+	// pending is the number of Wait calls answered with RUNNING before DONE.
+	pending int
+	// failed holds the error message of operations that end DONE but unsuccessful.
+	failed map[string]string
+}
+
+func (f *fakeOperations) Wait(_ context.Context, req *computepb.WaitZoneOperationRequest, _ ...gax.CallOption) (*computepb.Operation, error) {
+	f.waited = append(f.waited, req.Operation)
+	if f.pending > 0 {
+		f.pending--
+		return &computepb.Operation{Status: extutil.Ptr(computepb.Operation_RUNNING)}, nil
+	}
+	op := &computepb.Operation{Status: extutil.Ptr(computepb.Operation_DONE)}
+	if msg, ok := f.failed[req.Operation]; ok {
+		op.Error = &computepb.Error{Errors: []*computepb.Errors{{Code: extutil.Ptr("RESOURCE_NOT_READY"), Message: extutil.Ptr(msg)}}}
+	}
+	return op, nil
+}
+
+type fakeZonalMigs struct {
+	recreated map[string][]string
+}
+
+func (f *fakeZonalMigs) List(context.Context, *computepb.ListInstanceGroupManagersRequest, ...gax.CallOption) *compute.InstanceGroupManagerIterator {
+	return nil
+}
+
+func (f *fakeZonalMigs) ListManagedInstances(context.Context, *computepb.ListManagedInstancesInstanceGroupManagersRequest, ...gax.CallOption) *compute.ManagedInstanceIterator {
+	return nil
+}
+
+func (f *fakeZonalMigs) RecreateInstances(_ context.Context, req *computepb.RecreateInstancesInstanceGroupManagerRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	f.recreated[req.Zone+"/"+req.InstanceGroupManager] = req.InstanceGroupManagersRecreateInstancesRequestResource.Instances
+	return nil, nil
+}
+
+type fakeRegionalMigs struct {
+	recreated map[string][]string
+}
+
+func (f *fakeRegionalMigs) List(context.Context, *computepb.ListRegionInstanceGroupManagersRequest, ...gax.CallOption) *compute.InstanceGroupManagerIterator {
+	return nil
+}
+
+func (f *fakeRegionalMigs) ListManagedInstances(context.Context, *computepb.ListManagedInstancesRegionInstanceGroupManagersRequest, ...gax.CallOption) *compute.ManagedInstanceIterator {
+	return nil
+}
+
+func (f *fakeRegionalMigs) RecreateInstances(_ context.Context, req *computepb.RecreateInstancesRegionInstanceGroupManagerRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	f.recreated[req.Region+"/"+req.InstanceGroupManager] = req.RegionInstanceGroupManagersRecreateRequestResource.Instances
+	return nil, nil
+}
+
+func newOutageTestAttack(instances *fakeInstances, ops *fakeOperations, zonal *fakeZonalMigs, regional *fakeRegionalMigs) *zoneOutageAttack {
+	return &zoneOutageAttack{
+		instancesClientProvider: func(context.Context, string) (zoneInstancesApi, func(), error) {
+			return instances, func() {}, nil
+		},
+		operationsClientProvider: func(context.Context, string) (zoneOperationsApi, func(), error) {
+			return ops, func() {}, nil
+		},
+		zonalMigClientProvider: func(context.Context, string) (zonalMigApi, func(), error) {
+			return zonal, func() {}, nil
+		},
+		regionalMigClientProvider: func(context.Context, string) (regionalMigApi, func(), error) {
+			return regional, func() {}, nil
+		},
+	}
+}
+
+func TestZoneOutage_Describe(t *testing.T) {
+	a := &zoneOutageAttack{}
+	desc := a.Describe()
+	assert.Equal(t, ZoneOutageActionId, desc.Id)
+	assert.Equal(t, TargetIDZone, desc.TargetSelection.TargetType)
+	assert.Equal(t, action_kit_api.TimeControlExternal, desc.TimeControl)
+	assert.NotNil(t, desc.Stop)
+	assert.Equal(t, ZoneOutageState{}, a.NewEmptyState())
+}
+
+func TestZoneOutage_NewAction(t *testing.T) {
+	assert.NotNil(t, NewZoneOutageAction())
+}
+
+func TestZoneOutage_Prepare_MissingRequiredAttr(t *testing.T) {
+	valid := map[string][]string{attrProjectID: {"proj-a"}, attrZone: {"us-central1-a"}}
+	for _, drop := range []string{attrProjectID, attrZone} {
+		attrs := map[string][]string{}
+		for k, v := range valid {
+			if k != drop {
+				attrs[k] = v
+			}
+		}
+		a := &zoneOutageAttack{}
+		state := ZoneOutageState{}
+		_, err := a.Prepare(context.Background(), &state, extutil.JsonMangle(action_kit_api.PrepareActionRequestBody{
+			Target: extutil.Ptr(action_kit_api.Target{Attributes: attrs}),
+		}))
+		require.Error(t, err, "dropping %s should fail Prepare", drop)
+		assert.Contains(t, err.Error(), "missing")
+	}
+}
+
+func TestSelectStandaloneVms(t *testing.T) {
+	migMetadata := &computepb.Metadata{Items: []*computepb.Items{{
+		Key:   extutil.Ptr("created-by"),
+		Value: extutil.Ptr("projects/123/zones/us-central1-a/instanceGroupManagers/web"),
+	}}}
+	instances := []*computepb.Instance{
+		{Name: extutil.Ptr("db-2"), Status: extutil.Ptr("RUNNING"), Labels: map[string]string{"env": "prod", "tier": "db"}},
+		{Name: extutil.Ptr("db-1"), Status: extutil.Ptr("RUNNING"), Labels: map[string]string{"env": "prod", "tier": "db"}},
+		{Name: extutil.Ptr("db-stopped"), Status: extutil.Ptr("TERMINATED"), Labels: map[string]string{"env": "prod", "tier": "db"}},
+		{Name: extutil.Ptr("dev"), Status: extutil.Ptr("RUNNING"), Labels: map[string]string{"env": "dev", "tier": "db"}},
+		{Name: extutil.Ptr("web-abcd"), Status: extutil.Ptr("RUNNING"), Labels: map[string]string{"env": "prod", "tier": "db"}, Metadata: migMetadata},
+	}
+
+	vms, managed := selectStandaloneVms(instances, map[string]string{"env": "prod"})
+	assert.Equal(t, []string{"db-1", "db-2"}, vms)
+	assert.Equal(t, 1, managed)
+
+	vms, _ = selectStandaloneVms(instances, map[string]string{})
+	assert.Equal(t, []string{"db-1", "db-2", "dev"}, vms)
+}
+
+func TestRunningInstancesInZone(t *testing.T) {
+	managed := []*computepb.ManagedInstance{
+		{Instance: extutil.Ptr("https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-b/instances/web-2"), InstanceStatus: extutil.Ptr("RUNNING")},
+		{Instance: extutil.Ptr("https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-1"), InstanceStatus: extutil.Ptr("RUNNING")},
+		{Instance: extutil.Ptr("https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-3"), InstanceStatus: extutil.Ptr("STAGING")},
+	}
+	assert.Equal(t, []string{"https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-1"}, runningInstancesInZone(managed, "us-central1-a"))
+}
+
+func TestBlastRadius(t *testing.T) {
+	msg := blastRadius(&ZoneOutageState{
+		Zone: "us-central1-a",
+		Vms:  []string{"db-1", "db-2"},
+		Migs: []ZoneOutageMig{{Name: "web", Instances: []string{"a", "b"}}, {Name: "api", Instances: []string{"c"}}},
+	})
+	assert.Equal(t, "Zone outage in us-central1-a affects 2 standalone VM(s) and 3 MIG instance(s) across 2 MIG(s). VMs to stop: db-1, db-2. MIGs to recreate instances in: web (2), api (1).", msg)
+}
+
+func TestZoneOutage_StartDryRunChangesNothing(t *testing.T) {
+	instances := &fakeInstances{}
+	a := newOutageTestAttack(instances, nil, nil, nil)
+	state := &ZoneOutageState{ProjectID: "p", Zone: "us-central1-a", DryRun: true, Vms: []string{"db-1"}}
+
+	res, err := a.Start(context.Background(), state)
+
+	require.NoError(t, err)
+	assert.Contains(t, (*res.Messages)[0].Message, "Dry run")
+	assert.Empty(t, instances.stopped)
+
+	stopRes, err := a.Stop(context.Background(), state)
+	require.NoError(t, err)
+	assert.Nil(t, stopRes)
+}
+
+func TestZoneOutage_StartStopsVmsAndRecreatesMigInstances(t *testing.T) {
+	instances := &fakeInstances{}
+	zonal := &fakeZonalMigs{recreated: map[string][]string{}}
+	regional := &fakeRegionalMigs{recreated: map[string][]string{}}
+	a := newOutageTestAttack(instances, nil, zonal, regional)
+	state := &ZoneOutageState{
+		ProjectID: "p",
+		Zone:      "us-central1-a",
+		Region:    "us-central1",
+		Vms:       []string{"db-1", "db-2"},
+		Migs: []ZoneOutageMig{
+			{Scope: "zonal", Location: "us-central1-a", Name: "web", Instances: []string{"web-1"}},
+			{Scope: "regional", Location: "us-central1", Name: "api", Instances: []string{"api-1", "api-2"}},
+		},
+	}
+
+	res, err := a.Start(context.Background(), state)
+
+	require.NoError(t, err)
+	assert.Contains(t, (*res.Messages)[0].Message, "2 VM(s)")
+	assert.Contains(t, (*res.Messages)[0].Message, "3 MIG instance(s)")
+	assert.Equal(t, []string{"db-1", "db-2"}, instances.stopped)
+	assert.Equal(t, map[string]string{"db-1": "", "db-2": ""}, state.StopOperations)
+	assert.Equal(t, []string{"web-1"}, zonal.recreated["us-central1-a/web"])
+	assert.Equal(t, []string{"api-1", "api-2"}, regional.recreated["us-central1/api"])
+}
+
+func TestZoneOutage_StartRecordsPartialProgress(t *testing.T) {
+	instances := &fakeInstances{stopErr: map[string]error{"db-2": errors.New("boom")}}
+	a := newOutageTestAttack(instances, nil, nil, nil)
+	state := &ZoneOutageState{ProjectID: "p", Zone: "us-central1-a", Vms: []string{"db-1", "db-2", "db-3"}}
+
+	_, err := a.Start(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partially applied")
+	assert.Equal(t, map[string]string{"db-1": "", "db-3": ""}, state.StopOperations)
+}
+
+func TestZoneOutage_StopWaitsAndStartsStoppedVms(t *testing.T) {
+	instances := &fakeInstances{}
+	ops := &fakeOperations{pending: 1}
+	a := newOutageTestAttack(instances, ops, nil, nil)
+	state := &ZoneOutageState{ProjectID: "p", Zone: "us-central1-a", StopOperations: map[string]string{"db-2": "op-2", "db-1": "op-1"}}
+
+	res, err := a.Stop(context.Background(), state)
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, []string{"op-1", "op-1", "op-2"}, ops.waited)
+	assert.Equal(t, []string{"db-1", "db-2"}, instances.started)
+}
+
+func TestZoneOutage_StopReportsStartFailure(t *testing.T) {
+	instances := &fakeInstances{startErr: errors.New("boom")}
+	a := newOutageTestAttack(instances, &fakeOperations{}, nil, nil)
+	state := &ZoneOutageState{ProjectID: "p", Zone: "us-central1-a", StopOperations: map[string]string{"db-1": "op-1"}}
+
+	_, err := a.Stop(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to restart")
+}
+
+func TestZoneOutage_StopReportsFailedStopOperation(t *testing.T) {
+	instances := &fakeInstances{}
+	ops := &fakeOperations{failed: map[string]string{"op-1": "instance is being modified"}}
+	a := newOutageTestAttack(instances, ops, nil, nil)
+	state := &ZoneOutageState{ProjectID: "p", Zone: "us-central1-a", StopOperations: map[string]string{"db-1": "op-1", "db-2": "op-2"}}
+
+	_, err := a.Stop(context.Background(), state)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "instance is being modified")
+	assert.Equal(t, []string{"db-2"}, instances.started)
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extzone
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-gcp/config"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// zoneDiscovery derives zones from the targets of the VM, disk and MIG discoveries instead of listing them itself, so
+// only zones with workloads become targets. A source is nil when its discovery is disabled.
+type zoneDiscovery struct {
+	vms   discovery_kit_sdk.TargetDiscovery
+	disks discovery_kit_sdk.TargetDiscovery
+	migs  discovery_kit_sdk.TargetDiscovery
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*zoneDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*zoneDiscovery)(nil)
+)
+
+// NewZoneDiscovery builds the zone discovery on top of the registered VM, disk and MIG discoveries. Pass nil for a
+// discovery that is not enabled; its resources are then not counted.
+func NewZoneDiscovery(vms, disks, migs discovery_kit_sdk.TargetDiscovery) discovery_kit_sdk.TargetDiscovery {
+	return discovery_kit_sdk.NewCachedTargetDiscovery(&zoneDiscovery{vms: vms, disks: disks, migs: migs},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 60*time.Second),
+	)
+}
+
+func (d *zoneDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id:       TargetIDZone,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{CallInterval: extutil.Ptr("60s")},
+	}
+}
+
+func (d *zoneDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       TargetIDZone,
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     extutil.Ptr(targetIcon),
+		Label:    discovery_kit_api.PluralLabel{One: "Zone", Other: "Zones"},
+		Category: extutil.Ptr("cloud"),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: attrRegion},
+				{Attribute: attrVmCount},
+				{Attribute: attrDiskCount},
+				{Attribute: attrMigCount},
+				{Attribute: attrProjectID},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{{Attribute: "steadybit.label", Direction: "ASC"}},
+		},
+	}
+}
+
+func (d *zoneDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{Attribute: attrVmCount, Label: discovery_kit_api.PluralLabel{One: "Zone VM count", Other: "Zone VM counts"}},
+		{Attribute: attrDiskCount, Label: discovery_kit_api.PluralLabel{One: "Zone disk count", Other: "Zone disk counts"}},
+		{Attribute: attrMigCount, Label: discovery_kit_api.PluralLabel{One: "Zone MIG count", Other: "Zone MIG counts"}},
+	}
+}
+
+// DiscoverTargets reads the cached targets of the source discoveries, so it issues no API calls of its own. Right
+// after startup the sources may not have discovered anything yet; the zones then appear with the next refresh.
+func (d *zoneDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	byZone := make(map[projectZone]*zoneResources)
+	count := func(source discovery_kit_sdk.TargetDiscovery, name string, zoneOf func(discovery_kit_api.Target) string, add func(*zoneResources)) error {
+		if source == nil {
+			return nil
+		}
+		targets, err := source.DiscoverTargets(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to read %s targets for zone discovery", name)
+			return err
+		}
+		for _, t := range targets {
+			key := projectZone{project: mustHave(t.Attributes, attrProjectID), zone: zoneOf(t)}
+			if key.project == "" || key.zone == "" {
+				continue
+			}
+			if byZone[key] == nil {
+				byZone[key] = &zoneResources{}
+			}
+			add(byZone[key])
+		}
+		return nil
+	}
+
+	if err := count(d.vms, "VM", vmZone, func(r *zoneResources) { r.vms++ }); err != nil {
+		return nil, err
+	}
+	if err := count(d.disks, "persistent disk", diskZone, func(r *zoneResources) { r.disks++ }); err != nil {
+		return nil, err
+	}
+	if err := count(d.migs, "MIG", migZone, func(r *zoneResources) { r.migs++ }); err != nil {
+		return nil, err
+	}
+	return discovery_kit_commons.ApplyAttributeExcludes(toZoneTargets(byZone), config.Config.DiscoveryAttributesExcludesZone), nil
+}
+
+type projectZone struct {
+	project string
+	zone    string
+}
+
+// zoneResources counts the targets the source discoveries report in one zone.
+type zoneResources struct {
+	vms   int
+	disks int
+	migs  int
+}
+
+func vmZone(t discovery_kit_api.Target) string {
+	return mustHave(t.Attributes, attrZone)
+}
+
+// diskZone is empty for regional disks, which are replicated across two zones and survive the loss of one.
+func diskZone(t discovery_kit_api.Target) string {
+	return mustHave(t.Attributes, attrDiskZone)
+}
+
+// migZone is empty for regional MIGs: they span several zones and do not pin the zone they are in.
+func migZone(t discovery_kit_api.Target) string {
+	if mustHave(t.Attributes, attrMigScope) != "zonal" {
+		return ""
+	}
+	return mustHave(t.Attributes, attrMigLocation)
+}
+
+func toZoneTargets(byZone map[projectZone]*zoneResources) []discovery_kit_api.Target {
+	keys := make([]projectZone, 0, len(byZone))
+	for key := range byZone {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].project != keys[j].project {
+			return keys[i].project < keys[j].project
+		}
+		return keys[i].zone < keys[j].zone
+	})
+
+	targets := make([]discovery_kit_api.Target, 0, len(keys))
+	for _, key := range keys {
+		r := byZone[key]
+		targets = append(targets, discovery_kit_api.Target{
+			Id:         fmt.Sprintf("projects/%s/zones/%s", key.project, key.zone),
+			TargetType: TargetIDZone,
+			Label:      key.zone,
+			Attributes: map[string][]string{
+				attrZone:      {key.zone},
+				attrRegion:    {regionOfZone(key.zone)},
+				attrProjectID: {key.project},
+				attrVmCount:   {strconv.Itoa(r.vms)},
+				attrDiskCount: {strconv.Itoa(r.disks)},
+				attrMigCount:  {strconv.Itoa(r.migs)},
+			},
+		})
+	}
+	return targets
+}
+
+// regionOfZone strips the zone suffix, e.g. us-central1-a → us-central1.
+func regionOfZone(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package extzone
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource stands in for the VM, disk and MIG discoveries; only DiscoverTargets is used.
+type fakeSource struct {
+	targets []discovery_kit_api.Target
+	err     error
+}
+
+func (f *fakeSource) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{}
+}
+
+func (f *fakeSource) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{}
+}
+
+func (f *fakeSource) DiscoverTargets(context.Context) ([]discovery_kit_api.Target, error) {
+	return f.targets, f.err
+}
+
+func target(attributes map[string][]string) discovery_kit_api.Target {
+	return discovery_kit_api.Target{Attributes: attributes}
+}
+
+func TestZoneDiscovery_DerivesZonesFromSources(t *testing.T) {
+	d := &zoneDiscovery{
+		vms: &fakeSource{targets: []discovery_kit_api.Target{
+			target(map[string][]string{attrZone: {"us-central1-a"}, attrProjectID: {"proj-a"}}),
+			target(map[string][]string{attrZone: {"us-central1-a"}, attrProjectID: {"proj-a"}}),
+			target(map[string][]string{attrZone: {"us-central1-b"}, attrProjectID: {"proj-a"}}),
+			target(map[string][]string{attrZone: {"us-central1-a"}, attrProjectID: {"proj-b"}}),
+		}},
+		disks: &fakeSource{targets: []discovery_kit_api.Target{
+			target(map[string][]string{attrDiskZone: {"us-central1-a"}, attrProjectID: {"proj-a"}}),
+			// Regional disks carry no zone.
+			target(map[string][]string{attrRegion: {"us-central1"}, attrProjectID: {"proj-a"}}),
+			target(map[string][]string{attrDiskZone: {"europe-west1-c"}, attrProjectID: {"proj-a"}}),
+		}},
+		migs: &fakeSource{targets: []discovery_kit_api.Target{
+			target(map[string][]string{attrMigScope: {"zonal"}, attrMigLocation: {"us-central1-a"}, attrProjectID: {"proj-a"}}),
+			target(map[string][]string{attrMigScope: {"regional"}, attrMigLocation: {"us-central1"}, attrProjectID: {"proj-a"}}),
+		}},
+	}
+
+	targets, err := d.DiscoverTargets(context.Background())
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.Id)
+	}
+	assert.Equal(t, []string{
+		"projects/proj-a/zones/europe-west1-c",
+		"projects/proj-a/zones/us-central1-a",
+		"projects/proj-a/zones/us-central1-b",
+		"projects/proj-b/zones/us-central1-a",
+	}, ids)
+
+	zone := targets[1]
+	assert.Equal(t, "us-central1-a", zone.Label)
+	assert.Equal(t, TargetIDZone, zone.TargetType)
+	assert.Equal(t, []string{"us-central1-a"}, zone.Attributes[attrZone])
+	assert.Equal(t, []string{"us-central1"}, zone.Attributes[attrRegion])
+	assert.Equal(t, []string{"proj-a"}, zone.Attributes[attrProjectID])
+	assert.Equal(t, []string{"2"}, zone.Attributes[attrVmCount])
+	assert.Equal(t, []string{"1"}, zone.Attributes[attrDiskCount])
+	assert.Equal(t, []string{"1"}, zone.Attributes[attrMigCount])
+}
+
+func TestZoneDiscovery_SkipsDisabledSources(t *testing.T) {
+	d := &zoneDiscovery{
+		vms: &fakeSource{targets: []discovery_kit_api.Target{
+			target(map[string][]string{attrZone: {"us-central1-a"}, attrProjectID: {"proj-a"}}),
+		}},
+	}
+
+	targets, err := d.DiscoverTargets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, []string{"1"}, targets[0].Attributes[attrVmCount])
+	assert.Equal(t, []string{"0"}, targets[0].Attributes[attrDiskCount])
+	assert.Equal(t, []string{"0"}, targets[0].Attributes[attrMigCount])
+}
+
+func TestZoneDiscovery_FailsWhenSourceFails(t *testing.T) {
+	d := &zoneDiscovery{
+		vms:   &fakeSource{},
+		disks: &fakeSource{err: errors.New("boom")},
+	}
+
+	_, err := d.DiscoverTargets(context.Background())
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestRegionOfZone(t *testing.T) {
+	assert.Equal(t, "europe-west1", regionOfZone("europe-west1-b"))
+	assert.Equal(t, "nozone", regionOfZone("nozone"))
+}
+
+func TestZoneDiscovery_Describe(t *testing.T) {
+	d := &zoneDiscovery{}
+	assert.Equal(t, TargetIDZone, d.Describe().Id)
+	assert.Equal(t, TargetIDZone, d.DescribeTarget().Id)
+	assert.NotEmpty(t, d.DescribeAttributes())
+}
@@ -21,11 +21,13 @@ require (
 
 require (
 	cloud.google.com/go/container v1.53.1
+	cloud.google.com/go/memorystore v1.3.0
 	cloud.google.com/go/pubsub/v2 v2.6.1
 	cloud.google.com/go/redis v1.25.0
 	cloud.google.com/go/run v1.22.0
 	cloud.google.com/go/spanner v1.94.0
 	github.com/KimMachineGun/automemlimit v0.7.5
+	github.com/google/uuid v1.6.0
 	google.golang.org/grpc v1.83.0
 )
 
@@ -66,7 +68,6 @@ require (
 	github.com/go-resty/resty/v2 v2.17.2 // indirect
 	github.com/google/gnostic-models v0.7.1 // indirect
 	github.com/google/s2a-go v0.1.9 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/googleapis/enterprise-certificate-proxy v0.3.21 // indirect
 	github.com/gorilla/websocket v1.5.4-0.20250319132907-e064f32e3674 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
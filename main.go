@@ -21,6 +21,7 @@ import (
 	"github.com/steadybit/extension-gcp/extpubsub"
 	"github.com/steadybit/extension-gcp/extspanner"
 	"github.com/steadybit/extension-gcp/extvm"
+	"github.com/steadybit/extension-gcp/extzone"
 	"github.com/steadybit/extension-gcp/utils"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/exthealth"
@@ -59,8 +60,11 @@ func main() {
 	// This is a section you will most likely want to change: The registration of HTTP handlers
 	// for your extension. You might want to change these because the names do not fit, or because
 	// you do not have a need for all of them.
-	discovery_kit_sdk.Register(extvm.NewVirtualMachineDiscovery())
+	vmDiscovery := extvm.NewVirtualMachineDiscovery()
+	discovery_kit_sdk.Register(vmDiscovery)
 	action_kit_sdk.RegisterAction(extvm.NewVirtualMachineStateAction())
+	action_kit_sdk.RegisterAction(extvm.NewVirtualMachineStopAction())
+	action_kit_sdk.RegisterAction(extvm.NewVirtualMachineBlackholeAction())
 
 	// Opt-in modules added in feat/expand-gcp-targets-and-attacks. All disabled by default.
 	if config.Config.DiscoveryEnableGkeCluster {
@@ -69,9 +73,14 @@ func main() {
 	if config.Config.DiscoveryEnableGkeNodePool {
 		discovery_kit_sdk.Register(extgke.NewNodePoolDiscovery())
 		action_kit_sdk.RegisterAction(extgke.NewNodePoolTerminateInstancesAction())
+		action_kit_sdk.RegisterAction(extgke.NewNodePoolStopInstancesAction())
+		action_kit_sdk.RegisterAction(extgke.NewNodePoolZoneFailureAction())
 	}
+	// The zone discovery derives its zones from these, so it only counts the sources that are enabled.
+	var migDiscovery, diskDiscovery discovery_kit_sdk.TargetDiscovery
 	if config.Config.DiscoveryEnableMig {
-		discovery_kit_sdk.Register(extmig.NewMigDiscovery())
+		migDiscovery = extmig.NewMigDiscovery()
+		discovery_kit_sdk.Register(migDiscovery)
 		action_kit_sdk.RegisterAction(extmig.NewMigDeleteInstancesAction())
 	}
 	if config.Config.DiscoveryEnableCloudNat {
@@ -79,27 +88,65 @@ func main() {
 		action_kit_sdk.RegisterAction(extnat.NewCloudNatDisassociateAction())
 	}
 	if config.Config.DiscoveryEnablePersistentDisk {
-		discovery_kit_sdk.Register(extdisk.NewDiskDiscovery())
+		diskDiscovery = extdisk.NewDiskDiscovery()
+		discovery_kit_sdk.Register(diskDiscovery)
+		action_kit_sdk.RegisterAction(extdisk.NewDiskDetachAction())
+		action_kit_sdk.RegisterAction(extdisk.NewDiskThrottleAction())
 	}
 	if config.Config.DiscoveryEnableCloudSql {
 		discovery_kit_sdk.Register(extcloudsql.NewInstanceDiscovery())
 		action_kit_sdk.RegisterAction(extcloudsql.NewInstanceFailoverAction())
+		action_kit_sdk.RegisterAction(extcloudsql.NewInstanceRestartAction())
+		action_kit_sdk.RegisterAction(extcloudsql.NewInstanceStopAction())
+		action_kit_sdk.RegisterAction(extcloudsql.NewInstanceStopReplicaAction())
 	}
 	if config.Config.DiscoveryEnableSpanner {
 		discovery_kit_sdk.Register(extspanner.NewInstanceDiscovery())
+		action_kit_sdk.RegisterAction(extspanner.NewInstanceCapacityReductionAction())
+	}
+	if config.Config.DiscoveryEnableSpannerDatabase {
+		discovery_kit_sdk.Register(extspanner.NewDatabaseDiscovery())
+		discovery_kit_sdk.Register(extspanner.NewBackupDiscovery())
+		action_kit_sdk.RegisterAction(extspanner.NewDatabaseLeaderRelocationAction())
 	}
 	if config.Config.DiscoveryEnablePubSubTopic {
 		discovery_kit_sdk.Register(extpubsub.NewTopicDiscovery())
+		action_kit_sdk.RegisterAction(extpubsub.NewTopicFloodAction())
 	}
 	if config.Config.DiscoveryEnablePubSubSubscription {
 		discovery_kit_sdk.Register(extpubsub.NewSubscriptionDiscovery())
+		action_kit_sdk.RegisterAction(extpubsub.NewSubscriptionPauseAction())
+		action_kit_sdk.RegisterAction(extpubsub.NewSubscriptionReplayAction())
 	}
 	if config.Config.DiscoveryEnableMemorystoreRedis {
 		discovery_kit_sdk.Register(extmemorystore.NewRedisDiscovery())
 		action_kit_sdk.RegisterAction(extmemorystore.NewRedisFailoverAction())
+		action_kit_sdk.RegisterAction(extmemorystore.NewRedisMaintenanceAction())
+		action_kit_sdk.RegisterAction(extmemorystore.NewRedisUpgradeAction())
+	}
+	if config.Config.DiscoveryEnableMemorystoreRedisCluster {
+		discovery_kit_sdk.Register(extmemorystore.NewRedisClusterDiscovery())
+	}
+	if config.Config.DiscoveryEnableMemorystoreValkey {
+		discovery_kit_sdk.Register(extmemorystore.NewValkeyDiscovery())
+		action_kit_sdk.RegisterAction(extmemorystore.NewValkeySimulateMaintenanceAction())
 	}
 	if config.Config.DiscoveryEnableCloudRun {
 		discovery_kit_sdk.Register(extcloudrun.NewServiceDiscovery())
+		action_kit_sdk.RegisterAction(extcloudrun.NewServiceTrafficShiftAction())
+		action_kit_sdk.RegisterAction(extcloudrun.NewServiceScalingClampAction())
+		action_kit_sdk.RegisterAction(extcloudrun.NewServiceIngressLockdownAction())
+	}
+	if config.Config.DiscoveryEnableCloudRunRevision {
+		discovery_kit_sdk.Register(extcloudrun.NewRevisionDiscovery())
+	}
+	if config.Config.DiscoveryEnableCloudRunJob {
+		discovery_kit_sdk.Register(extcloudrun.NewJobDiscovery())
+		discovery_kit_sdk.Register(extcloudrun.NewExecutionDiscovery())
+	}
+	if config.Config.DiscoveryEnableZone {
+		discovery_kit_sdk.Register(extzone.NewZoneDiscovery(vmDiscovery, diskDiscovery, migDiscovery))
+		action_kit_sdk.RegisterAction(extzone.NewZoneOutageAction())
 	}
 
 	exthttp.RegisterRevisionedHandler("/", getExtensionList)
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// OperationPollInterval is how often WaitForOperation polls a long-running operation that is not done yet.
+var OperationPollInterval = 5 * time.Second
+
+// OperationPoller reports whether a long-running operation is done. An error with done=true is the operation's own
+// failure; with done=false the poll itself failed.
+type OperationPoller func(ctx context.Context, operation string) (bool, error)
+
+// WaitForOperation polls the operation until it is done and returns its failure, if any.
+func WaitForOperation(ctx context.Context, poll OperationPoller, operation string) error {
+	for {
+		done, err := poll(ctx, operation)
+		if done {
+			if err != nil {
+				return fmt.Errorf("operation %s failed: %w", operation, err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("poll operation %s: %w", operation, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("operation %s still running: %w", operation, ctx.Err())
+		case <-time.After(OperationPollInterval):
+		}
+	}
+}
+
+// OperationStatus maps the outcome of an OperationPoller to a status result: RUNNING keeps the action
+// going, a failed operation ends it with a failed result and a failed poll is returned as an error. Actions that
+// change a resource for a configured duration use it to follow their update, so the update ending never completes
+// them by itself; only its failure ends them early. It returns nil, nil once the operation succeeded, leaving the
+// success result to the caller. service names the API in the error of a failed poll.
+func OperationStatus(done bool, err error, service, failedTitle string) (*action_kit_api.StatusResult, error) {
+	switch {
+	case err != nil && !done:
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to poll %s operation", service), err)
+	case err != nil:
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  failedTitle,
+				Detail: extutil.Ptr(err.Error()),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	case !done:
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	return nil, nil
+}
+
+// ZoneOperationsWaiter is the part of the Compute Engine zone operations client needed to wait for an operation.
+type ZoneOperationsWaiter interface {
+	Wait(ctx context.Context, req *computepb.WaitZoneOperationRequest, opts ...gax.CallOption) (*computepb.Operation, error)
+}
+
+// WaitForZoneOperation blocks until the zonal operation is DONE and returns its failure, if any, as an
+// OperationFailedError. A single Wait call
+// gives up after two minutes, so it is repeated until the operation finishes or ctx ends.
+func WaitForZoneOperation(ctx context.Context, ops ZoneOperationsWaiter, projectID, zone, operation string) error {
+	for {
+		op, err := ops.Wait(ctx, &computepb.WaitZoneOperationRequest{Project: projectID, Zone: zone, Operation: operation})
+		if err != nil {
+			return err
+		}
+		if op.GetStatus() == computepb.Operation_DONE {
+			if detail := OperationErrorDetail(op); detail != "" {
+				return &OperationFailedError{Operation: operation, Detail: detail}
+			}
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// WaitForComputeOperation blocks until an operation returned by a Compute Engine client call is done. Wait only
+// reports transport errors, so the operation's own errors are checked afterwards. A nil operation is a no-op.
+func WaitForComputeOperation(ctx context.Context, op *compute.Operation) error {
+	if op == nil {
+		return nil
+	}
+	if err := op.Wait(ctx); err != nil {
+		return err
+	}
+	if detail := OperationErrorDetail(op.Proto()); detail != "" {
+		return &OperationFailedError{Operation: op.Name(), Detail: detail}
+	}
+	return nil
+}
+
+// OperationFailedError is returned when the operation itself finished with an error, as opposed to waiting for it
+// failing. Callers that can live with a failed operation tell the two apart with errors.As.
+type OperationFailedError struct {
+	Operation string
+	Detail    string
+}
+
+func (e *OperationFailedError) Error() string {
+	return fmt.Sprintf("operation %s failed: %s", e.Operation, e.Detail)
+}
+
+// OperationErrorDetail joins the errors a finished Compute Engine operation reports. It is empty for a successful one.
+func OperationErrorDetail(op *computepb.Operation) string {
+	var details []string
+	for _, e := range op.GetError().GetErrors() {
+		details = append(details, fmt.Sprintf("%s: %s", e.GetCode(), e.GetMessage()))
+	}
+	if len(details) == 0 && op.GetHttpErrorStatusCode() >= 400 {
+		details = append(details, fmt.Sprintf("%d: %s", op.GetHttpErrorStatusCode(), op.GetHttpErrorMessage()))
+	}
+	return strings.Join(details, "; ")
+}
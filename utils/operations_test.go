@@ -0,0 +1,110 @@
+/*
+ * Copyright 2026 steadybit GmbH. All rights reserved.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedZoneOperations answers each Wait call with the next operation of the script.
+type scriptedZoneOperations struct {
+	script []*computepb.Operation
+	calls  int
+}
+
+func (s *scriptedZoneOperations) Wait(context.Context, *computepb.WaitZoneOperationRequest, ...gax.CallOption) (*computepb.Operation, error) {
+	op := s.script[s.calls]
+	s.calls++
+	return op, nil
+}
+
+func TestWaitForZoneOperation_WaitsUntilDone(t *testing.T) {
+	ops := &scriptedZoneOperations{script: []*computepb.Operation{
+		{Status: extutil.Ptr(computepb.Operation_RUNNING)},
+		{Status: extutil.Ptr(computepb.Operation_DONE)},
+	}}
+
+	require.NoError(t, WaitForZoneOperation(context.Background(), ops, "p", "us-central1-a", "op-1"))
+	assert.Equal(t, 2, ops.calls)
+}
+
+func TestWaitForZoneOperation_ReturnsOperationFailure(t *testing.T) {
+	ops := &scriptedZoneOperations{script: []*computepb.Operation{{
+		Status: extutil.Ptr(computepb.Operation_DONE),
+		Error:  &computepb.Error{Errors: []*computepb.Errors{{Code: extutil.Ptr("QUOTA_EXCEEDED"), Message: extutil.Ptr("no quota left")}}},
+	}}}
+
+	err := WaitForZoneOperation(context.Background(), ops, "p", "us-central1-a", "op-1")
+
+	var failed *OperationFailedError
+	require.ErrorAs(t, err, &failed)
+	assert.Equal(t, "op-1", failed.Operation)
+	assert.Contains(t, err.Error(), "QUOTA_EXCEEDED: no quota left")
+}
+
+func TestWaitForComputeOperation_NilOperation(t *testing.T) {
+	require.NoError(t, WaitForComputeOperation(context.Background(), nil))
+}
+
+func TestOperationErrorDetail(t *testing.T) {
+	assert.Empty(t, OperationErrorDetail(&computepb.Operation{Status: extutil.Ptr(computepb.Operation_DONE)}))
+	assert.Equal(t, "403: Forbidden", OperationErrorDetail(&computepb.Operation{
+		HttpErrorStatusCode: extutil.Ptr(int32(403)),
+		HttpErrorMessage:    extutil.Ptr("Forbidden"),
+	}))
+}
+
+func TestWaitForOperation(t *testing.T) {
+	previous := OperationPollInterval
+	OperationPollInterval = time.Millisecond
+	t.Cleanup(func() { OperationPollInterval = previous })
+
+	polls := 0
+	err := WaitForOperation(context.Background(), func(context.Context, string) (bool, error) {
+		polls++
+		return polls == 3, nil
+	}, "op-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+
+	err = WaitForOperation(context.Background(), func(context.Context, string) (bool, error) {
+		return true, errors.New("quota exceeded")
+	}, "op-2")
+	assert.EqualError(t, err, "operation op-2 failed: quota exceeded")
+
+	err = WaitForOperation(context.Background(), func(context.Context, string) (bool, error) {
+		return false, errors.New("unavailable")
+	}, "op-3")
+	assert.EqualError(t, err, "poll operation op-3: unavailable")
+}
+
+func TestOperationStatus(t *testing.T) {
+	result, err := OperationStatus(false, nil, "Memorystore", "Update failed")
+	require.NoError(t, err)
+	assert.False(t, result.Completed)
+
+	result, err = OperationStatus(true, nil, "Memorystore", "Update failed")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	result, err = OperationStatus(true, errors.New("not enough capacity"), "Memorystore", "Update failed")
+	require.NoError(t, err)
+	assert.True(t, result.Completed)
+	assert.Equal(t, "Update failed", result.Error.Title)
+	assert.Equal(t, "not enough capacity", *result.Error.Detail)
+
+	_, err = OperationStatus(false, errors.New("unavailable"), "Memorystore", "Update failed")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to poll Memorystore operation")
+}